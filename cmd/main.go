@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/ZaphCode/clean-arch/config"
 	_ "github.com/ZaphCode/clean-arch/docs" // Swagger docs
@@ -11,21 +12,40 @@ import (
 	addressHandler "github.com/ZaphCode/clean-arch/src/api/handlers/address"
 	authHandler "github.com/ZaphCode/clean-arch/src/api/handlers/auth"
 	cardHandler "github.com/ZaphCode/clean-arch/src/api/handlers/card"
+	cartHandler "github.com/ZaphCode/clean-arch/src/api/handlers/cart"
 	categoryHandler "github.com/ZaphCode/clean-arch/src/api/handlers/category"
+	couponHandler "github.com/ZaphCode/clean-arch/src/api/handlers/coupon"
 	orderHandler "github.com/ZaphCode/clean-arch/src/api/handlers/order"
+	paymentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/payment"
 	productHandler "github.com/ZaphCode/clean-arch/src/api/handlers/product"
+	returnHandler "github.com/ZaphCode/clean-arch/src/api/handlers/returns"
+	reviewHandler "github.com/ZaphCode/clean-arch/src/api/handlers/review"
+	shipmentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipment"
+	shippingHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipping"
 	userHandler "github.com/ZaphCode/clean-arch/src/api/handlers/user"
 	"github.com/ZaphCode/clean-arch/src/api/middlewares"
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/cart"
 	"github.com/ZaphCode/clean-arch/src/repositories/category"
+	"github.com/ZaphCode/clean-arch/src/repositories/coupon"
+	"github.com/ZaphCode/clean-arch/src/repositories/idempotency"
+	invoiceRepo "github.com/ZaphCode/clean-arch/src/repositories/invoice"
 	"github.com/ZaphCode/clean-arch/src/repositories/order"
 	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/returns"
+	"github.com/ZaphCode/clean-arch/src/repositories/review"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipment"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipping"
 	"github.com/ZaphCode/clean-arch/src/repositories/user"
 	"github.com/ZaphCode/clean-arch/src/services/auth"
 	"github.com/ZaphCode/clean-arch/src/services/core"
 	"github.com/ZaphCode/clean-arch/src/services/email"
+	"github.com/ZaphCode/clean-arch/src/services/invoice"
 	"github.com/ZaphCode/clean-arch/src/services/payment"
+	"github.com/ZaphCode/clean-arch/src/services/search"
+	"github.com/ZaphCode/clean-arch/src/services/tax"
+	"github.com/ZaphCode/clean-arch/src/services/upload"
 	"github.com/ZaphCode/clean-arch/src/services/validation"
 	"github.com/ZaphCode/clean-arch/src/utils"
 )
@@ -70,9 +90,21 @@ func setServerConfiguration(server *api.Server, cfg config.Config) {
 		catRepo  domain.CategoryRepository
 		addrRepo domain.AddressRepository
 		ordRepo  domain.OrderRepository
+		cartRepo domain.CartRepository
+		cpnRepo  domain.CouponRepository
+		idemRepo domain.IdempotencyRepository
+		shipRepo domain.ShippingRepository
+		shpRepo  domain.ShipmentRepository
+		retRepo  domain.ReturnRepository
+		invRepo  domain.InvoiceRepository
+		revRepo  domain.ReviewRepository
+		pmSvc    payment.PaymentService
+		uplSvc   upload.UploadService
 	)
 
-	if len(os.Args) > 1 && os.Args[1] == "dev" {
+	dev := len(os.Args) > 1 && os.Args[1] == "dev"
+
+	if dev {
 		//* Development
 		fmt.Println("DEV MODE")
 		//userRepo = user.NewMemoryUserRepository(utils.UserAdmin, utils.UserExp1, utils.UserExp2)
@@ -85,6 +117,23 @@ func setServerConfiguration(server *api.Server, cfg config.Config) {
 		addrRepo = address.NewMemoryPersistentAddressRepository("tmpdata/addresses.json")
 		//ordRepo = order.NewMemoryOrderRepository()
 		ordRepo = order.NewMemoryPersistentOrderRepository("tmpdata/orders.json")
+		//cartRepo = cart.NewMemoryCartRepository()
+		cartRepo = cart.NewMemoryPersistentCartRepository("tmpdata/carts.json")
+		//cpnRepo = coupon.NewMemoryCouponRepository()
+		cpnRepo = coupon.NewMemoryPersistentCouponRepository("tmpdata/coupons.json")
+		idemRepo = idempotency.NewMemoryIdempotencyRepository()
+		//shipRepo = shipping.NewMemoryShippingRepository(utils.ShippingExp1, utils.ShippingExp2)
+		shipRepo = shipping.NewMemoryPersistentShippingRepository("tmpdata/shipping_methods.json")
+		//shpRepo = shipment.NewMemoryShipmentRepository()
+		shpRepo = shipment.NewMemoryPersistentShipmentRepository("tmpdata/shipments.json")
+		//retRepo = returns.NewMemoryReturnRepository()
+		retRepo = returns.NewMemoryPersistentReturnRepository("tmpdata/returns.json")
+		//invRepo = invoiceRepo.NewMemoryInvoiceRepository()
+		invRepo = invoiceRepo.NewMemoryPersistentInvoiceRepository("tmpdata/invoices.json")
+		//revRepo = review.NewMemoryReviewRepository()
+		revRepo = review.NewMemoryPersistentReviewRepository("tmpdata/reviews.json")
+		pmSvc = payment.NewMemoryPaymentService(cfg.Stripe.WebhookSecret, userRepo)
+		uplSvc = upload.NewLocalUploadService(utils.UploadDir, cfg.Api.ServerHost+utils.UploadRoute)
 	} else {
 		//* Production
 		client := utils.GetFirestoreClient(config.GetFirebaseApp())
@@ -93,41 +142,91 @@ func setServerConfiguration(server *api.Server, cfg config.Config) {
 		catRepo = category.NewFirestoreCategoryRepository(client, utils.CategColl)
 		addrRepo = address.NewFirestoreAddressRepository(client, utils.AddrColl)
 		ordRepo = order.NewFirestoreOrderRepository(client, utils.OrderColl)
+		cartRepo = cart.NewFirestoreCartRepository(client, utils.CartColl)
+		cpnRepo = coupon.NewFirestoreCouponRepository(client, utils.CouponColl)
+		idemRepo = idempotency.NewFirestoreIdempotencyRepository(client, utils.IdemColl)
+		shipRepo = shipping.NewFirestoreShippingRepository(client, utils.ShipColl)
+		shpRepo = shipment.NewFirestoreShipmentRepository(client, utils.ShipmentColl)
+		retRepo = returns.NewFirestoreReturnRepository(client, utils.ReturnColl)
+		invRepo = invoiceRepo.NewFirestoreInvoiceRepository(client, utils.InvoiceColl)
+		revRepo = review.NewFirestoreReviewRepository(client, utils.ReviewColl)
+		pmSvc = payment.NewStripePaymentService(cfg.Stripe.SecretKey, cfg.Stripe.WebhookSecret, userRepo)
+		uplSvc = upload.NewFirebaseUploadService(utils.GetStorageClient(config.GetFirebaseApp()), cfg.Storage.Bucket, cfg.Storage.UploadFolder)
+	}
+
+	//* Search index, built from the catalog the first time
+	srchSvc, err := search.NewBleveSearchService(utils.SearchIndexDir, prodRepo.Find)
+
+	if err != nil {
+		log.Fatal("Error opening the search index: ", err)
 	}
 
 	//* Services
 	userSvc := core.NewUserService(userRepo)
-	prodSvc := core.NewProductService(prodRepo, catRepo)
+	prodSvc := core.NewProductService(prodRepo, catRepo, revRepo, srchSvc)
 	catSvc := core.NewCategoryService(catRepo, prodRepo)
 	addrSvc := core.NewAddressService(addrRepo, userRepo)
-	ordSvc := core.NewOrderService(ordRepo, addrRepo)
-	pmSvc := payment.NewStripePaymentService(cfg.Stripe.SecretKey, userRepo)
+	cartSvc := core.NewCartService(cartRepo, prodRepo)
 	emailSvc := email.NewSmtpEmailService()
+	cpnSvc := core.NewCouponService(cpnRepo, prodRepo)
+	ordSvc := core.NewOrderService(ordRepo, addrRepo, prodRepo, cpnSvc, email.NewOrderEmailNotifier(emailSvc, userRepo))
+	shipSvc := core.NewShippingService(shipRepo, addrRepo, prodRepo)
+	shpSvc := core.NewShipmentService(shpRepo, ordSvc)
+	retSvc := core.NewReturnService(retRepo, ordSvc, prodRepo)
+	invSvc := core.NewInvoiceService(invRepo, ordRepo)
+	revSvc := core.NewReviewService(revRepo, ordRepo, prodRepo, userRepo)
+	invGen := invoice.NewInvoiceGenerator(invoice.Issuer{Name: "Clean Arch Store"})
+	taxCalc := tax.MustLoadTableTaxCalculator("./config/tax_rates.json")
+	idemSvc := core.NewIdempotencyService(idemRepo, time.Duration(cfg.Api.IdempotencyWindow)*time.Second)
 	vldSvc := validation.NewValidationService()
 	jwtSvc := auth.NewJWTService()
 
+	//* Without webhook the payment events go straight to the orders
+	if src, ok := pmSvc.(payment.EventSource); ok {
+		src.OnEvent(ordSvc.HandlePaymentEvent)
+	}
+
 	//* Middlewares
 	authMdlw := middlewares.NewAuthMiddleware(jwtSvc)
 	paymMdlw := middlewares.NewPaymentMiddleware(pmSvc)
+	idemMdlw := middlewares.NewIdempotencyMiddleware(idemSvc)
 
 	// Handlers
-	usrHdlr := userHandler.NewUserHandler(userSvc, vldSvc)
+	usrHdlr := userHandler.NewUserHandler(userSvc, uplSvc, vldSvc)
 	addrHdlr := addressHandler.NewAddressHandler(userSvc, addrSvc, vldSvc)
 	authHdlr := authHandler.NewAuthHandler(userSvc, emailSvc, jwtSvc, vldSvc)
-	prodHdlr := productHandler.NewProductHandler(prodSvc, catSvc, vldSvc)
+	prodHdlr := productHandler.NewProductHandler(prodSvc, catSvc, srchSvc, uplSvc, vldSvc)
 	catHdlr := categoryHandler.NewCategoryHandler(prodSvc, catSvc, vldSvc)
 	cardHdlr := cardHandler.NewCardHandler(userSvc, pmSvc, vldSvc)
-	ordHdlr := orderHandler.NewOrderHandler(userSvc, ordSvc, prodSvc, pmSvc, vldSvc)
+	cartHdlr := cartHandler.NewCartHandler(cartSvc, prodSvc, vldSvc)
+	ordHdlr := orderHandler.NewOrderHandler(userSvc, ordSvc, prodSvc, cartSvc, cpnSvc, shipSvc, addrSvc, invSvc, taxCalc, invGen, pmSvc, vldSvc)
+	pmHdlr := paymentHandler.NewPaymentHandler(ordSvc, pmSvc)
+	cpnHdlr := couponHandler.NewCouponHandler(cpnSvc, vldSvc)
+	shipHdlr := shippingHandler.NewShippingHandler(shipSvc, vldSvc)
+	shpHdlr := shipmentHandler.NewShipmentHandler(shpSvc, ordSvc, vldSvc)
+	retHdlr := returnHandler.NewReturnHandler(retSvc, ordSvc, pmSvc, vldSvc)
+	revHdlr := reviewHandler.NewReviewHandler(revSvc, vldSvc)
 
 	//* Setup
 	server.SetGlobalMiddlewares()
 
+	if dev {
+		server.ServeUploads(utils.UploadDir)
+	}
+
 	//* Routes
 	server.CreateAuthRoutes(authHdlr, authMdlw)
 	server.CreateUserRoutes(usrHdlr, authMdlw)
 	server.CreateProductRoutes(prodHdlr, authMdlw)
 	server.CreateCategoryRoutes(catHdlr, authMdlw)
 	server.CreateAddressesRoutes(addrHdlr, authMdlw)
-	server.CreateCardRoutes(cardHdlr, paymMdlw, authMdlw)
-	server.CreateOrderRoutes(ordHdlr, paymMdlw, authMdlw)
+	server.CreateCardRoutes(cardHdlr, paymMdlw, idemMdlw, authMdlw)
+	server.CreateOrderRoutes(ordHdlr, paymMdlw, idemMdlw, authMdlw)
+	server.CreateCartRoutes(cartHdlr, authMdlw)
+	server.CreatePaymentRoutes(pmHdlr)
+	server.CreateCouponRoutes(cpnHdlr, authMdlw)
+	server.CreateShippingRoutes(shipHdlr, authMdlw)
+	server.CreateShipmentRoutes(shpHdlr, authMdlw)
+	server.CreateReturnRoutes(retHdlr, authMdlw)
+	server.CreateReviewRoutes(revHdlr, authMdlw)
 }
@@ -21,6 +21,7 @@ type api struct {
 	AccessTokenHeader  string `json:"access_token_header"`
 	RefreshTokenHeader string `json:"refresh_token_header"`
 	RefreshTokenCookie string `json:"refresh_token_cookie"`
+	IdempotencyWindow  int64  `json:"idempotency_window"` // seconds
 }
 
 type oauthServices struct {
@@ -45,8 +46,9 @@ type smtp struct {
 }
 
 type stripe struct {
-	PublicKey string `json:"public_key"`
-	SecretKey string `json:"secret_key"`
+	PublicKey     string `json:"public_key"`
+	SecretKey     string `json:"secret_key"`
+	WebhookSecret string `json:"webhook_secret"`
 }
 
 type Config struct {
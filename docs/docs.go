@@ -695,6 +695,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dtos.SaveCardDTO"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -719,43 +725,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/category/all": {
-            "get": {
-                "description": "Get all categories",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "category"
-                ],
-                "summary": "Get categories",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CategoriesRespOKDTO"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
-                        }
-                    }
-                }
-            }
-        },
-        "/category/create": {
+        "/cart/add": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create category",
+                "description": "Add a product to the auth user cart. Adding a product that is already there increases its quantity",
                 "consumes": [
                     "application/json"
                 ],
@@ -763,25 +740,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "cart"
                 ],
-                "summary": "Create new category",
+                "summary": "Add item to cart",
                 "parameters": [
                     {
-                        "description": "category data",
-                        "name": "category_data",
+                        "description": "item data",
+                        "name": "item_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.NewCategoryDTO"
+                            "$ref": "#/definitions/dtos.AddCartItemDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryRespOKDTO"
+                            "$ref": "#/definitions/dtos.CartRespOKDTO"
                         }
                     },
                     "400": {
@@ -796,12 +773,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -817,14 +788,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/category/delete/{id}": {
+        "/cart/clear": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete category",
+                "description": "Remove all the products from the auth user cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -832,30 +803,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
-                ],
-                "summary": "Delete category",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "category uuid",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "cart"
                 ],
+                "summary": "Clear cart",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.RespOKDTO"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.CartRespOKDTO"
                         }
                     },
                     "401": {
@@ -864,12 +819,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -879,14 +828,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/list": {
-            "get": {
+        "/cart/remove/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all orders from auth user",
+                "description": "Remove a product from the auth user cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -894,14 +843,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "cart"
+                ],
+                "summary": "Remove cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048",
+                        "description": "variant uuid",
+                        "name": "variant",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get auth user orders",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrdersRespOKDTO"
+                            "$ref": "#/definitions/dtos.CartRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
                     },
                     "401": {
@@ -910,6 +882,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -919,14 +897,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/new": {
-            "post": {
+        "/cart/update/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create order",
+                "description": "Change the quantity of a product in the auth user cart. Zero removes it",
                 "consumes": [
                     "application/json"
                 ],
@@ -934,17 +912,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "cart"
                 ],
-                "summary": "Create new order",
+                "summary": "Update cart item",
                 "parameters": [
                     {
-                        "description": "order data",
-                        "name": "order_data",
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048",
+                        "description": "variant uuid",
+                        "name": "variant",
+                        "in": "query"
+                    },
+                    {
+                        "description": "item data",
+                        "name": "item_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.NewOrderDTO"
+                            "$ref": "#/definitions/dtos.UpdateCartItemDTO"
                         }
                     }
                 ],
@@ -952,7 +945,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderRespOKDTO"
+                            "$ref": "#/definitions/dtos.CartRespOKDTO"
                         }
                     },
                     "400": {
@@ -988,9 +981,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/all": {
+        "/cart/view": {
             "get": {
-                "description": "Get all products",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the auth user cart with the current prices. Items that cannot be bought have an issue",
                 "consumes": [
                     "application/json"
                 ],
@@ -998,14 +996,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "cart"
                 ],
-                "summary": "Get products",
+                "summary": "Get cart",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductsRespOKDTO"
+                            "$ref": "#/definitions/dtos.CartRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
                     "500": {
@@ -1017,14 +1021,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/create": {
+        "/category/all": {
+            "get": {
+                "description": "Get all categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Get categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CategoriesRespOKDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/category/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create product",
+                "description": "Create category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1032,17 +1065,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Create new product",
+                "summary": "Create new category",
                 "parameters": [
                     {
-                        "description": "product data",
-                        "name": "product_data",
+                        "description": "category data",
+                        "name": "category_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.NewProductDTO"
+                            "$ref": "#/definitions/dtos.NewCategoryDTO"
                         }
                     }
                 ],
@@ -1050,7 +1083,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductRespOKDTO"
+                            "$ref": "#/definitions/dtos.CategoryRespOKDTO"
                         }
                     },
                     "400": {
@@ -1086,14 +1119,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/delete/{id}": {
+        "/category/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete product",
+                "description": "Delete category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1101,26 +1134,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Delete product",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "string",
                         "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "product   uuid",
+                        "description": "category uuid",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/dtos.RespOKDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1142,9 +1181,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/get/{id}": {
+        "/coupon/all": {
             "get": {
-                "description": "Get product by id",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all coupons",
                 "consumes": [
                     "application/json"
                 ],
@@ -1152,36 +1196,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
-                ],
-                "summary": "Get product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "product   uuid",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "coupon"
                 ],
+                "summary": "Get coupons",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductRespOKDTO"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.CouponsRespOKDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
                     "500": {
@@ -1193,14 +1221,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/update/{id}": {
-            "put": {
+        "/coupon/create": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update product",
+                "description": "Create coupon. Percentage coupons use the rate and fixed ones the amount",
                 "consumes": [
                     "application/json"
                 ],
@@ -1208,33 +1236,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "coupon"
                 ],
-                "summary": "Update product",
+                "summary": "Create new coupon",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "product   uuid",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "product data",
-                        "name": "product_data",
+                        "description": "coupon data",
+                        "name": "coupon_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateProductDTO"
+                            "$ref": "#/definitions/dtos.NewCouponDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespOKDTO"
+                            "$ref": "#/definitions/dtos.CouponRespOKDTO"
                         }
                     },
                     "400": {
@@ -1249,17 +1269,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
+                    }
+                }
+            }
+        },
+        "/coupon/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete coupon",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coupon"
+                ],
+                "summary": "Delete coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "coupon uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1270,14 +1340,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/all": {
+        "/coupon/get/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all users",
+                "description": "Get coupon by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1285,14 +1355,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "coupon"
+                ],
+                "summary": "Get coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "coupon uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UsersRespOKDTO"
+                            "$ref": "#/definitions/dtos.CouponRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
                         }
                     },
                     "500": {
@@ -1304,14 +1402,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/create": {
-            "post": {
+        "/coupon/update/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create new user",
+                "description": "Update coupon. The code and the type cannot be changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1319,25 +1417,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "coupon"
                 ],
-                "summary": "Create user",
+                "summary": "Update coupon",
                 "parameters": [
                     {
-                        "description": "user data",
-                        "name": "user_data",
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "coupon uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "coupon data",
+                        "name": "coupon_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.NewUserDTO"
+                            "$ref": "#/definitions/dtos.UpdateCouponDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                            "$ref": "#/definitions/dtos.RespOKDTO"
                         }
                     },
                     "400": {
@@ -1352,6 +1458,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -1367,14 +1479,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/delete/{id}": {
-            "delete": {
+        "/order/all": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete user by ID",
+                "description": "Get a page of all orders, newest first. Send the next cursor of a page to get the following one",
                 "consumes": [
                     "application/json"
                 ],
@@ -1382,24 +1494,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Delete user",
+                "summary": "Get orders",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "paid",
+                            "processing",
+                            "shipped",
+                            "delivered",
+                            "cancelled",
+                            "refunded"
+                        ],
                         "type": "string",
-                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "user uuid",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "Paid flag",
+                        "name": "paid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner uuid",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Created at or after (unix seconds)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Created before (unix seconds)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                            "$ref": "#/definitions/dtos.OrdersPageRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
                         }
                     },
                     "401": {
@@ -1408,10 +1574,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
                     },
                     "500": {
@@ -1423,14 +1589,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/get/{id}": {
-            "get": {
+        "/order/cancel/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user by ID",
+                "description": "Cancel an auth user order while it is still pending",
                 "consumes": [
                     "application/json"
                 ],
@@ -1438,42 +1604,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Get user",
+                "summary": "Cancel order",
                 "parameters": [
                     {
                         "type": "string",
                         "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "user uuid",
+                        "description": "order uuid",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                            "$ref": "#/definitions/dtos.RespOKDTO"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "406": {
+                        "description": "Not Acceptable",
                         "schema": {
                             "$ref": "#/definitions/dtos.RespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
                     },
                     "500": {
@@ -1485,14 +1651,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/update/{id}": {
-            "put": {
+        "/order/checkout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upadate existing user",
+                "description": "Create an order with the products of the auth user cart and empty it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1500,33 +1666,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Update user",
+                "summary": "Checkout cart",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
-                        "description": "user uuid",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "user data",
-                        "name": "user_data",
+                        "description": "checkout data",
+                        "name": "checkout_data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateUserDTO"
+                            "$ref": "#/definitions/dtos.CheckoutDTO"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                            "$ref": "#/definitions/dtos.OrderRespOKDTO"
                         }
                     },
                     "400": {
@@ -1541,10 +1705,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.AuthRespErrDTO"
                         }
                     },
-                    "406": {
-                        "description": "Not Acceptable",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/dtos.RespErrDTO"
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
                         }
                     },
                     "422": {
@@ -1561,84 +1725,4777 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.OrderProduct": {
-            "type": "object",
-            "properties": {
-                "product_id": {
-                    "type": "string"
-                },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
         },
-        "dtos.AddressDTO": {
-            "type": "object",
-            "required": [
-                "city",
-                "country",
-                "line1",
-                "name",
-                "postal_code",
-                "state"
-            ],
-            "properties": {
-                "city": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "example": "Los Angeles"
+        "/order/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an order that was not paid or was refunded. The stock of pending orders goes back",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Delete order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/get/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an order by id. Users only see their own orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all orders from auth user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get auth user orders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrdersRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Create new order",
+                "parameters": [
+                    {
+                        "description": "order data",
+                        "name": "order_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewOrderDTO"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/refund/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give back all or part of the order amount. Zero amount refunds what is left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Refund order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "refund data",
+                        "name": "refund_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefundOrderDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/status/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an order to the next status of its lifecycle",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Update order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new status",
+                        "name": "status_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateOrderStatusDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/invoice": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the invoice of a paid order as pdf or html. The first download gives the order its invoice number. Only the owner and admins can get it",
+                "produces": [
+                    "application/pdf",
+                    "text/html"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get order invoice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "html"
+                        ],
+                        "type": "string",
+                        "description": "Invoice format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/webhook": {
+            "post": {
+                "description": "Receive the payment provider events and update the orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Payment webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "event signature",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/all": {
+            "get": {
+                "description": "Get a page of products, newest first by default. Send the next cursor of a page to get the following one. A price range can only be sorted by price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Any of the tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Min price in minor units",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max price in minor units",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "Available flag",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price",
+                            "name",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductsPageRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/category/{name}": {
+            "get": {
+                "description": "Get the products of a category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get products by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "clothes",
+                        "description": "Category name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductsRespOKDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Create new product",
+                "parameters": [
+                    {
+                        "description": "product data",
+                        "name": "product_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewProductDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product   uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/get/{id}": {
+            "get": {
+                "description": "Get product by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product   uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductRespOKDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/latest": {
+            "get": {
+                "description": "Get the newest products first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get latest products",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "How many products, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductsRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/search": {
+            "get": {
+                "description": "Search the products by name, description, tags and category. The best matches go first, small typos are tolerated and the last word can be unfinished. The matched words come wrapped in \u003cmark\u003e tags in the highlights",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only of this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "description": "Page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hits to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SearchRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/tags": {
+            "get": {
+                "description": "Get the products with any of the tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get products by tags",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tags, 10 at most",
+                        "name": "tags",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductsRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update product, the images_url are added to the product images",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product   uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "product data",
+                        "name": "product_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateProductDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/image": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image (png, jpeg, gif or webp, up to 8 MB, 6000x6000 and 24 megapixels) and add it to the product images in every rendition",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "product image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImageRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/image/{iid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an image from the product and delete its uploaded files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Remove product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048",
+                        "description": "image uuid",
+                        "name": "iid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/variant/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a variant to a product. It must pick one value of every product option",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Add product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "variant data",
+                        "name": "variant_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewVariantDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.VariantRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/variant/delete/{vid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a product variant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Remove product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048",
+                        "description": "variant uuid",
+                        "name": "vid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/variant/update/{vid}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a product variant. A zero price makes it take the product price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048",
+                        "description": "variant uuid",
+                        "name": "vid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "variant data",
+                        "name": "variant_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateVariantDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.VariantRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all returns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Get returns",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnsRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/approve/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a requested return and refund the part of the order paid for its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Approve return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "return uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "moderation data",
+                        "name": "moderation_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateReturnDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all returns from auth user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Get auth user returns",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnsRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ask to send back delivered items of an auth user order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Request a return",
+                "parameters": [
+                    {
+                        "description": "return data",
+                        "name": "return_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewReturnDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/receive/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the items of an approved return arrived. With restock they go back to the inventory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Receive return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "return uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "receive data",
+                        "name": "receive_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReceiveReturnDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/reject/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a requested return. Its items can be asked for again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "return"
+                ],
+                "summary": "Reject return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "return uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "moderation data",
+                        "name": "moderation_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateReturnDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Review a product. Only users with a paid order of the product can review it, once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Review a product",
+                "parameters": [
+                    {
+                        "description": "review data",
+                        "name": "review_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewReviewDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "review uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/hide/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide a review or show it again. The hidden reviews do not count for the product rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Hide review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "review uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "hide data",
+                        "name": "hide_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HideReviewDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/product/{id}": {
+            "get": {
+                "description": "Get the visible reviews of a product, the newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Get product reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewsRespOKDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/product/{id}/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the reviews of a product, the hidden ones too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Get all product reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "product uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewsRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipment/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a shipment of a paid order. Without items it ships everything left. The order moves to shipped once every item has been shipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Create new shipment",
+                "parameters": [
+                    {
+                        "description": "shipment data",
+                        "name": "shipment_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewShipmentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShipmentRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipment/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a shipment recorded by mistake while the order still has items to ship",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Delete shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "shipment uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipment/order/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the shipments of an order. Users only see their own orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Get order shipments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "order uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShipmentsRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipment/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the tracking data or mark the shipment as delivered. The order moves to delivered once every shipment has arrived",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipment"
+                ],
+                "summary": "Update shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "shipment uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "shipment data",
+                        "name": "shipment_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateShipmentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create shipping method with its rate table",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Create new shipping method",
+                "parameters": [
+                    {
+                        "description": "shipping method data",
+                        "name": "shipping_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewShippingMethodDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete shipping method",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Delete shipping method",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "shipping method uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/methods": {
+            "get": {
+                "description": "Get all shipping methods with their rate tables",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Get shipping methods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodsRespOKDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Price every active shipping method for the products and address. Cheapest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Quote shipping",
+                "parameters": [
+                    {
+                        "description": "quote data",
+                        "name": "quote_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuoteShippingDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingQuotesRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update shipping method. The rates are replaced as a whole and the code cannot be changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Update shipping method",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "shipping method uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "shipping method data",
+                        "name": "shipping_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateShippingMethodDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UsersRespOKDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/avatar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image (png, jpeg, gif or webp, up to 8 MB, 6000x6000 and 24 megapixels) as the auth user avatar, the medium rendition is used and the previous avatar is deleted",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Upload avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "avatar image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.URLRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "user data",
+                        "name": "user_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NewUserDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "user uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/get/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "user uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upadate existing user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "3afc3021-9395-11ed-a8b6-d8bbc1a27045",
+                        "description": "user uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "user data",
+                        "name": "user_data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateUserDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRespOKDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationRespErrDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRespErrDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RespErrDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DetailRespErrDTO"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Image": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImageRendition"
+                    }
+                }
+            }
+        },
+        "domain.ImageRendition": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "jpeg, png or webp",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "thumbnail, medium, large or original",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrderProduct": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "discount_rate": {
+                    "type": "integer"
+                },
+                "line_total": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "variant": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrderTax": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "basis points, 825 is 8.25%",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Image"
+                    }
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ReturnItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ShipmentItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ShippingRate": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "max_weight": {
+                    "type": "integer"
+                },
+                "price": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.StatusChange": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.AddCartItemDTO": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048"
+                }
+            }
+        },
+        "dtos.AddressDTO": {
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "line1",
+                "name",
+                "postal_code",
+                "state"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "Los Angeles"
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "USA"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "line1": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "Lolipop"
+                },
+                "line2": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "Wolfstreet"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "main card"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "24156"
+                },
+                "state": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "California"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                }
+            }
+        },
+        "dtos.AddressRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.AddressDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.AddressesRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AddressDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.AuthRespErrDTO": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "the token is expired"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid access token"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failure"
+                }
+            }
+        },
+        "dtos.CardDTO": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "visa"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "USA"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "exp_month": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "exp_year": {
+                    "type": "integer",
+                    "example": 2025
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "last4": {
+                    "type": "string",
+                    "example": "1429"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Main card"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                }
+            }
+        },
+        "dtos.CardsRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CardDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.CartDTO": {
+            "type": "object",
+            "properties": {
+                "can_checkout": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CartItemDTO"
+                    }
+                },
+                "total": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                }
+            }
+        },
+        "dtos.CartItemDTO": {
+            "type": "object",
+            "properties": {
+                "discount_rate": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "issue": {
+                    "type": "string",
+                    "example": "unavailable"
+                },
+                "line_total": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Red Hoodie"
+                },
+                "price": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "HOODIE-RED-M"
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "M / Black"
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048"
+                }
+            }
+        },
+        "dtos.CartRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.CartDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.CategoriesRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.CategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "example": "clothes"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                }
+            }
+        },
+        "dtos.CategoryRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.CategoryDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.CheckoutDTO": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "payment_id",
+                "shipping_method"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "SUMMER10"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                },
+                "shipping_method": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "standard"
+                }
+            }
+        },
+        "dtos.CouponDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "clothes"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "example": 1767225600
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_user": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_order_amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "rate": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "t-shirts"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "uses_by_user": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dtos.CouponRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.CouponDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.CouponsRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CouponDTO"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.DetailRespErrDTO": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "error querying the database"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "something went wrong"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failure"
+                }
+            }
+        },
+        "dtos.FacetCountDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "value": {
+                    "type": "string",
+                    "example": "clothes"
+                }
+            }
+        },
+        "dtos.HideReviewDTO": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.ImageRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Image"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.ModerateReturnDTO": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Refunded, the box was not opened"
+                }
+            }
+        },
+        "dtos.NewAddressDTO": {
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "line1",
+                "name",
+                "postal_code",
+                "state"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "Los Angeles"
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "USA"
+                },
+                "line1": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "Lolipop"
+                },
+                "line2": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "Wolfstreet"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "main card"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "24156"
+                },
+                "state": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "California"
+                }
+            }
+        },
+        "dtos.NewCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "example": "clothes"
+                }
+            }
+        },
+        "dtos.NewCouponDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "categories": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "clothes"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "SUMMER10"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1767225600
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "max_uses_per_user": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "min_order_amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2000
+                },
+                "rate": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 10
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "t-shirts"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "percentage"
+                }
+            }
+        },
+        "dtos.NewOrderDTO": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "payment_id",
+                "products",
+                "shipping_method"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "SUMMER10"
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderProduct"
+                    }
+                },
+                "shipping_method": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "standard"
+                }
+            }
+        },
+        "dtos.NewProductDTO": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "price",
+                "tags"
+            ],
+            "properties": {
+                "avalible": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "clothes"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 4,
+                    "example": "The best T-shirt in the world."
+                },
+                "discount_rate": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 23
+                },
+                "images_url": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/image1.png",
+                        "https://example.com/image2.png"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 4,
+                    "example": "Black T-Shirt Addidas"
+                },
+                "options": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductOptionDTO"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2599
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 25
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 6,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "t-shirts",
+                        "clothes",
+                        "addidas"
+                    ]
+                }
+            }
+        },
+        "dtos.NewReturnDTO": {
+            "type": "object",
+            "required": [
+                "items",
+                "order_id",
+                "reason"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.ReturnItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 4,
+                    "example": "The shoes are too small"
+                }
+            }
+        },
+        "dtos.NewReviewDTO": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "rating",
+                "title"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "The size fits well and it did not shrink after washing it."
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 80,
+                    "minLength": 2,
+                    "example": "Comfy and warm"
+                }
+            }
+        },
+        "dtos.NewShipmentDTO": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "order_id",
+                "tracking_number"
+            ],
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "UPS"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/domain.ShipmentItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "shipped_at": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1674405183
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4,
+                    "example": "1Z999AA10123456784"
+                }
+            }
+        },
+        "dtos.NewShippingMethodDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "rates",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "standard"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "Standard shipping"
+                },
+                "rates": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ShippingRateDTO"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "flat",
+                        "weight"
+                    ],
+                    "example": "flat"
+                }
+            }
+        },
+        "dtos.NewUserDTO": {
+            "type": "object",
+            "required": [
+                "age",
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "minimum": 15,
+                    "example": 20
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@gmail.com"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://nwdistrict.ifas.ufl.edu/nat/files/2021/01/Groundhog.jpg"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "moderator"
+                    ],
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "minLength": 4,
+                    "example": "John Doe"
+                },
+                "verified_email": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dtos.NewVariantDTO": {
+            "type": "object",
+            "required": [
+                "options",
+                "sku"
+            ],
+            "properties": {
+                "images_url": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/tee-black.png"
+                    ]
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "color": "black",
+                        "size": "M"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2799
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "TEE-BLK-M"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12
+                }
+            }
+        },
+        "dtos.OrderDTO": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "payment_id",
+                "products",
+                "shipping_method"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "SUMMER10"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "discount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "paid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "payment_id": {
+                    "type": "string",
+                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                },
+                "payment_intent_id": {
+                    "type": "string",
+                    "example": "pi_3NKPiEG8UXDxPRba0Q2VqT8l"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderProduct"
+                    }
+                },
+                "refunded_amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "shipping_cost": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "shipping_method": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "standard"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatusChange"
+                    }
+                },
+                "tax": {
+                    "$ref": "#/definitions/domain.OrderTax"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
+                }
+            }
+        },
+        "dtos.OrderRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.OrderDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.OrdersPageDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderDTO"
+                    }
+                },
+                "next": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dtos.OrdersPageRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.OrdersPageDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.OrdersRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderDTO"
+                    }
                 },
-                "country": {
+                "message": {
                     "type": "string",
-                    "maxLength": 30,
-                    "example": "USA"
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.ProductDTO": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "price",
+                "tags"
+            ],
+            "properties": {
+                "avalible": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "clothes"
                 },
                 "created_at": {
                     "type": "integer",
                     "example": 1674405183
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 4,
+                    "example": "The best T-shirt in the world."
+                },
+                "discount_rate": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 23
+                },
                 "id": {
                     "type": "string",
                     "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "line1": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "example": "Lolipop"
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Image"
+                    }
                 },
-                "line2": {
+                "images_url": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/image1.png",
+                        "https://example.com/image2.png"
+                    ]
+                },
+                "name": {
                     "type": "string",
-                    "maxLength": 20,
-                    "example": "Wolfstreet"
+                    "maxLength": 50,
+                    "minLength": 4,
+                    "example": "Black T-Shirt Addidas"
+                },
+                "options": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductOptionDTO"
+                    }
+                },
+                "price": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "rating_avg": {
+                    "type": "number",
+                    "example": 4.25
+                },
+                "rating_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "skus": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "TEE-BLK-M",
+                        "TEE-BLK-L"
+                    ]
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 25
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 6,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "t-shirts",
+                        "clothes",
+                        "addidas"
+                    ]
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1674405181
                 },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProductVariant"
+                    }
+                }
+            }
+        },
+        "dtos.ProductOptionDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
                 "name": {
                     "type": "string",
                     "maxLength": 20,
-                    "example": "main card"
+                    "example": "size"
                 },
-                "postal_code": {
+                "values": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "S",
+                        "M",
+                        "L"
+                    ]
+                }
+            }
+        },
+        "dtos.ProductRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dtos.ProductDTO"
+                },
+                "message": {
                     "type": "string",
-                    "example": "24156"
+                    "example": "Data retrived!"
                 },
-                "state": {
+                "status": {
                     "type": "string",
-                    "maxLength": 40,
-                    "example": "California"
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.ProductsPageDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductDTO"
+                    }
                 },
-                "updated_at": {
+                "next": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "total": {
                     "type": "integer",
-                    "example": 1674405181
+                    "example": 42
                 }
             }
         },
-        "dtos.AddressRespOKDTO": {
+        "dtos.ProductsPageRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dtos.AddressDTO"
+                    "$ref": "#/definitions/dtos.ProductsPageDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1650,13 +6507,13 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.AddressesRespOKDTO": {
+        "dtos.ProductsRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.AddressDTO"
+                        "$ref": "#/definitions/dtos.ProductDTO"
                     }
                 },
                 "message": {
@@ -1669,16 +6526,52 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.AuthRespErrDTO": {
+        "dtos.QuoteShippingDTO": {
             "type": "object",
+            "required": [
+                "address_id",
+                "products"
+            ],
             "properties": {
-                "detail": {
+                "address_id": {
                     "type": "string",
-                    "example": "the token is expired"
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
+                "products": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderProduct"
+                    }
+                }
+            }
+        },
+        "dtos.ReceiveReturnDTO": {
+            "type": "object",
+            "properties": {
+                "restock": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.RefundOrderDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "in minor units of the order currency",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1500
+                }
+            }
+        },
+        "dtos.RespErrDTO": {
+            "type": "object",
+            "properties": {
                 "message": {
                     "type": "string",
-                    "example": "invalid access token"
+                    "example": "something went wrong"
                 },
                 "status": {
                     "type": "string",
@@ -1686,59 +6579,89 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.CardDTO": {
+        "dtos.RespOKDTO": {
             "type": "object",
             "properties": {
-                "brand": {
+                "message": {
                     "type": "string",
-                    "example": "visa"
+                    "example": "Data retrived!"
                 },
-                "country": {
+                "status": {
                     "type": "string",
-                    "example": "USA"
-                },
+                    "example": "success"
+                }
+            }
+        },
+        "dtos.ReturnDTO": {
+            "type": "object",
+            "required": [
+                "items",
+                "order_id",
+                "reason"
+            ],
+            "properties": {
                 "created_at": {
                     "type": "integer",
                     "example": 1674405183
                 },
-                "exp_month": {
-                    "type": "integer",
-                    "example": 11
-                },
-                "exp_year": {
-                    "type": "integer",
-                    "example": 2025
-                },
                 "id": {
                     "type": "string",
                     "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "last4": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.ReturnItem"
+                    }
+                },
+                "moderator_note": {
                     "type": "string",
-                    "example": "1429"
+                    "example": "Refunded, the box was not opened"
                 },
-                "name": {
+                "order_id": {
                     "type": "string",
-                    "example": "Main card"
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "payment_id": {
+                "reason": {
                     "type": "string",
-                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                    "maxLength": 500,
+                    "minLength": 4,
+                    "example": "The shoes are too small"
+                },
+                "refunded_amount": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "restocked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.StatusChange"
+                    }
                 },
                 "updated_at": {
                     "type": "integer",
                     "example": 1674405181
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 }
             }
         },
-        "dtos.CardsRespOKDTO": {
+        "dtos.ReturnRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CardDTO"
-                    }
+                    "$ref": "#/definitions/dtos.ReturnDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1750,13 +6673,13 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.CategoriesRespOKDTO": {
+        "dtos.ReturnsRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.CategoryDTO"
+                        "$ref": "#/definitions/dtos.ReturnDTO"
                     }
                 },
                 "message": {
@@ -1769,36 +6692,66 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.CategoryDTO": {
+        "dtos.ReviewDTO": {
             "type": "object",
             "required": [
-                "name"
+                "product_id",
+                "rating",
+                "title"
             ],
             "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "john"
+                },
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "The size fits well and it did not shrink after washing it."
+                },
                 "created_at": {
                     "type": "integer",
                     "example": 1674405183
                 },
+                "hidden": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "id": {
                     "type": "string",
                     "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "name": {
+                "product_id": {
                     "type": "string",
-                    "maxLength": 15,
-                    "example": "clothes"
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 80,
+                    "minLength": 2,
+                    "example": "Comfy and warm"
                 },
                 "updated_at": {
                     "type": "integer",
                     "example": 1674405181
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 }
             }
         },
-        "dtos.CategoryRespOKDTO": {
+        "dtos.ReviewRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dtos.CategoryDTO"
+                    "$ref": "#/definitions/dtos.ReviewDTO"
                 },
                 "message": {
                     "type": "string",
@@ -1810,261 +6763,150 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.DetailRespErrDTO": {
+        "dtos.ReviewsRespOKDTO": {
             "type": "object",
             "properties": {
-                "detail": {
-                    "type": "string",
-                    "example": "error querying the database"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ReviewDTO"
+                    }
                 },
                 "message": {
                     "type": "string",
-                    "example": "something went wrong"
+                    "example": "Data retrived!"
                 },
                 "status": {
                     "type": "string",
-                    "example": "failure"
-                }
-            }
-        },
-        "dtos.NewAddressDTO": {
-            "type": "object",
-            "required": [
-                "city",
-                "country",
-                "line1",
-                "name",
-                "postal_code",
-                "state"
-            ],
-            "properties": {
-                "city": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "example": "Los Angeles"
-                },
-                "country": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "example": "USA"
-                },
-                "line1": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "example": "Lolipop"
-                },
-                "line2": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "example": "Wolfstreet"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "example": "main card"
-                },
-                "postal_code": {
-                    "type": "string",
-                    "example": "24156"
-                },
-                "state": {
-                    "type": "string",
-                    "maxLength": 40,
-                    "example": "California"
+                    "example": "success"
                 }
             }
         },
-        "dtos.NewCategoryDTO": {
+        "dtos.SaveCardDTO": {
             "type": "object",
             "required": [
-                "name"
+                "payment_id"
             ],
             "properties": {
-                "name": {
+                "payment_id": {
                     "type": "string",
-                    "maxLength": 15,
-                    "example": "clothes"
+                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
                 }
             }
         },
-        "dtos.NewOrderDTO": {
+        "dtos.SearchHitDTO": {
             "type": "object",
-            "required": [
-                "address_id",
-                "payment_id",
-                "products"
-            ],
             "properties": {
-                "address_id": {
-                    "type": "string",
-                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "payment_id": {
-                    "type": "string",
-                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                "product": {
+                    "$ref": "#/definitions/dtos.ProductDTO"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.OrderProduct"
-                    }
+                "score": {
+                    "type": "number",
+                    "example": 7.412
                 }
             }
         },
-        "dtos.NewProductDTO": {
+        "dtos.SearchRespOKDTO": {
             "type": "object",
-            "required": [
-                "description",
-                "images_url",
-                "name",
-                "price",
-                "tags"
-            ],
             "properties": {
-                "avalible": {
-                    "type": "boolean"
-                },
-                "category": {
-                    "type": "string",
-                    "example": "clothes"
+                "data": {
+                    "$ref": "#/definitions/dtos.SearchResultDTO"
                 },
-                "description": {
+                "message": {
                     "type": "string",
-                    "maxLength": 200,
-                    "minLength": 4,
-                    "example": "The best T-shirt in the world."
-                },
-                "discount_rate": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0,
-                    "example": 23
-                },
-                "images_url": {
-                    "type": "array",
-                    "maxItems": 10,
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "https://example.com/image1.png",
-                        "https://example.com/image2.png"
-                    ]
+                    "example": "Data retrived!"
                 },
-                "name": {
+                "status": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 4,
-                    "example": "Black T-Shirt Addidas"
-                },
-                "price": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 2599
-                },
-                "tags": {
-                    "type": "array",
-                    "maxItems": 6,
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "t-shirts",
-                        "clothes",
-                        "addidas"
-                    ]
+                    "example": "success"
                 }
             }
         },
-        "dtos.NewUserDTO": {
+        "dtos.SearchResultDTO": {
             "type": "object",
-            "required": [
-                "age",
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "age": {
-                    "type": "integer",
-                    "minimum": 15,
-                    "example": 20
-                },
-                "email": {
-                    "type": "string",
-                    "example": "john@gmail.com"
-                },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://nwdistrict.ifas.ufl.edu/nat/files/2021/01/Groundhog.jpg"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "password123"
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "user",
-                        "moderator"
-                    ],
-                    "example": "user"
+                "facets": {
+                    "type": "object",
+                    "properties": {
+                        "categories": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.FacetCountDTO"
+                            }
+                        },
+                        "tags": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.FacetCountDTO"
+                            }
+                        }
+                    }
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 15,
-                    "minLength": 4,
-                    "example": "John Doe"
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SearchHitDTO"
+                    }
                 },
-                "verified_email": {
-                    "type": "boolean",
-                    "example": false
+                "total": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "dtos.OrderDTO": {
+        "dtos.ShipmentDTO": {
             "type": "object",
             "required": [
-                "address_id",
-                "payment_id",
-                "products"
+                "carrier",
+                "order_id",
+                "tracking_number"
             ],
             "properties": {
-                "address_id": {
+                "carrier": {
                     "type": "string",
-                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
-                },
-                "amount": {
-                    "type": "integer",
-                    "example": 14500
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "UPS"
                 },
                 "created_at": {
                     "type": "integer",
                     "example": 1674405183
                 },
+                "delivered_at": {
+                    "type": "integer",
+                    "example": 1674605183
+                },
                 "id": {
                     "type": "string",
                     "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "paid": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "payment_id": {
-                    "type": "string",
-                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
-                },
-                "products": {
+                "items": {
                     "type": "array",
+                    "maxItems": 100,
                     "items": {
-                        "$ref": "#/definitions/domain.OrderProduct"
+                        "$ref": "#/definitions/domain.ShipmentItem"
                     }
                 },
-                "status": {
+                "order_id": {
                     "type": "string",
-                    "example": "pending"
+                    "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
+                },
+                "shipped_at": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1674405183
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4,
+                    "example": "1Z999AA10123456784"
                 },
                 "updated_at": {
                     "type": "integer",
@@ -2072,11 +6914,11 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.OrderRespOKDTO": {
+        "dtos.ShipmentRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dtos.OrderDTO"
+                    "$ref": "#/definitions/dtos.ShipmentDTO"
                 },
                 "message": {
                     "type": "string",
@@ -2088,13 +6930,13 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.OrdersRespOKDTO": {
+        "dtos.ShipmentsRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.OrderDTO"
+                        "$ref": "#/definitions/dtos.ShipmentDTO"
                     }
                 },
                 "message": {
@@ -2107,77 +6949,38 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.ProductDTO": {
+        "dtos.ShippingMethodDTO": {
             "type": "object",
-            "required": [
-                "description",
-                "images_url",
-                "name",
-                "price",
-                "tags"
-            ],
             "properties": {
-                "avalible": {
-                    "type": "boolean"
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "category": {
+                "code": {
                     "type": "string",
-                    "example": "clothes"
+                    "example": "standard"
                 },
                 "created_at": {
                     "type": "integer",
                     "example": 1674405183
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 4,
-                    "example": "The best T-shirt in the world."
-                },
-                "discount_rate": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0,
-                    "example": 23
-                },
                 "id": {
                     "type": "string",
                     "example": "8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"
                 },
-                "images_url": {
-                    "type": "array",
-                    "maxItems": 10,
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "https://example.com/image1.png",
-                        "https://example.com/image2.png"
-                    ]
-                },
                 "name": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 4,
-                    "example": "Black T-Shirt Addidas"
-                },
-                "price": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 2599
+                    "example": "Standard shipping"
                 },
-                "tags": {
+                "rates": {
                     "type": "array",
-                    "maxItems": 6,
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "t-shirts",
-                        "clothes",
-                        "addidas"
-                    ]
+                        "$ref": "#/definitions/domain.ShippingRate"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "flat"
                 },
                 "updated_at": {
                     "type": "integer",
@@ -2185,11 +6988,11 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.ProductRespOKDTO": {
+        "dtos.ShippingMethodRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dtos.ProductDTO"
+                    "$ref": "#/definitions/dtos.ShippingMethodDTO"
                 },
                 "message": {
                     "type": "string",
@@ -2201,13 +7004,13 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.ProductsRespOKDTO": {
+        "dtos.ShippingMethodsRespOKDTO": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.ProductDTO"
+                        "$ref": "#/definitions/dtos.ShippingMethodDTO"
                     }
                 },
                 "message": {
@@ -2220,22 +7023,31 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.RespErrDTO": {
+        "dtos.ShippingQuoteDTO": {
             "type": "object",
             "properties": {
-                "message": {
+                "cost": {
+                    "$ref": "#/definitions/domain.Money"
+                },
+                "method": {
                     "type": "string",
-                    "example": "something went wrong"
+                    "example": "standard"
                 },
-                "status": {
+                "name": {
                     "type": "string",
-                    "example": "failure"
+                    "example": "Standard shipping"
                 }
             }
         },
-        "dtos.RespOKDTO": {
+        "dtos.ShippingQuotesRespOKDTO": {
             "type": "object",
             "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ShippingQuoteDTO"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "Data retrived!"
@@ -2246,15 +7058,33 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.SaveCardDTO": {
+        "dtos.ShippingRateDTO": {
             "type": "object",
-            "required": [
-                "payment_id"
-            ],
             "properties": {
-                "payment_id": {
+                "country": {
                     "type": "string",
-                    "example": "pm_1NKPiEG8UXDxPRbaEDuh6BrU"
+                    "maxLength": 60,
+                    "example": "USA"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "max_weight": {
+                    "description": "grams, 0 means any weight",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1000
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "state": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "Washintong"
                 }
             }
         },
@@ -2408,6 +7238,98 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.UpdateCartItemDTO": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 3
+                }
+            }
+        },
+        "dtos.UpdateCouponDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 700
+                },
+                "categories": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "clothes"
+                    ]
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "expires_at": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1767225600
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "max_uses_per_user": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "min_order_amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2500
+                },
+                "rate": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 15
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "t-shirts"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateOrderStatusDTO": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "processing",
+                        "shipped",
+                        "delivered",
+                        "cancelled"
+                    ],
+                    "example": "shipped"
+                }
+            }
+        },
         "dtos.UpdateProductDTO": {
             "type": "object",
             "properties": {
@@ -2418,6 +7340,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "clothes"
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 200,
@@ -2448,11 +7374,23 @@ const docTemplate = `{
                     "minLength": 4,
                     "example": "Black T-Shirt Addidas"
                 },
+                "options": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductOptionDTO"
+                    }
+                },
                 "price": {
                     "type": "integer",
                     "minimum": 0,
                     "example": 2599
                 },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 25
+                },
                 "tags": {
                     "type": "array",
                     "maxItems": 6,
@@ -2467,6 +7405,61 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.UpdateShipmentDTO": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "FedEx"
+                },
+                "delivered_at": {
+                    "type": "integer",
+                    "example": 1674605183
+                },
+                "shipped_at": {
+                    "type": "integer",
+                    "example": 1674405183
+                },
+                "tracking_number": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4,
+                    "example": "449044304137821"
+                }
+            }
+        },
+        "dtos.UpdateShippingMethodDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "Standard shipping"
+                },
+                "rates": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ShippingRateDTO"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "flat",
+                        "weight"
+                    ],
+                    "example": "weight"
+                }
+            }
+        },
         "dtos.UpdateUserDTO": {
             "type": "object",
             "properties": {
@@ -2498,6 +7491,46 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.UpdateVariantDTO": {
+            "type": "object",
+            "properties": {
+                "images_url": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com/tee-black.png"
+                    ]
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "color": "black",
+                        "size": "M"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2799
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "TEE-BLK-M"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12
+                }
+            }
+        },
         "dtos.UserDTO": {
             "type": "object",
             "required": [
@@ -2623,6 +7656,22 @@ const docTemplate = `{
                     "example": "failure"
                 }
             }
+        },
+        "dtos.VariantRespOKDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ProductVariant"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Data retrived!"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,44 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/google/uuid"
+)
+
+type AddCartItemDTO struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	VariantID uuid.UUID `json:"variant_id,omitempty" example:"5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048"`
+	Quantity  uint      `json:"quantity" validate:"required,gte=1,lte=100" example:"2"`
+}
+
+type UpdateCartItemDTO struct {
+	Quantity uint `json:"quantity" validate:"number,gte=0,lte=100" example:"3"`
+}
+
+type CheckoutDTO struct {
+	PaymentID      string    `json:"payment_id" validate:"required" example:"pm_1NKPiEG8UXDxPRbaEDuh6BrU"`
+	AddressID      uuid.UUID `json:"address_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	CouponCode     string    `json:"coupon_code,omitempty" validate:"omitempty,alphanum,max=20" example:"SUMMER10"`
+	ShippingMethod string    `json:"shipping_method" validate:"required,alphanum,max=20" example:"standard"`
+}
+
+type CartItemDTO struct {
+	ProductID    uuid.UUID    `json:"product_id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	VariantID    uuid.UUID    `json:"variant_id" example:"5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048"`
+	Quantity     uint         `json:"quantity" example:"2"`
+	Name         string       `json:"name" example:"Red Hoodie"`
+	Variant      string       `json:"variant,omitempty" example:"M / Black"`
+	SKU          string       `json:"sku,omitempty" example:"HOODIE-RED-M"`
+	Price        domain.Money `json:"price"`
+	DiscountRate int64        `json:"discount_rate" example:"10"`
+	LineTotal    domain.Money `json:"line_total"`
+	Issue        string       `json:"issue,omitempty" example:"unavailable"`
+}
+
+type CartDTO struct {
+	UserID      uuid.UUID     `json:"user_id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Items       []CartItemDTO `json:"items"`
+	Total       domain.Money  `json:"total"`
+	CanCheckout bool          `json:"can_checkout" example:"true"`
+	UpdatedAt   int64         `json:"updated_at" example:"1674405181"`
+}
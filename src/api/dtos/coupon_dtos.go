@@ -0,0 +1,92 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type NewCouponDTO struct {
+	Code           string   `json:"code" validate:"required,alphanum,min=3,max=20" example:"SUMMER10"`
+	Type           string   `json:"type" validate:"required,oneof=percentage fixed" example:"percentage"`
+	Rate           int64    `json:"rate" validate:"required_if=Type percentage,number,gte=0,lte=100" example:"10"`
+	Amount         int64    `json:"amount" validate:"required_if=Type fixed,number,gte=0" example:"500"`
+	Currency       string   `json:"currency" validate:"omitempty,len=3,lowercase" example:"usd"`
+	MinOrderAmount int64    `json:"min_order_amount" validate:"number,gte=0" example:"2000"`
+	ExpiresAt      int64    `json:"expires_at" validate:"number,gte=0" example:"1767225600"`
+	MaxUses        int64    `json:"max_uses" validate:"number,gte=0" example:"100"`
+	MaxUsesPerUser int64    `json:"max_uses_per_user" validate:"number,gte=0" example:"1"`
+	Categories     []string `json:"categories" validate:"max=10" example:"clothes"`
+	Tags           []string `json:"tags" validate:"max=10" example:"t-shirts"`
+	Active         bool     `json:"active" example:"true"`
+}
+
+func (dto NewCouponDTO) AdaptToCoupon() (cpn domain.Coupon) {
+	if dto.Currency == "" {
+		dto.Currency = utils.DefaultCurrency
+	}
+
+	cpn.Code = dto.Code
+	cpn.Type = dto.Type
+	cpn.Rate = dto.Rate
+	cpn.MinOrderAmount = domain.NewMoney(dto.MinOrderAmount, dto.Currency)
+	cpn.ExpiresAt = dto.ExpiresAt
+	cpn.MaxUses = dto.MaxUses
+	cpn.MaxUsesPerUser = dto.MaxUsesPerUser
+	cpn.Categories = dto.Categories
+	cpn.Tags = dto.Tags
+	cpn.Active = dto.Active
+
+	if dto.Type == utils.CouponFixed {
+		cpn.Amount = domain.NewMoney(dto.Amount, dto.Currency)
+	}
+
+	return
+}
+
+type CouponDTO struct { //? Documentation
+	ID             uuid.UUID        `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Code           string           `json:"code" example:"SUMMER10"`
+	Type           string           `json:"type" example:"percentage"`
+	Rate           int64            `json:"rate" example:"10"`
+	Amount         domain.Money     `json:"amount"`
+	MinOrderAmount domain.Money     `json:"min_order_amount"`
+	ExpiresAt      int64            `json:"expires_at" example:"1767225600"`
+	MaxUses        int64            `json:"max_uses" example:"100"`
+	MaxUsesPerUser int64            `json:"max_uses_per_user" example:"1"`
+	Uses           int64            `json:"uses" example:"12"`
+	UsesByUser     map[string]int64 `json:"uses_by_user"`
+	Categories     []string         `json:"categories" example:"clothes"`
+	Tags           []string         `json:"tags" example:"t-shirts"`
+	Active         bool             `json:"active" example:"true"`
+	CreatedAt      int64            `json:"created_at" example:"1674405183"`
+	UpdatedAt      int64            `json:"updated_at" example:"1674405181"`
+}
+
+type UpdateCouponDTO struct {
+	Rate           *int64   `json:"rate,omitempty" validate:"omitempty,number,gte=1,lte=100" example:"15"`
+	Amount         *int64   `json:"amount,omitempty" validate:"omitempty,number,gte=1" example:"700"`
+	MinOrderAmount *int64   `json:"min_order_amount,omitempty" validate:"omitempty,number,gte=0" example:"2500"`
+	Currency       string   `json:"currency,omitempty" validate:"required_with=Amount MinOrderAmount,omitempty,len=3,lowercase" example:"usd"`
+	ExpiresAt      *int64   `json:"expires_at,omitempty" validate:"omitempty,number,gte=0" example:"1767225600"`
+	MaxUses        *int64   `json:"max_uses,omitempty" validate:"omitempty,number,gte=0" example:"200"`
+	MaxUsesPerUser *int64   `json:"max_uses_per_user,omitempty" validate:"omitempty,number,gte=0" example:"2"`
+	Categories     []string `json:"categories,omitempty" validate:"omitempty,max=10" example:"clothes"`
+	Tags           []string `json:"tags,omitempty" validate:"omitempty,max=10" example:"t-shirts"`
+	Active         *bool    `json:"active,omitempty"`
+}
+
+func (dto UpdateCouponDTO) AdaptToUpdateFields() domain.UpdateFields {
+	fields := utils.StructToMap(dto)
+	delete(fields, "Currency")
+
+	if dto.Amount != nil {
+		fields["Amount"] = domain.NewMoney(*dto.Amount, dto.Currency)
+	}
+
+	if dto.MinOrderAmount != nil {
+		fields["MinOrderAmount"] = domain.NewMoney(*dto.MinOrderAmount, dto.Currency)
+	}
+
+	return fields
+}
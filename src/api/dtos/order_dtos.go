@@ -1,35 +1,93 @@
 package dtos
 
 import (
+	"strings"
+
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/google/uuid"
 )
 
 type NewOrderDTO struct {
-	PaymentID string                `json:"payment_id" validate:"required" example:"pm_1NKPiEG8UXDxPRbaEDuh6BrU"`
-	Products  []domain.OrderProduct `json:"products" validate:"required"`
-	AddressID uuid.UUID             `json:"address_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	PaymentID      string                `json:"payment_id" validate:"required" example:"pm_1NKPiEG8UXDxPRbaEDuh6BrU"`
+	Products       []domain.OrderProduct `json:"products" validate:"required"`
+	AddressID      uuid.UUID             `json:"address_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	CouponCode     string                `json:"coupon_code,omitempty" validate:"omitempty,alphanum,max=20" example:"SUMMER10"`
+	ShippingMethod string                `json:"shipping_method" validate:"required,alphanum,max=20" example:"standard"`
 }
 
 type OrderDTO struct {
 	NewOrderDTO
-	ID        uuid.UUID `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
-	CreatedAt int64     `json:"created_at" example:"1674405183"`
-	Amount    int64     `json:"amount" example:"14500"`
-	Status    string    `json:"status" example:"pending"`
-	Paid      bool      `json:"paid" example:"true"`
-	UpdatedAt int64     `json:"updated_at" example:"1674405181"`
+	ID              uuid.UUID             `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	CreatedAt       int64                 `json:"created_at" example:"1674405183"`
+	PaymentIntentID string                `json:"payment_intent_id" example:"pi_3NKPiEG8UXDxPRba0Q2VqT8l"`
+	Amount          domain.Money          `json:"amount"`
+	RefundedAmount  domain.Money          `json:"refunded_amount"`
+	Discount        domain.Money          `json:"discount"`
+	ShippingCost    domain.Money          `json:"shipping_cost"`
+	Tax             domain.OrderTax       `json:"tax"`
+	Status          string                `json:"status" example:"pending"`
+	Paid            bool                  `json:"paid" example:"true"`
+	StatusHistory   []domain.StatusChange `json:"status_history"`
+	UpdatedAt       int64                 `json:"updated_at" example:"1674405181"`
+}
+
+type RefundOrderDTO struct {
+	Amount int64 `json:"amount" validate:"number,gte=0" example:"1500"` // in minor units of the order currency
+}
+
+// UpdateOrderStatusDTO has no paid nor refunded, the payment state
+// comes from the payment provider. No order goes back to pending.
+type UpdateOrderStatusDTO struct {
+	Status string `json:"status" validate:"required,oneof=processing shipped delivered cancelled" example:"shipped"`
+}
+
+type OrderFilterDTO struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending paid processing shipped delivered cancelled refunded"`
+	Paid   string `query:"paid" validate:"omitempty,oneof=true false"`
+	UserID string `query:"user_id" validate:"omitempty,uuid"`
+	From   int64  `query:"from" validate:"gte=0"`
+	To     int64  `query:"to" validate:"gte=0"`
+	Limit  int    `query:"limit" validate:"gte=0,lte=100"`
+	Cursor string `query:"cursor" validate:"omitempty,uuid"`
+}
+
+func (dto OrderFilterDTO) AdaptToOrderFilter() domain.OrderFilter {
+	f := domain.OrderFilter{
+		Status: dto.Status,
+		From:   dto.From,
+		To:     dto.To,
+		Limit:  dto.Limit,
+		Cursor: dto.Cursor,
+	}
+
+	if dto.Paid != "" {
+		paid := dto.Paid == "true"
+		f.Paid = &paid
+	}
+
+	if uid, err := uuid.Parse(dto.UserID); err == nil {
+		f.UserID = uid
+	}
+
+	return f
+}
+
+type OrdersPageDTO struct { //? Documentation
+	Items []OrderDTO `json:"items"`
+	Total int        `json:"total" example:"42"`
+	Next  string     `json:"next" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
 }
 
-func (dto NewOrderDTO) AdaptToOrder(price int64, usrid uuid.UUID) domain.Order {
+func (dto NewOrderDTO) AdaptToOrder(price domain.Money, usrid uuid.UUID) domain.Order {
 	return domain.Order{
-		UserID:    usrid,
-		AddressID: dto.AddressID,
-		PaymentID: dto.PaymentID,
-		Amount:    price,
-		Products:  dto.Products,
-		Status:    utils.StatusPending,
-		Paid:      false,
+		UserID:         usrid,
+		AddressID:      dto.AddressID,
+		Amount:         price,
+		Products:       dto.Products,
+		CouponCode:     strings.ToUpper(dto.CouponCode),
+		ShippingMethod: strings.ToLower(dto.ShippingMethod),
+		Status:         utils.StatusPending,
+		Paid:           false,
 	}
 }
@@ -7,46 +7,248 @@ import (
 )
 
 type NewProductDTO struct {
-	Category     string   `json:"category" validation:"required" example:"clothes"`
-	Name         string   `json:"name" validate:"required,min=4,max=50" example:"Black T-Shirt Addidas"`
-	Description  string   `json:"description" validate:"required,min=4,max=200" example:"The best T-shirt in the world."`
-	Price        int64    `json:"price" validate:"required,number,gte=0" example:"2599"`
-	DiscountRate int64    `json:"discount_rate" validate:"number,gte=0,lte=100" example:"23"`
-	ImagesUrl    []string `json:"images_url" validate:"required,min=1,max=10,dive,url" example:"https://example.com/image1.png,https://example.com/image2.png"`
-	Tags         []string `json:"tags" validate:"required,max=6" example:"t-shirts,clothes,addidas"`
-	Avalible     bool     `json:"avalible"`
+	Category     string             `json:"category" validation:"required" example:"clothes"`
+	Name         string             `json:"name" validate:"required,min=4,max=50" example:"Black T-Shirt Addidas"`
+	Description  string             `json:"description" validate:"required,min=4,max=200" example:"The best T-shirt in the world."`
+	Price        int64              `json:"price" validate:"required,number,gte=0" example:"2599"`
+	Currency     string             `json:"currency" validate:"omitempty,len=3,lowercase" example:"usd"`
+	DiscountRate int64              `json:"discount_rate" validate:"number,gte=0,lte=100" example:"23"`
+	ImagesUrl    []string           `json:"images_url,omitempty" validate:"omitempty,max=10,dive,url" example:"https://example.com/image1.png,https://example.com/image2.png"`
+	Tags         []string           `json:"tags" validate:"required,max=6" example:"t-shirts,clothes,addidas"`
+	Avalible     bool               `json:"avalible"`
+	Stock        int64              `json:"stock" validate:"number,gte=0" example:"25"`
+	Options      []ProductOptionDTO `json:"options,omitempty" validate:"omitempty,max=3,dive"`
 }
 
 func (dto NewProductDTO) AdaptToProduct() (prod domain.Product) {
 	prod.Category = dto.Category
 	prod.Name = dto.Name
 	prod.Description = dto.Description
-	prod.Price = dto.Price
+	prod.Price = domain.NewMoney(dto.Price, dto.Currency)
 	prod.DiscountRate = dto.DiscountRate
-	prod.ImagesUrl = dto.ImagesUrl
+	prod.Images = adaptImages(dto.ImagesUrl)
 	prod.Tags = dto.Tags
 	prod.Available = dto.Avalible
+	prod.Stock = dto.Stock
+	prod.Options = adaptOptions(dto.Options)
+
+	if prod.Price.Currency == "" {
+		prod.Price.Currency = utils.DefaultCurrency
+	}
+
 	return
 }
 
 type ProductDTO struct { //? Documentation
 	NewProductDTO
-	ID        uuid.UUID `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
-	CreatedAt int64     `json:"created_at" example:"1674405183"`
-	UpdatedAt int64     `json:"updated_at" example:"1674405181"`
+	Price       domain.Money            `json:"price"`
+	Images      []domain.Image          `json:"images"`
+	Variants    []domain.ProductVariant `json:"variants"`
+	SKUs        []string                `json:"skus" example:"TEE-BLK-M,TEE-BLK-L"`
+	RatingAvg   float64                 `json:"rating_avg" example:"4.25"`
+	RatingCount int64                   `json:"rating_count" example:"12"`
+	ID          uuid.UUID               `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	CreatedAt   int64                   `json:"created_at" example:"1674405183"`
+	UpdatedAt   int64                   `json:"updated_at" example:"1674405181"`
 }
 
+// UpdateProductDTO adds the images of ImagesUrl to the product ones,
+// the images are taken out with the remove image route.
 type UpdateProductDTO struct {
-	Category     string   `json:"category,omitempty" example:"clothes"`
-	Name         string   `json:"name,omitempty" validate:"omitempty,min=4,max=50" example:"Black T-Shirt Addidas"`
-	Description  string   `json:"description,omitempty" validate:"omitempty,min=4,max=200" example:"The best T-shirt in the world."`
-	Price        *int64   `json:"price,omitempty" validate:"omitempty,number,gte=0" example:"2599"`
-	DiscountRate *int64   `json:"discount_rate,omitempty" validate:"omitempty,number,gte=0,lte=100" example:"23"`
-	ImagesUrl    []string `json:"images_url,omitempty" validate:"omitempty,min=1,max=10,dive,url" example:"https://example.com/image1.png,https://example.com/image2.png"`
-	Tags         []string `json:"tags,omitempty" validate:"omitempty,max=6" example:"t-shirts,clothes,addidas"`
-	Available    *bool    `json:"available,omitempty"`
+	Category     string             `json:"category,omitempty" example:"clothes"`
+	Name         string             `json:"name,omitempty" validate:"omitempty,min=4,max=50" example:"Black T-Shirt Addidas"`
+	Description  string             `json:"description,omitempty" validate:"omitempty,min=4,max=200" example:"The best T-shirt in the world."`
+	Price        *int64             `json:"price,omitempty" validate:"omitempty,number,gte=0" example:"2599"`
+	Currency     string             `json:"currency,omitempty" validate:"required_with=Price,excluded_without=Price,omitempty,len=3,lowercase" example:"usd"`
+	DiscountRate *int64             `json:"discount_rate,omitempty" validate:"omitempty,number,gte=0,lte=100" example:"23"`
+	ImagesUrl    []string           `json:"images_url,omitempty" validate:"omitempty,min=1,max=10,dive,url" example:"https://example.com/image1.png,https://example.com/image2.png"`
+	Tags         []string           `json:"tags,omitempty" validate:"omitempty,max=6" example:"t-shirts,clothes,addidas"`
+	Available    *bool              `json:"available,omitempty"`
+	Stock        *int64             `json:"stock,omitempty" validate:"omitempty,number,gte=0" example:"25"`
+	Options      []ProductOptionDTO `json:"options,omitempty" validate:"omitempty,max=3,dive"`
 }
 
 func (dto UpdateProductDTO) AdaptToUpdateFields() domain.UpdateFields {
-	return utils.StructToMap(dto)
+	fields := utils.StructToMap(dto)
+	delete(fields, "Currency")
+	delete(fields, "ImagesUrl")
+
+	if dto.Price != nil {
+		fields["Price"] = domain.NewMoney(*dto.Price, dto.Currency)
+	}
+
+	if dto.Options != nil {
+		fields["Options"] = adaptOptions(dto.Options)
+	}
+
+	if dto.ImagesUrl != nil {
+		fields["Images"] = adaptImages(dto.ImagesUrl)
+	}
+
+	return fields
+}
+
+type ProductOptionDTO struct {
+	Name   string   `json:"name" validate:"required,max=20" example:"size"`
+	Values []string `json:"values" validate:"required,min=1,max=20,unique,dive,required,max=20" example:"S,M,L"`
+}
+
+func adaptOptions(dtos []ProductOptionDTO) []domain.ProductOption {
+	if dtos == nil {
+		return nil
+	}
+
+	opts := make([]domain.ProductOption, len(dtos))
+
+	for i, o := range dtos {
+		opts[i] = domain.ProductOption{Name: o.Name, Values: o.Values}
+	}
+
+	return opts
+}
+
+// adaptImages makes an image for every url.
+func adaptImages(urls []string) []domain.Image {
+	imgs := make([]domain.Image, len(urls))
+
+	for i, url := range urls {
+		imgs[i] = domain.NewURLImage(url)
+	}
+
+	return imgs
+}
+
+type NewVariantDTO struct {
+	SKU       string            `json:"sku" validate:"required,max=40" example:"TEE-BLK-M"`
+	Options   map[string]string `json:"options" validate:"required,min=1,max=3" example:"size:M,color:black"`
+	Price     int64             `json:"price" validate:"number,gte=0" example:"2799"`
+	Stock     int64             `json:"stock" validate:"number,gte=0" example:"12"`
+	ImagesUrl []string          `json:"images_url" validate:"omitempty,max=10,dive,url" example:"https://example.com/tee-black.png"`
+}
+
+// AdaptToVariant leaves the price zero when it is not set,
+// the variant then takes the product price.
+func (dto NewVariantDTO) AdaptToVariant(currency string) domain.ProductVariant {
+	v := domain.ProductVariant{
+		SKU:     dto.SKU,
+		Options: dto.Options,
+		Stock:   dto.Stock,
+		Images:  adaptImages(dto.ImagesUrl),
+	}
+
+	if dto.Price > 0 {
+		v.Price = domain.NewMoney(dto.Price, currency)
+	}
+
+	return v
+}
+
+type UpdateVariantDTO struct {
+	SKU       string            `json:"sku,omitempty" validate:"omitempty,max=40" example:"TEE-BLK-M"`
+	Options   map[string]string `json:"options,omitempty" validate:"omitempty,min=1,max=3" example:"size:M,color:black"`
+	Price     *int64            `json:"price,omitempty" validate:"omitempty,number,gte=0" example:"2799"`
+	Stock     *int64            `json:"stock,omitempty" validate:"omitempty,number,gte=0" example:"12"`
+	ImagesUrl []string          `json:"images_url,omitempty" validate:"omitempty,max=10,dive,url" example:"https://example.com/tee-black.png"`
+}
+
+// ApplyTo changes the fields that were sent, a zero price goes
+// back to the product price.
+func (dto UpdateVariantDTO) ApplyTo(v *domain.ProductVariant, currency string) {
+	if dto.SKU != "" {
+		v.SKU = dto.SKU
+	}
+
+	if dto.Options != nil {
+		v.Options = dto.Options
+	}
+
+	if dto.Price != nil {
+		v.Price = domain.Money{}
+
+		if *dto.Price > 0 {
+			v.Price = domain.NewMoney(*dto.Price, currency)
+		}
+	}
+
+	if dto.Stock != nil {
+		v.Stock = *dto.Stock
+	}
+
+	if dto.ImagesUrl != nil {
+		v.Images = adaptImages(dto.ImagesUrl)
+	}
+}
+
+type ProductFilterDTO struct {
+	Category  string   `query:"category"`
+	Tags      []string `query:"tags" validate:"max=10"`
+	MinPrice  int64    `query:"min_price" validate:"gte=0"`
+	MaxPrice  int64    `query:"max_price" validate:"gte=0"`
+	Available string   `query:"available" validate:"omitempty,oneof=true false"`
+	SortBy    string   `query:"sort_by" validate:"omitempty,oneof=price name created_at"`
+	Order     string   `query:"order" validate:"omitempty,oneof=asc desc"`
+	Limit     int      `query:"limit" validate:"gte=0,lte=100"`
+	Cursor    string   `query:"cursor" validate:"omitempty,uuid"`
+}
+
+func (dto ProductFilterDTO) AdaptToProductFilter() domain.ProductFilter {
+	f := domain.ProductFilter{
+		Category: dto.Category,
+		Tags:     dto.Tags,
+		MinPrice: dto.MinPrice,
+		MaxPrice: dto.MaxPrice,
+		SortBy:   dto.SortBy,
+		Desc:     dto.Order == "desc",
+		Limit:    dto.Limit,
+		Cursor:   dto.Cursor,
+	}
+
+	if dto.Available != "" {
+		avl := dto.Available == "true"
+		f.Available = &avl
+	}
+
+	return f
+}
+
+type ProductTagsDTO struct {
+	Tags []string `query:"tags" validate:"required,min=1,max=10"`
+}
+
+type LatestProductsDTO struct {
+	Limit int `query:"limit" validate:"gte=0,lte=100"`
+}
+
+type ProductsPageDTO struct { //? Documentation
+	Items []ProductDTO `json:"items"`
+	Total int          `json:"total" example:"42"`
+	Next  string       `json:"next" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+}
+
+type SearchQueryDTO struct {
+	Q        string `query:"q" validate:"required,max=100"`
+	Category string `query:"category"`
+	Tag      string `query:"tag"`
+	Limit    int    `query:"limit" validate:"gte=0,lte=50"`
+	Offset   int    `query:"offset" validate:"gte=0"`
+}
+
+type SearchResultDTO struct { //? Documentation
+	Hits   []SearchHitDTO `json:"hits"`
+	Total  int            `json:"total" example:"3"`
+	Facets struct {
+		Categories []FacetCountDTO `json:"categories"`
+		Tags       []FacetCountDTO `json:"tags"`
+	} `json:"facets"`
+}
+
+type SearchHitDTO struct { //? Documentation
+	Product    ProductDTO        `json:"product"`
+	Score      float64           `json:"score" example:"7.412"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type FacetCountDTO struct { //? Documentation
+	Value string `json:"value" example:"clothes"`
+	Count int    `json:"count" example:"2"`
 }
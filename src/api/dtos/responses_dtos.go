@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/ZaphCode/clean-arch/src/domain"
+
 //? ---------------------------------------------
 //? All this dtos are for documentation porpurses
 //? ---------------------------------------------
@@ -30,11 +32,31 @@ type ProductRespOKDTO struct {
 	Data ProductDTO `json:"data"`
 }
 
+type VariantRespOKDTO struct {
+	RespOKDTO
+	Data domain.ProductVariant `json:"data"`
+}
+
+type ImageRespOKDTO struct {
+	RespOKDTO
+	Data domain.Image `json:"data"`
+}
+
 type ProductsRespOKDTO struct {
 	RespOKDTO
 	Data []ProductDTO `json:"data"`
 }
 
+type ProductsPageRespOKDTO struct {
+	RespOKDTO
+	Data ProductsPageDTO `json:"data"`
+}
+
+type SearchRespOKDTO struct {
+	RespOKDTO
+	Data SearchResultDTO `json:"data"`
+}
+
 //* -------- CARDS ----------
 
 type CardRespOKDTO struct {
@@ -82,6 +104,83 @@ type OrdersRespOKDTO struct {
 	Data []OrderDTO `json:"data"`
 }
 
+type OrdersPageRespOKDTO struct {
+	RespOKDTO
+	Data OrdersPageDTO `json:"data"`
+}
+
+//* -------- CART ----------
+
+type CartRespOKDTO struct {
+	RespOKDTO
+	Data CartDTO `json:"data"`
+}
+
+//* -------- COUPONS ----------
+
+type CouponRespOKDTO struct {
+	RespOKDTO
+	Data CouponDTO `json:"data"`
+}
+
+type CouponsRespOKDTO struct {
+	RespOKDTO
+	Data []CouponDTO `json:"data"`
+}
+
+//* -------- SHIPPING ----------
+
+type ShippingMethodRespOKDTO struct {
+	RespOKDTO
+	Data ShippingMethodDTO `json:"data"`
+}
+
+type ShippingMethodsRespOKDTO struct {
+	RespOKDTO
+	Data []ShippingMethodDTO `json:"data"`
+}
+
+type ShippingQuotesRespOKDTO struct {
+	RespOKDTO
+	Data []ShippingQuoteDTO `json:"data"`
+}
+
+//* -------- SHIPMENTS ----------
+
+type ShipmentRespOKDTO struct {
+	RespOKDTO
+	Data ShipmentDTO `json:"data"`
+}
+
+type ShipmentsRespOKDTO struct {
+	RespOKDTO
+	Data []ShipmentDTO `json:"data"`
+}
+
+//* -------- RETURNS ----------
+
+type ReturnRespOKDTO struct {
+	RespOKDTO
+	Data ReturnDTO `json:"data"`
+}
+
+type ReturnsRespOKDTO struct {
+	RespOKDTO
+	Data []ReturnDTO `json:"data"`
+}
+
+//* -------- REVIEWS ----------
+
+type ReviewRespOKDTO struct {
+	RespOKDTO
+	Data ReviewDTO `json:"data"`
+}
+
+type ReviewsRespOKDTO struct {
+	RespOKDTO
+	Data []ReviewDTO `json:"data"`
+}
+
 //* --------- AUTH -------------
 
 type URLRespOKDTO struct {
@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/google/uuid"
+)
+
+type NewReturnDTO struct {
+	OrderID uuid.UUID           `json:"order_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Reason  string              `json:"reason" validate:"required,min=4,max=500" example:"The shoes are too small"`
+	Items   []domain.ReturnItem `json:"items" validate:"required,min=1,max=100"`
+}
+
+func (dto NewReturnDTO) AdaptToReturn(usrID uuid.UUID) domain.ReturnRequest {
+	return domain.ReturnRequest{
+		OrderID: dto.OrderID,
+		UserID:  usrID,
+		Reason:  dto.Reason,
+		Items:   dto.Items,
+	}
+}
+
+type ReturnDTO struct { //? Documentation
+	NewReturnDTO
+	ID             uuid.UUID             `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	UserID         uuid.UUID             `json:"user_id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Status         string                `json:"status" example:"requested"`
+	ModeratorNote  string                `json:"moderator_note" example:"Refunded, the box was not opened"`
+	RefundedAmount domain.Money          `json:"refunded_amount"`
+	Restocked      bool                  `json:"restocked" example:"true"`
+	StatusHistory  []domain.StatusChange `json:"status_history"`
+	CreatedAt      int64                 `json:"created_at" example:"1674405183"`
+	UpdatedAt      int64                 `json:"updated_at" example:"1674405181"`
+}
+
+type ModerateReturnDTO struct {
+	Note string `json:"note" validate:"max=500" example:"Refunded, the box was not opened"`
+}
+
+type ReceiveReturnDTO struct {
+	Restock bool `json:"restock" example:"true"`
+}
@@ -0,0 +1,37 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/google/uuid"
+)
+
+type NewReviewDTO struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Rating    int64     `json:"rating" validate:"required,gte=1,lte=5" example:"4"`
+	Title     string    `json:"title" validate:"required,min=2,max=80" example:"Comfy and warm"`
+	Body      string    `json:"body" validate:"max=2000" example:"The size fits well and it did not shrink after washing it."`
+}
+
+func (dto NewReviewDTO) AdaptToReview(usrID uuid.UUID) domain.Review {
+	return domain.Review{
+		ProductID: dto.ProductID,
+		UserID:    usrID,
+		Rating:    dto.Rating,
+		Title:     dto.Title,
+		Body:      dto.Body,
+	}
+}
+
+type ReviewDTO struct { //? Documentation
+	NewReviewDTO
+	ID        uuid.UUID `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	UserID    uuid.UUID `json:"user_id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Author    string    `json:"author" example:"john"`
+	Hidden    bool      `json:"hidden" example:"false"`
+	CreatedAt int64     `json:"created_at" example:"1674405183"`
+	UpdatedAt int64     `json:"updated_at" example:"1674405181"`
+}
+
+type HideReviewDTO struct {
+	Hidden bool `json:"hidden" example:"true"`
+}
@@ -0,0 +1,44 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type NewShipmentDTO struct {
+	OrderID        uuid.UUID             `json:"order_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Carrier        string                `json:"carrier" validate:"required,min=2,max=50" example:"UPS"`
+	TrackingNumber string                `json:"tracking_number" validate:"required,min=4,max=100" example:"1Z999AA10123456784"`
+	Items          []domain.ShipmentItem `json:"items" validate:"max=100"`
+	ShippedAt      int64                 `json:"shipped_at" validate:"number,gte=0" example:"1674405183"`
+}
+
+func (dto NewShipmentDTO) AdaptToShipment() domain.Shipment {
+	return domain.Shipment{
+		OrderID:        dto.OrderID,
+		Carrier:        dto.Carrier,
+		TrackingNumber: dto.TrackingNumber,
+		Items:          dto.Items,
+		ShippedAt:      dto.ShippedAt,
+	}
+}
+
+type ShipmentDTO struct { //? Documentation
+	NewShipmentDTO
+	ID          uuid.UUID `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	DeliveredAt int64     `json:"delivered_at" example:"1674605183"`
+	CreatedAt   int64     `json:"created_at" example:"1674405183"`
+	UpdatedAt   int64     `json:"updated_at" example:"1674405181"`
+}
+
+type UpdateShipmentDTO struct {
+	Carrier        string `json:"carrier,omitempty" validate:"omitempty,min=2,max=50" example:"FedEx"`
+	TrackingNumber string `json:"tracking_number,omitempty" validate:"omitempty,min=4,max=100" example:"449044304137821"`
+	ShippedAt      *int64 `json:"shipped_at,omitempty" validate:"omitempty,number,gt=0" example:"1674405183"`
+	DeliveredAt    *int64 `json:"delivered_at,omitempty" validate:"omitempty,number,gt=0" example:"1674605183"`
+}
+
+func (dto UpdateShipmentDTO) AdaptToUpdateFields() domain.UpdateFields {
+	return utils.StructToMap(dto)
+}
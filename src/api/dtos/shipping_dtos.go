@@ -0,0 +1,94 @@
+package dtos
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type ShippingRateDTO struct {
+	Country   string `json:"country" validate:"max=60" example:"USA"`
+	State     string `json:"state" validate:"max=60" example:"Washintong"`
+	MaxWeight int64  `json:"max_weight" validate:"number,gte=0" example:"1000"` // grams, 0 means any weight
+	Price     int64  `json:"price" validate:"number,gte=0" example:"500"`
+	Currency  string `json:"currency" validate:"omitempty,len=3,lowercase" example:"usd"`
+}
+
+func (dto ShippingRateDTO) AdaptToShippingRate() domain.ShippingRate {
+	if dto.Currency == "" {
+		dto.Currency = utils.DefaultCurrency
+	}
+
+	return domain.ShippingRate{
+		Country:   dto.Country,
+		State:     dto.State,
+		MaxWeight: dto.MaxWeight,
+		Price:     domain.NewMoney(dto.Price, dto.Currency),
+	}
+}
+
+type NewShippingMethodDTO struct {
+	Code   string            `json:"code" validate:"required,alphanum,min=3,max=20" example:"standard"`
+	Name   string            `json:"name" validate:"required,min=3,max=50" example:"Standard shipping"`
+	Type   string            `json:"type" validate:"required,oneof=flat weight" example:"flat"`
+	Rates  []ShippingRateDTO `json:"rates" validate:"required,min=1,max=100,dive"`
+	Active bool              `json:"active" example:"true"`
+}
+
+func (dto NewShippingMethodDTO) AdaptToShippingMethod() (sm domain.ShippingMethod) {
+	sm.Code = dto.Code
+	sm.Name = dto.Name
+	sm.Type = dto.Type
+	sm.Active = dto.Active
+	sm.Rates = adaptShippingRates(dto.Rates)
+	return
+}
+
+type ShippingMethodDTO struct { //? Documentation
+	ID        uuid.UUID             `json:"id" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Code      string                `json:"code" example:"standard"`
+	Name      string                `json:"name" example:"Standard shipping"`
+	Type      string                `json:"type" example:"flat"`
+	Rates     []domain.ShippingRate `json:"rates"`
+	Active    bool                  `json:"active" example:"true"`
+	CreatedAt int64                 `json:"created_at" example:"1674405183"`
+	UpdatedAt int64                 `json:"updated_at" example:"1674405181"`
+}
+
+type UpdateShippingMethodDTO struct {
+	Name   string            `json:"name,omitempty" validate:"omitempty,min=3,max=50" example:"Standard shipping"`
+	Type   string            `json:"type,omitempty" validate:"omitempty,oneof=flat weight" example:"weight"`
+	Rates  []ShippingRateDTO `json:"rates,omitempty" validate:"omitempty,min=1,max=100,dive"`
+	Active *bool             `json:"active,omitempty"`
+}
+
+func (dto UpdateShippingMethodDTO) AdaptToUpdateFields() domain.UpdateFields {
+	fields := utils.StructToMap(dto)
+
+	if dto.Rates != nil {
+		fields["Rates"] = adaptShippingRates(dto.Rates)
+	}
+
+	return fields
+}
+
+type QuoteShippingDTO struct {
+	AddressID uuid.UUID             `json:"address_id" validate:"required" example:"8ded83fe-93c8-11ed-ab0f-d8bbc1a27048"`
+	Products  []domain.OrderProduct `json:"products" validate:"required,min=1"`
+}
+
+type ShippingQuoteDTO struct { //? Documentation
+	Method string       `json:"method" example:"standard"`
+	Name   string       `json:"name" example:"Standard shipping"`
+	Cost   domain.Money `json:"cost"`
+}
+
+func adaptShippingRates(rates []ShippingRateDTO) []domain.ShippingRate {
+	srs := make([]domain.ShippingRate, 0, len(rates))
+
+	for _, r := range rates {
+		srs = append(srs, r.AdaptToShippingRate())
+	}
+
+	return srs
+}
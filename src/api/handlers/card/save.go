@@ -13,6 +13,7 @@ import (
 // @Produce      json
 // @Security     BearerAuth
 // @Param        card_data  body dtos.SaveCardDTO true "card data"
+// @Param        Idempotency-Key  header string false "retries with the same key replay the first response"
 // @Success      200  {object}  dtos.CardsRespOKDTO
 // @Failure      401  {object}  dtos.AuthRespErrDTO
 // @Failure      500  {object}  dtos.DetailRespErrDTO
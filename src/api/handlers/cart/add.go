@@ -0,0 +1,48 @@
+package cart
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Add cart item handler
+// @Summary      Add item to cart
+// @Description  Add a product to the auth user cart. Adding a product that is already there increases its quantity
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        item_data  body dtos.AddCartItemDTO true "item data"
+// @Success      200  {object}  dtos.CartRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /cart/add [post]
+func (h *CartHandler) AddCartItem(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	body := dtos.AddCartItemDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.cartSvc.AddItem(ud.ID, domain.ProductRef{
+		ProductID: body.ProductID, VariantID: body.VariantID,
+	}, body.Quantity); err != nil {
+		return h.RespErr(c, 400, "cannot add the product", err.Error())
+	}
+
+	return h.respCart(c, "product added")
+}
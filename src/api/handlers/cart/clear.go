@@ -0,0 +1,31 @@
+package cart
+
+import (
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Clear cart handler
+// @Summary      Clear cart
+// @Description  Remove all the products from the auth user cart
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.CartRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /cart/clear [delete]
+func (h *CartHandler) ClearCart(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	if err := h.cartSvc.Clear(ud.ID); err != nil {
+		return h.RespErr(c, 500, "error clearing the cart", err.Error())
+	}
+
+	return h.respCart(c, "cart cleared")
+}
@@ -0,0 +1,20 @@
+package cart
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get cart handler
+// @Summary      Get cart
+// @Description  Get the auth user cart with the current prices. Items that cannot be bought have an issue
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.CartRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /cart/view [get]
+func (h *CartHandler) GetCart(c *fiber.Ctx) error {
+	return h.respCart(c, "user cart")
+}
@@ -0,0 +1,153 @@
+package cart
+
+import (
+	"fmt"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CartHandler struct {
+	shared.Responder
+	cartSvc domain.CartService
+	prodSvc domain.ProductService
+	vldSvc  validation.ValidationService
+}
+
+func NewCartHandler(
+	cartSvc domain.CartService,
+	prodSvc domain.ProductService,
+	vldSvc validation.ValidationService,
+) *CartHandler {
+	return &CartHandler{
+		cartSvc: cartSvc,
+		prodSvc: prodSvc,
+		vldSvc:  vldSvc,
+	}
+}
+
+// cartView prices the cart with the current products and
+// flags the items that cannot be bought.
+func (h *CartHandler) cartView(cart *domain.Cart) (dtos.CartDTO, error) {
+	view := dtos.CartDTO{
+		UserID:      cart.UserID,
+		Items:       []dtos.CartItemDTO{},
+		CanCheckout: len(cart.Items) > 0,
+		UpdatedAt:   cart.UpdatedAt,
+	}
+
+	for _, item := range cart.Items {
+		iv := dtos.CartItemDTO{
+			ProductID: item.ID, VariantID: item.VariantID, Quantity: item.Quantity,
+		}
+
+		p, err := h.prodSvc.GetByID(item.ID)
+
+		if err != nil {
+			return view, err
+		}
+
+		var v *domain.ProductVariant
+
+		if p != nil && p.HasVariants() {
+			v = p.Variant(item.VariantID)
+		}
+
+		//* The variant may be gone with the product or just by itself
+		switch {
+		case p == nil, p.HasVariants() && v == nil, !p.HasVariants() && item.VariantID != uuid.Nil:
+			iv.Issue = utils.CartItemDeleted
+		case !p.Available:
+			iv.Issue = utils.CartItemUnavailable
+		case v != nil && v.Stock < int64(item.Quantity):
+			iv.Issue = utils.CartItemOutOfStock
+		case p.Stock < int64(item.Quantity):
+			iv.Issue = utils.CartItemOutOfStock
+		}
+
+		if p != nil {
+			iv.Name = p.Name
+			iv.Price = p.Price
+			iv.DiscountRate = p.DiscountRate
+		}
+
+		if v != nil {
+			iv.Variant = p.VariantLabel(*v)
+			iv.SKU = v.SKU
+			iv.Price = p.VariantPrice(*v)
+		}
+
+		if iv.Issue == "" {
+			total, err := h.prodSvc.CalculateTotalPrice([]domain.OrderProduct{item})
+
+			if err != nil {
+				return view, err
+			}
+
+			iv.LineTotal = total
+
+			if view.Total, err = view.Total.Add(total); err != nil {
+				return view, err
+			}
+		} else {
+			view.CanCheckout = false
+		}
+
+		view.Items = append(view.Items, iv)
+	}
+
+	return view, nil
+}
+
+// itemRef reads the cart item from the product id param
+// and the optional variant query.
+func (h *CartHandler) itemRef(c *fiber.Ctx) (domain.ProductRef, error) {
+	ref := domain.ProductRef{}
+
+	pid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return ref, fmt.Errorf("invalid product id")
+	}
+
+	ref.ProductID = pid
+
+	if c.Query("variant") == "" {
+		return ref, nil
+	}
+
+	if ref.VariantID, err = uuid.Parse(c.Query("variant")); err != nil {
+		return ref, fmt.Errorf("invalid variant id")
+	}
+
+	return ref, nil
+}
+
+// respCart responds with the updated cart of the auth user.
+func (h *CartHandler) respCart(c *fiber.Ctx, msg string) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	cart, err := h.cartSvc.GetByUserID(ud.ID)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting cart", err.Error())
+	}
+
+	view, err := h.cartView(cart)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error pricing the cart", err.Error())
+	}
+
+	return h.RespOK(c, 200, msg, view)
+}
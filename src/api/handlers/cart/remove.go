@@ -0,0 +1,41 @@
+package cart
+
+import (
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Remove cart item handler
+// @Summary      Remove cart item
+// @Description  Remove a product from the auth user cart
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        variant  query string false "variant uuid" example(5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048)
+// @Success      200  {object}  dtos.CartRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.DetailRespErrDTO
+// @Router       /cart/remove/{id} [delete]
+func (h *CartHandler) RemoveCartItem(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	ref, err := h.itemRef(c)
+
+	if err != nil {
+		return h.RespErr(c, 406, err.Error())
+	}
+
+	if err := h.cartSvc.RemoveItem(ud.ID, ref); err != nil {
+		return h.RespErr(c, 400, "cannot remove the item", err.Error())
+	}
+
+	return h.respCart(c, "item removed")
+}
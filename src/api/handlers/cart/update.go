@@ -0,0 +1,54 @@
+package cart
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Update cart item handler
+// @Summary      Update cart item
+// @Description  Change the quantity of a product in the auth user cart. Zero removes it
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        variant  query string false "variant uuid" example(5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048)
+// @Param        item_data  body dtos.UpdateCartItemDTO true "item data"
+// @Success      200  {object}  dtos.CartRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /cart/update/{id} [put]
+func (h *CartHandler) UpdateCartItem(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	ref, err := h.itemRef(c)
+
+	if err != nil {
+		return h.RespErr(c, 406, err.Error())
+	}
+
+	body := dtos.UpdateCartItemDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.cartSvc.UpdateItem(ud.ID, ref, body.Quantity); err != nil {
+		return h.RespErr(c, 400, "cannot update the item", err.Error())
+	}
+
+	return h.respCart(c, "item updated")
+}
@@ -0,0 +1,40 @@
+package coupon
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Create coupon handler
+// @Summary      Create new coupon
+// @Description  Create coupon. Percentage coupons use the rate and fixed ones the amount
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        coupon_data  body dtos.NewCouponDTO true "coupon data"
+// @Success      201  {object}  dtos.CouponRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /coupon/create [post]
+func (h *CouponHandler) CreateCoupon(c *fiber.Ctx) error {
+	body := dtos.NewCouponDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	cpn := body.AdaptToCoupon()
+
+	if err := h.cpnSvc.Create(&cpn); err != nil {
+		return h.RespErr(c, 500, "error creating coupon", err.Error())
+	}
+
+	return h.RespOK(c, 201, "coupon created", cpn)
+}
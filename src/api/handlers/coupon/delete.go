@@ -0,0 +1,33 @@
+package coupon
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Delete coupon handler
+// @Summary      Delete coupon
+// @Description  Delete coupon
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "coupon uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /coupon/delete/{id} [delete]
+func (h *CouponHandler) DeleteCoupon(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid coupon id")
+	}
+
+	if err := h.cpnSvc.Delete(uid); err != nil {
+		return h.RespErr(c, 500, "error deleting coupon", err.Error())
+	}
+
+	return h.RespOK(c, 200, "coupon deleted")
+}
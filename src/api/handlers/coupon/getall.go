@@ -0,0 +1,24 @@
+package coupon
+
+import "github.com/gofiber/fiber/v2"
+
+// * Get coupons handler
+// @Summary      Get coupons
+// @Description  Get all coupons
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.CouponsRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /coupon/all [get]
+func (h *CouponHandler) GetCoupons(c *fiber.Ctx) error {
+	cpns, err := h.cpnSvc.GetAll()
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting coupons", err.Error())
+	}
+
+	return h.RespOK(c, 200, "all coupons", cpns)
+}
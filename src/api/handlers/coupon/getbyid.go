@@ -0,0 +1,40 @@
+package coupon
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Get coupon by ID handler
+// @Summary      Get coupon
+// @Description  Get coupon by id
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "coupon uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.CouponRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Router       /coupon/get/{id} [get]
+func (h *CouponHandler) GetCoupon(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid coupon id")
+	}
+
+	cpn, err := h.cpnSvc.GetByID(uid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting coupon", err.Error())
+	}
+
+	if cpn == nil {
+		return h.RespErr(c, 404, "coupon not found")
+	}
+
+	return h.RespOK(c, 200, "coupon found", cpn)
+}
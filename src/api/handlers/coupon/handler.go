@@ -0,0 +1,23 @@
+package coupon
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+)
+
+type CouponHandler struct {
+	shared.Responder
+	cpnSvc domain.CouponService
+	vldSvc validation.ValidationService
+}
+
+func NewCouponHandler(
+	cpnSvc domain.CouponService,
+	vldSvc validation.ValidationService,
+) *CouponHandler {
+	return &CouponHandler{
+		cpnSvc: cpnSvc,
+		vldSvc: vldSvc,
+	}
+}
@@ -0,0 +1,47 @@
+package coupon
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Update coupon handler
+// @Summary      Update coupon
+// @Description  Update coupon. The code and the type cannot be changed
+// @Tags         coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "coupon uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        coupon_data  body dtos.UpdateCouponDTO true "coupon data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /coupon/update/{id} [put]
+func (h *CouponHandler) UpdateCoupon(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid coupon id")
+	}
+
+	body := dtos.UpdateCouponDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.cpnSvc.Update(uid, body.AdaptToUpdateFields()); err != nil {
+		return h.RespErr(c, 500, "error updating coupon", err.Error())
+	}
+
+	return h.RespOK(c, 200, "coupon updated")
+}
@@ -0,0 +1,47 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Cancel order handler
+// @Summary      Cancel order
+// @Description  Cancel an auth user order while it is still pending
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /order/cancel/{id} [put]
+func (h *OrderHandler) CancelOrder(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	if err := h.ordSvc.Cancel(uid, ud.ID); err != nil {
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the order cannot be cancelled", err.Error())
+		}
+		return h.RespErr(c, 500, "error cancelling order", err.Error())
+	}
+
+	return h.RespOK(c, 200, "order cancelled")
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Checkout cart handler
+// @Summary      Checkout cart
+// @Description  Create an order with the products of the auth user cart and empty it
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        checkout_data  body dtos.CheckoutDTO true "checkout data"
+// @Param        Idempotency-Key  header string false "retries with the same key replay the first response"
+// @Success      200  {object}  dtos.OrderRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /order/checkout [post]
+func (h *OrderHandler) CheckoutCart(c *fiber.Ctx) error {
+	usrData, ok1 := c.Locals("user-data").(*auth.Claims)
+	cusID, ok2 := c.Locals("customer-id").(string)
+
+	if !ok1 || !ok2 {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	body := dtos.CheckoutDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	cart, err := h.cartSvc.GetByUserID(usrData.ID)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting cart", err.Error())
+	}
+
+	if len(cart.Items) == 0 {
+		return h.RespErr(c, 400, "the cart is empty")
+	}
+
+	order, err := h.placeOrder(c, usrData.ID, cusID, dtos.NewOrderDTO{
+		PaymentID:      body.PaymentID,
+		AddressID:      body.AddressID,
+		Products:       cart.Items,
+		CouponCode:     body.CouponCode,
+		ShippingMethod: body.ShippingMethod,
+	})
+
+	if order == nil {
+		return err
+	}
+
+	if err := h.cartSvc.Clear(usrData.ID); err != nil {
+		utils.PrintColor("red", "Error clearing the cart")
+	}
+
+	return h.RespOK(c, 200, "order created", fiber.Map{
+		"order": order,
+	})
+}
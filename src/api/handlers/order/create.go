@@ -1,10 +1,14 @@
 package order
 
 import (
+	"errors"
+
 	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/services/auth"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // * Create new order handler
@@ -15,11 +19,13 @@ import (
 // @Produce      json
 // @Security     BearerAuth
 // @Param        order_data  body dtos.NewOrderDTO true "order data"
+// @Param        Idempotency-Key  header string false "retries with the same key replay the first response"
 // @Success      200  {object}  dtos.OrderRespOKDTO
 // @Failure      401  {object}  dtos.AuthRespErrDTO
 // @Failure      500  {object}  dtos.DetailRespErrDTO
 // @Failure      422  {object}  dtos.DetailRespErrDTO
 // @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
 // @Failure      400  {object}  dtos.ValidationRespErrDTO
 // @Router       /order/new [post]
 func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
@@ -40,31 +46,150 @@ func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 		return h.RespValErr(c, 400, "one or more fields are invalid", err)
 	}
 
+	order, err := h.placeOrder(c, usrData.ID, cusID, body)
+
+	if order == nil {
+		return err
+	}
+
+	return h.RespOK(c, 200, "order created", fiber.Map{
+		"order": order,
+	})
+}
+
+// placeOrder prices the products, the shipping and the taxes, reserves
+// the stock, saves the order and charges it.
+// If something fails it responds and returns a nil order.
+func (h *OrderHandler) placeOrder(c *fiber.Ctx, usrID uuid.UUID, cusID string, body dtos.NewOrderDTO) (*domain.Order, error) {
 	price, err := h.prodSvc.CalculateTotalPrice(body.Products)
 
 	if err != nil {
-		return h.RespErr(c, 400, "some product are invalid", err.Error())
+		return nil, h.RespErr(c, 400, "some product are invalid", err.Error())
 	}
 
-	order := body.AdaptToOrder(price, usrData.ID)
+	discount := domain.Money{}
 
-	if err := h.ordSvc.Create(&order); err != nil {
-		return h.RespErr(c, 500, "error creating order", err.Error())
+	if body.CouponCode != "" {
+		if discount, err = h.cpnSvc.Apply(body.CouponCode, usrID, body.Products); err != nil {
+			if errors.Is(err, utils.ErrInvalidCoupon) {
+				return nil, h.RespErr(c, 400, "the coupon cannot be used", err.Error())
+			}
+			return nil, h.RespErr(c, 500, "error applying the coupon", err.Error())
+		}
+
+		if price, err = price.Sub(discount); err != nil {
+			return nil, h.RespErr(c, 500, "error applying the coupon", err.Error())
+		}
 	}
 
-	err = h.pmSvc.MakePayment(cusID, body.PaymentID, price)
+	//* The coupon never takes anything off the shipping
+	shipping, err := h.shipSvc.QuoteMethod(body.ShippingMethod, body.AddressID, body.Products)
 
 	if err != nil {
-		return h.RespErr(c, 500, "error making the payment", err.Error())
+		if errors.Is(err, utils.ErrNoShipping) {
+			return nil, h.RespErr(c, 400, "the shipping method cannot be used", err.Error())
+		}
+		return nil, h.RespErr(c, 500, "error quoting shipping", err.Error())
+	}
+
+	if price, err = price.Add(shipping); err != nil {
+		return nil, h.RespErr(c, 400, "the shipping method cannot be used", err.Error())
 	}
 
-	go func() {
-		if err = h.ordSvc.SetPaidStatus(order.ID, true); err != nil {
-			utils.PrintColor("red", "Error updating order status")
+	addr, err := h.addrSvc.GetByID(body.AddressID)
+
+	if err != nil {
+		return nil, h.RespErr(c, 500, "error getting the address", err.Error())
+	}
+
+	if addr == nil {
+		return nil, h.RespErr(c, 400, "address not found")
+	}
+
+	ordTax, err := h.taxCalc.Calculate(addr, body.Products, discount, shipping)
+
+	if err != nil {
+		return nil, h.RespErr(c, 500, "error calculating taxes", err.Error())
+	}
+
+	//* Inclusive taxes are already in the prices
+	if !ordTax.Inclusive {
+		if price, err = price.Add(ordTax.Amount); err != nil {
+			return nil, h.RespErr(c, 500, "error calculating taxes", err.Error())
 		}
-	}()
+	}
 
-	return h.RespOK(c, 200, "order created", fiber.Map{
-		"order": order,
-	})
+	order := body.AdaptToOrder(price, usrID)
+	order.Discount = discount
+	order.ShippingCost = shipping
+	order.Tax = ordTax
+
+	//* Counted before the order exists, cancelling the order gives it back
+	if body.CouponCode != "" {
+		if err := h.cpnSvc.Redeem(body.CouponCode, usrID); err != nil {
+			return nil, h.RespErr(c, 400, "the coupon cannot be used", err.Error())
+		}
+
+		order.CouponRedeemed = true
+	}
+
+	if err := h.ordSvc.Create(&order); err != nil {
+		h.releaseCoupon(order)
+		if errors.Is(err, utils.ErrOutOfStock) {
+			return nil, h.RespErr(c, 409, "not enough stock", err.Error())
+		}
+		return nil, h.RespErr(c, 500, "error creating order", err.Error())
+	}
+
+	piID, err := h.pmSvc.MakePayment(cusID, body.PaymentID, price, paymentKey(c, usrID))
+
+	//* Saved first, the events of a declined payment come for it too
+	if piID != "" {
+		if err := h.ordSvc.SetPaymentIntentID(order.ID, piID); err != nil {
+			utils.PrintColor("red", "Error saving order payment intent")
+		}
+
+		order.PaymentIntentID = piID
+	}
+
+	if err != nil {
+		h.abortOrder(order.ID)
+		return nil, h.RespErr(c, 500, "error making the payment", err.Error())
+	}
+
+	//* The paid status is set by the payment webhook
+
+	return &order, nil
+}
+
+// releaseCoupon gives back the coupon use of an order that could not
+// be created.
+func (h *OrderHandler) releaseCoupon(ord domain.Order) {
+	if !ord.CouponRedeemed {
+		return
+	}
+
+	if err := h.cpnSvc.Release(ord.CouponCode, ord.UserID); err != nil {
+		utils.PrintColor("red", "Error releasing the coupon: ", err)
+	}
+}
+
+// abortOrder cancels an order that could not be charged, which also
+// puts its stock and its coupon back.
+func (h *OrderHandler) abortOrder(ID uuid.UUID) {
+	if err := h.ordSvc.UpdateStatus(ID, utils.StatusCancelled); err != nil {
+		utils.PrintColor("red", "Error cancelling the order: ", err)
+	}
+}
+
+// paymentKey scopes the client idempotency key to the user (or the
+// order) because the payment provider shares the keys across the whole account.
+func paymentKey(c *fiber.Ctx, scope uuid.UUID) string {
+	key := c.Get(utils.IdempotencyHeader)
+
+	if key == "" {
+		return ""
+	}
+
+	return scope.String() + "-" + key
 }
@@ -0,0 +1,44 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Delete order handler
+// @Summary      Delete order
+// @Description  Delete an order that was not paid or was refunded. The stock of pending orders goes back
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Router       /order/delete/{id} [delete]
+func (h *OrderHandler) DeleteOrder(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	if err := h.ordSvc.Delete(uid); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "order not found")
+		}
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the order cannot be deleted", err.Error())
+		}
+		return h.RespErr(c, 500, "error deleting order", err.Error())
+	}
+
+	return h.RespOK(c, 200, "order deleted")
+}
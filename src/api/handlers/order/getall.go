@@ -0,0 +1,46 @@
+package order
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get orders handler
+// @Summary      Get orders
+// @Description  Get a page of all orders, newest first. Send the next cursor of a page to get the following one
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status   query string false "Order status" Enums(pending, paid, processing, shipped, delivered, cancelled, refunded)
+// @Param        paid     query string false "Paid flag" Enums(true, false)
+// @Param        user_id  query string false "Owner uuid"
+// @Param        from     query int    false "Created at or after (unix seconds)"
+// @Param        to       query int    false "Created before (unix seconds)"
+// @Param        limit    query int    false "Page size, 20 by default" maximum(100)
+// @Param        cursor   query string false "Next cursor of the previous page"
+// @Success      200  {object}  dtos.OrdersPageRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /order/all [get]
+func (h *OrderHandler) GetAllOrders(c *fiber.Ctx) error {
+	query := dtos.OrderFilterDTO{}
+
+	if err := c.QueryParser(&query); err != nil {
+		return h.RespErr(c, 422, "error parsing the query", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&query); err != nil {
+		return h.RespValErr(c, 400, "one or more filters are invalid", err)
+	}
+
+	page, err := h.ordSvc.GetPage(query.AdaptToOrderFilter())
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting orders", err.Error())
+	}
+
+	return h.RespOK(c, 200, "orders page", page)
+}
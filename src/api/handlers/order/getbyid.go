@@ -0,0 +1,49 @@
+package order
+
+import (
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Get order handler
+// @Summary      Get order
+// @Description  Get an order by id. Users only see their own orders
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.OrderRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Router       /order/get/{id} [get]
+func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	ord, err := h.ordSvc.GetByID(uid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting order", err.Error())
+	}
+
+	//* Other users orders look like they do not exist
+	if ord == nil || (ord.UserID != ud.ID && ud.Role == utils.UserRole) {
+		return h.RespErr(c, 404, "order not found")
+	}
+
+	return h.RespOK(c, 200, "order found", ord)
+}
@@ -3,7 +3,9 @@ package order
 import (
 	"github.com/ZaphCode/clean-arch/src/api/shared"
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/invoice"
 	"github.com/ZaphCode/clean-arch/src/services/payment"
+	"github.com/ZaphCode/clean-arch/src/services/tax"
 	"github.com/ZaphCode/clean-arch/src/services/validation"
 )
 
@@ -13,6 +15,13 @@ type OrderHandler struct {
 	ordSvc  domain.OrderService
 	pmSvc   payment.PaymentService
 	prodSvc domain.ProductService
+	cartSvc domain.CartService
+	cpnSvc  domain.CouponService
+	shipSvc domain.ShippingService
+	addrSvc domain.AddressService
+	invSvc  domain.InvoiceService
+	taxCalc tax.TaxCalculator
+	invGen  invoice.InvoiceGenerator
 	vldSvc  validation.ValidationService
 }
 
@@ -20,6 +29,13 @@ func NewOrderHandler(
 	usrSvc domain.UserService,
 	ordSvc domain.OrderService,
 	prodSvc domain.ProductService,
+	cartSvc domain.CartService,
+	cpnSvc domain.CouponService,
+	shipSvc domain.ShippingService,
+	addrSvc domain.AddressService,
+	invSvc domain.InvoiceService,
+	taxCalc tax.TaxCalculator,
+	invGen invoice.InvoiceGenerator,
 	pmSvc payment.PaymentService,
 	vldSvc validation.ValidationService,
 ) *OrderHandler {
@@ -27,6 +43,13 @@ func NewOrderHandler(
 		usrSvc:  usrSvc,
 		prodSvc: prodSvc,
 		ordSvc:  ordSvc,
+		cartSvc: cartSvc,
+		cpnSvc:  cpnSvc,
+		shipSvc: shipSvc,
+		addrSvc: addrSvc,
+		invSvc:  invSvc,
+		taxCalc: taxCalc,
+		invGen:  invGen,
 		pmSvc:   pmSvc,
 		vldSvc:  vldSvc,
 	}
@@ -0,0 +1,115 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/services/invoice"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Get order invoice handler
+// @Summary      Get order invoice
+// @Description  Download the invoice of a paid order as pdf or html. The first download gives the order its invoice number. Only the owner and admins can get it
+// @Tags         order
+// @Produce      application/pdf
+// @Produce      html
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        format query string false "Invoice format" Enums(pdf, html)
+// @Success      200  {file}    file
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.RespErrDTO
+// @Router       /order/{id}/invoice [get]
+func (h *OrderHandler) GetOrderInvoice(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	format := c.Query("format", "pdf")
+
+	if format != "pdf" && format != "html" {
+		return h.RespErr(c, 400, "invalid format", "use pdf or html")
+	}
+
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	ord, err := h.ordSvc.GetByID(uid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting order", err.Error())
+	}
+
+	//* Other users orders look like they do not exist
+	if ord == nil || (ord.UserID != ud.ID && ud.Role != utils.AdminRole) {
+		return h.RespErr(c, 404, "order not found")
+	}
+
+	inv, err := h.invSvc.GetOrIssue(uid)
+
+	if err != nil {
+		if errors.Is(err, utils.ErrNotInvoiceable) {
+			return h.RespErr(c, 409, "the order cannot be invoiced", err.Error())
+		}
+		return h.RespErr(c, 500, "error issuing invoice", err.Error())
+	}
+
+	usr, err := h.usrSvc.GetByID(ord.UserID)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting user", err.Error())
+	}
+
+	addr, err := h.addrSvc.GetByID(ord.AddressID)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting address", err.Error())
+	}
+
+	//* Deleted users or addresses do not block the invoice
+	if usr == nil {
+		usr = &domain.User{}
+	}
+
+	if addr == nil {
+		addr = &domain.Address{}
+	}
+
+	doc := invoice.NewDocument(*inv, *ord, *usr, *addr)
+
+	if format == "html" {
+		html, err := h.invGen.HTML(doc)
+
+		if err != nil {
+			return h.RespErr(c, 500, "error generating invoice", err.Error())
+		}
+
+		c.Type("html", "utf-8")
+
+		return c.Status(200).Send(html)
+	}
+
+	pdf, err := h.invGen.PDF(doc)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error generating invoice", err.Error())
+	}
+
+	c.Type("pdf")
+	c.Attachment(doc.Number + ".pdf")
+
+	return c.Status(200).Send(pdf)
+}
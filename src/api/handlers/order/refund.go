@@ -0,0 +1,86 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Refund order handler
+// @Summary      Refund order
+// @Description  Give back all or part of the order amount. Zero amount refunds what is left
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        refund_data  body dtos.RefundOrderDTO true "refund data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /order/refund/{id} [post]
+func (h *OrderHandler) RefundOrder(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	body := dtos.RefundOrderDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	left, err := h.ordSvc.RefundableAmount(uid)
+
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the order cannot be refunded", err.Error())
+		}
+		return h.RespErr(c, 500, "error getting order", err.Error())
+	}
+
+	amount := domain.NewMoney(body.Amount, left.Currency)
+
+	if amount.IsZero() {
+		amount = left
+	}
+
+	if amount.Amount > left.Amount {
+		return h.RespErr(c, 400, "invalid refund amount", "the amount is bigger than what is left to refund")
+	}
+
+	ord, err := h.ordSvc.GetByID(uid)
+
+	if err != nil || ord == nil {
+		return h.RespErr(c, 500, "error getting order")
+	}
+
+	refunded, err := h.pmSvc.Refund(ord.PaymentIntentID, amount, paymentKey(c, uid))
+
+	if err != nil {
+		return h.RespErr(c, 500, "error making the refund", err.Error())
+	}
+
+	//* The webhook may have recorded it already, then nothing changes
+	if err := h.ordSvc.RegisterRefund(uid, refunded); err != nil {
+		return h.RespErr(c, 500, "refund made but the order was not updated", err.Error())
+	}
+
+	return h.RespOK(c, 200, "order refunded", fiber.Map{
+		"amount": amount,
+	})
+}
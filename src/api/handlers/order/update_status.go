@@ -0,0 +1,58 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Update order status handler
+// @Summary      Update order status
+// @Description  Move an order to the next status of its lifecycle
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "order uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        status_data  body dtos.UpdateOrderStatusDTO true "new status"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /order/status/{id} [put]
+func (h *OrderHandler) UpdateOrderStatus(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid order id")
+	}
+
+	body := dtos.UpdateOrderStatusDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.ordSvc.UpdateStatus(uid, body.Status); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "order not found")
+		}
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "invalid status change", err.Error())
+		}
+		return h.RespErr(c, 500, "error updating order status", err.Error())
+	}
+
+	return h.RespOK(c, 200, "order status updated")
+}
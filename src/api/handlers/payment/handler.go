@@ -0,0 +1,23 @@
+package payment
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/payment"
+)
+
+type PaymentHandler struct {
+	shared.Responder
+	ordSvc domain.OrderService
+	pmSvc  payment.PaymentService
+}
+
+func NewPaymentHandler(
+	ordSvc domain.OrderService,
+	pmSvc payment.PaymentService,
+) *PaymentHandler {
+	return &PaymentHandler{
+		ordSvc: ordSvc,
+		pmSvc:  pmSvc,
+	}
+}
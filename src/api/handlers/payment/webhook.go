@@ -0,0 +1,37 @@
+package payment
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Payment webhook handler
+// @Summary      Payment webhook
+// @Description  Receive the payment provider events and update the orders
+// @Tags         payment
+// @Accept       json
+// @Produce      json
+// @Param        Stripe-Signature  header string true "event signature"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      400  {object}  dtos.DetailRespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /payment/webhook [post]
+func (h *PaymentHandler) Webhook(c *fiber.Ctx) error {
+	evt, err := h.pmSvc.ParseWebhookEvent(c.Body(), c.Get("Stripe-Signature"))
+
+	if err != nil {
+		return h.RespErr(c, 400, "invalid event", err.Error())
+	}
+
+	if err := h.ordSvc.HandlePaymentEvent(evt); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "order not found", err.Error())
+		}
+		return h.RespErr(c, 500, "error handling the event", err.Error())
+	}
+
+	return h.RespOK(c, 200, "event received")
+}
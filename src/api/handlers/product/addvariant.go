@@ -0,0 +1,60 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Add variant handler
+// @Summary      Add product variant
+// @Description  Add a variant to a product. It must pick one value of every product option
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        variant_data  body dtos.NewVariantDTO true "variant data"
+// @Success      201  {object}  dtos.VariantRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/{id}/variant/create [post]
+func (h *ProductHandler) AddVariant(c *fiber.Ctx) error {
+	pid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	body := dtos.NewVariantDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	p, err := h.prodSvc.GetByID(pid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting product", err.Error())
+	}
+
+	if p == nil {
+		return h.RespErr(c, 404, "product not found")
+	}
+
+	v := body.AdaptToVariant(p.Price.Currency)
+
+	if err := h.prodSvc.AddVariant(pid, &v); err != nil {
+		return h.variantErr(c, "error adding variant", err)
+	}
+
+	return h.RespOK(c, 201, "variant added", v)
+}
@@ -34,7 +34,7 @@ func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 	prod := body.AdaptToProduct()
 
 	if err := h.prodSvc.Create(&prod); err != nil {
-		return h.RespErr(c, 500, "error creating product", err.Error())
+		return h.variantErr(c, "error creating product", err)
 	}
 
 	return h.RespOK(c, 201, "product created", prod)
@@ -1,22 +1,52 @@
 package product
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
 
 // * Get products handler
 // @Summary      Get products
-// @Description  Get all products
+// @Description  Get a page of products, newest first by default. Send the next cursor of a page to get the following one. A price range can only be sorted by price
 // @Tags         product
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  dtos.ProductsRespOKDTO
+// @Param        category   query string   false "Category name"
+// @Param        tags       query []string false "Any of the tags" collectionFormat(csv)
+// @Param        min_price  query int      false "Min price in minor units"
+// @Param        max_price  query int      false "Max price in minor units"
+// @Param        available  query string   false "Available flag" Enums(true, false)
+// @Param        sort_by    query string   false "Sort field" Enums(price, name, created_at)
+// @Param        order      query string   false "Sort order" Enums(asc, desc)
+// @Param        limit      query int      false "Page size, 20 by default" maximum(100)
+// @Param        cursor     query string   false "Next cursor of the previous page"
+// @Success      200  {object}  dtos.ProductsPageRespOKDTO
 // @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
 // @Router       /product/all [get]
 func (h *ProductHandler) GetProducts(c *fiber.Ctx) error {
-	ps, err := h.prodSvc.GetAll()
+	query := dtos.ProductFilterDTO{}
+
+	if err := c.QueryParser(&query); err != nil {
+		return h.RespErr(c, 422, "error parsing the query", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&query); err != nil {
+		return h.RespValErr(c, 400, "one or more filters are invalid", err)
+	}
+
+	page, err := h.prodSvc.GetPage(query.AdaptToProductFilter())
 
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidFilter) {
+			return h.RespErr(c, 400, "invalid filters", err.Error())
+		}
 		return h.RespErr(c, 500, "error getting products", err.Error())
 	}
 
-	return h.RespOK(c, 200, "all products", ps)
+	return h.RespOK(c, 200, "products page", page)
 }
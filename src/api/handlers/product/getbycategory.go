@@ -0,0 +1,38 @@
+package product
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get products by category handler
+// @Summary      Get products by category
+// @Description  Get the products of a category
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        name  path string true "Category name" example(clothes)
+// @Success      200  {object}  dtos.ProductsRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Router       /product/category/{name} [get]
+func (h *ProductHandler) GetProductsByCategory(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	cat, err := h.catSvc.GetByName(name)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting category", err.Error())
+	}
+
+	if cat == nil {
+		return h.RespErr(c, 404, "category not found")
+	}
+
+	ps, err := h.prodSvc.GetByCategory(cat.Name)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting products", err.Error())
+	}
+
+	return h.RespOK(c, 200, "products of the category", ps)
+}
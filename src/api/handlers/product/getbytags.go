@@ -0,0 +1,38 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get products by tags handler
+// @Summary      Get products by tags
+// @Description  Get the products with any of the tags
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        tags  query []string true "Tags, 10 at most" collectionFormat(csv)
+// @Success      200  {object}  dtos.ProductsRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/tags [get]
+func (h *ProductHandler) GetProductsByTags(c *fiber.Ctx) error {
+	query := dtos.ProductTagsDTO{}
+
+	if err := c.QueryParser(&query); err != nil {
+		return h.RespErr(c, 422, "error parsing the query", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&query); err != nil {
+		return h.RespValErr(c, 400, "invalid tags", err)
+	}
+
+	ps, err := h.prodSvc.GetByTags(query.Tags...)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting products", err.Error())
+	}
+
+	return h.RespOK(c, 200, "products with the tags", ps)
+}
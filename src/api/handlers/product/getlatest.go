@@ -0,0 +1,42 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get latest products handler
+// @Summary      Get latest products
+// @Description  Get the newest products first
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        limit  query int false "How many products, 10 by default" maximum(100)
+// @Success      200  {object}  dtos.ProductsRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/latest [get]
+func (h *ProductHandler) GetLatestProducts(c *fiber.Ctx) error {
+	query := dtos.LatestProductsDTO{}
+
+	if err := c.QueryParser(&query); err != nil {
+		return h.RespErr(c, 422, "error parsing the query", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&query); err != nil {
+		return h.RespValErr(c, 400, "invalid limit", err)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	ps, err := h.prodSvc.GetLatestProds(query.Limit)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting products", err.Error())
+	}
+
+	return h.RespOK(c, 200, "latest products", ps)
+}
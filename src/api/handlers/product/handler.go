@@ -1,26 +1,52 @@
 package product
 
 import (
+	"errors"
+
 	"github.com/ZaphCode/clean-arch/src/api/shared"
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/search"
+	"github.com/ZaphCode/clean-arch/src/services/upload"
 	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
 )
 
 type ProductHandler struct {
 	shared.Responder
 	prodSvc domain.ProductService
 	catSvc  domain.CategoryService
+	srchSvc search.SearchService
+	uplSvc  upload.UploadService
 	vldSvc  validation.ValidationService
 }
 
 func NewProductHandler(
 	prodSvc domain.ProductService,
 	catSvc domain.CategoryService,
+	srchSvc search.SearchService,
+	uplSvc upload.UploadService,
 	vldSvc validation.ValidationService,
 ) *ProductHandler {
 	return &ProductHandler{
 		prodSvc: prodSvc,
 		catSvc:  catSvc,
+		srchSvc: srchSvc,
+		uplSvc:  uplSvc,
 		vldSvc:  vldSvc,
 	}
 }
+
+// variantErr responds with the status that fits the variant error.
+func (h *ProductHandler) variantErr(c *fiber.Ctx, msg string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.RespErr(c, 404, msg, err.Error())
+	case errors.Is(err, utils.ErrInvalidVariant):
+		return h.RespErr(c, 400, msg, err.Error())
+	case errors.Is(err, utils.ErrTooManyImages):
+		return h.RespErr(c, 409, msg, err.Error())
+	default:
+		return h.RespErr(c, 500, msg, err.Error())
+	}
+}
@@ -0,0 +1,49 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Remove product image handler
+// @Summary      Remove product image
+// @Description  Remove an image from the product and delete its uploaded files
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        iid  path string true "image uuid" example(5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Router       /product/{id}/image/{iid} [delete]
+func (h *ProductHandler) RemoveProductImage(c *fiber.Ctx) error {
+	pid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	iid, err := uuid.Parse(c.Params("iid"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid image id")
+	}
+
+	img, err := h.prodSvc.RemoveImage(pid, iid)
+
+	if err != nil {
+		return h.variantErr(c, "error removing image", err)
+	}
+
+	//* The image is out of the product already, the files left are only logged
+	if err := h.uplSvc.RemoveImage(*img); err != nil {
+		utils.PrintColor("red", "Error removing the image files: ", err)
+	}
+
+	return h.RespOK(c, 200, "image removed")
+}
@@ -0,0 +1,41 @@
+package product
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Remove variant handler
+// @Summary      Remove product variant
+// @Description  Remove a product variant
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        vid  path string true "variant uuid" example(5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Router       /product/{id}/variant/delete/{vid} [delete]
+func (h *ProductHandler) RemoveVariant(c *fiber.Ctx) error {
+	pid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	vid, err := uuid.Parse(c.Params("vid"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid variant id")
+	}
+
+	if err := h.prodSvc.RemoveVariant(pid, vid); err != nil {
+		return h.variantErr(c, "error removing variant", err)
+	}
+
+	return h.RespOK(c, 200, "variant removed")
+}
@@ -0,0 +1,62 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/services/search"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Search products handler
+// @Summary      Search products
+// @Description  Search the products by name, description, tags and category. The best matches go first, small typos are tolerated and the last word can be unfinished. The matched words come wrapped in <mark> tags in the highlights
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Param        q         query string true  "Search text" maxlength(100)
+// @Param        category  query string false "Only of this category"
+// @Param        tag       query string false "Only with this tag"
+// @Param        limit     query int    false "Page size, 20 by default" maximum(50)
+// @Param        offset    query int    false "Hits to skip"
+// @Success      200  {object}  dtos.SearchRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/search [get]
+func (h *ProductHandler) SearchProducts(c *fiber.Ctx) error {
+	query := dtos.SearchQueryDTO{}
+
+	if err := c.QueryParser(&query); err != nil {
+		return h.RespErr(c, 422, "error parsing the query", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&query); err != nil {
+		return h.RespValErr(c, 400, "invalid search", err)
+	}
+
+	res, err := h.srchSvc.Search(search.Query{
+		Text:     query.Q,
+		Category: query.Category,
+		Tag:      query.Tag,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	})
+
+	if err != nil {
+		return h.RespErr(c, 500, "error searching the products", err.Error())
+	}
+
+	//* The stock changes with the orders, not through the index
+	for i, hit := range res.Hits {
+		prod, err := h.prodSvc.GetByID(hit.Product.ID)
+
+		if err != nil {
+			return h.RespErr(c, 500, "error getting product", err.Error())
+		}
+
+		if prod != nil {
+			res.Hits[i].Product = *prod
+		}
+	}
+
+	return h.RespOK(c, 200, "search results", res)
+}
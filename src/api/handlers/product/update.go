@@ -8,7 +8,7 @@ import (
 
 // * Update prod handler
 // @Summary      Update product
-// @Description  Update product
+// @Description  Update product, the images_url are added to the product images
 // @Tags         product
 // @Accept       json
 // @Produce      json
@@ -19,6 +19,7 @@ import (
 // @Failure      401  {object}  dtos.AuthRespErrDTO
 // @Failure      500  {object}  dtos.DetailRespErrDTO
 // @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
 // @Failure      406  {object}  dtos.RespErrDTO
 // @Failure      400  {object}  dtos.ValidationRespErrDTO
 // @Router       /product/update/{id} [put]
@@ -42,7 +43,7 @@ func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 	uf := body.AdaptToUpdateFields()
 
 	if err := h.prodSvc.Update(uid, uf); err != nil {
-		return h.RespErr(c, 500, "error updating product", err.Error())
+		return h.variantErr(c, "error updating product", err)
 	}
 
 	return h.RespOK(c, 200, "product updated")
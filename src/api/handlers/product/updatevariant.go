@@ -0,0 +1,68 @@
+package product
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Update variant handler
+// @Summary      Update product variant
+// @Description  Update a product variant. A zero price makes it take the product price
+// @Tags         product
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        vid  path string true "variant uuid" example(5f1c2a7e-93c8-11ed-ab0f-d8bbc1a27048)
+// @Param        variant_data  body dtos.UpdateVariantDTO true "variant data"
+// @Success      200  {object}  dtos.VariantRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/{id}/variant/update/{vid} [put]
+func (h *ProductHandler) UpdateVariant(c *fiber.Ctx) error {
+	pid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	vid, err := uuid.Parse(c.Params("vid"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid variant id")
+	}
+
+	body := dtos.UpdateVariantDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	p, err := h.prodSvc.GetByID(pid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting product", err.Error())
+	}
+
+	if p == nil || p.Variant(vid) == nil {
+		return h.RespErr(c, 404, "variant not found")
+	}
+
+	v := *p.Variant(vid)
+	body.ApplyTo(&v, p.Price.Currency)
+
+	if err := h.prodSvc.UpdateVariant(pid, v); err != nil {
+		return h.variantErr(c, "error updating variant", err)
+	}
+
+	return h.RespOK(c, 200, "variant updated", v)
+}
@@ -0,0 +1,105 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Upload product image handler
+// @Summary      Upload product image
+// @Description  Upload an image (png, jpeg, gif or webp, up to 8 MB, 6000x6000 and 24 megapixels) and add it to the product images in every rendition
+// @Tags         product
+// @Accept       mpfd
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        image  formData file true "product image"
+// @Success      201  {object}  dtos.ImageRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.RespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /product/{id}/image [post]
+func (h *ProductHandler) UploadProductImage(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	fh, err := c.FormFile("image")
+
+	if err != nil {
+		return h.RespErr(c, 422, "error parsing the image", err.Error())
+	}
+
+	p, err := h.prodSvc.GetByID(uid)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting product", err.Error())
+	}
+
+	if p == nil {
+		return h.RespErr(c, 404, "product not found")
+	}
+
+	if len(p.Images) >= utils.MaxProductImages {
+		return h.RespErr(c, 409, fmt.Sprintf("a product can have up to %d images", utils.MaxProductImages))
+	}
+
+	f, err := shared.OpenImage(fh, "image", h.vldSvc)
+
+	var verrs validation.ValidationErrors
+
+	if errors.As(err, &verrs) {
+		return h.RespValErr(c, 400, "invalid image", verrs)
+	}
+
+	if err != nil {
+		return h.RespErr(c, 500, "error reading the image", err.Error())
+	}
+
+	defer f.Close()
+
+	img, err := h.uplSvc.UploadImage(f)
+
+	if errors.As(err, &verrs) {
+		return h.RespValErr(c, 400, "invalid image", verrs)
+	}
+
+	if errors.Is(err, utils.ErrInvalidImage) {
+		return h.RespErr(c, 400, "invalid image", err.Error())
+	}
+
+	if err != nil {
+		return h.RespErr(c, 500, "error uploading the image", err.Error())
+	}
+
+	//* The limit is checked again with the image added, other uploads may have filled it
+	if err := h.prodSvc.AddImages(uid, *img); err != nil {
+		if rerr := h.uplSvc.RemoveImage(*img); rerr != nil {
+			utils.PrintColor("red", "Error removing the unused image: ", rerr)
+		}
+
+		if errors.Is(err, utils.ErrTooManyImages) {
+			return h.RespErr(c, 409, fmt.Sprintf("a product can have up to %d images", utils.MaxProductImages))
+		}
+
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "product not found")
+		}
+
+		return h.RespErr(c, 500, "error updating product", err.Error())
+	}
+
+	return h.RespOK(c, 201, "image uploaded", img)
+}
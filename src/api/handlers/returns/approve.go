@@ -0,0 +1,111 @@
+package returns
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Approve return handler
+// @Summary      Approve return
+// @Description  Approve a requested return and refund the part of the order paid for its items
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "return uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        moderation_data  body dtos.ModerateReturnDTO true "moderation data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /return/approve/{id} [put]
+func (h *ReturnHandler) ApproveReturn(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid return id")
+	}
+
+	body := dtos.ModerateReturnDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	//* Claimed first, a second approval of the same return stops here
+	if err := h.retSvc.StartApproval(uid); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "return not found")
+		}
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the return cannot be approved", err.Error())
+		}
+		return h.RespErr(c, 500, "error approving the return", err.Error())
+	}
+
+	amount, err := h.retSvc.RefundAmount(uid)
+
+	if err != nil {
+		h.cancelApproval(uid)
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the return cannot be approved", err.Error())
+		}
+		return h.RespErr(c, 500, "error getting refund amount", err.Error())
+	}
+
+	ret, err := h.retSvc.GetByID(uid)
+
+	if err != nil || ret == nil {
+		h.cancelApproval(uid)
+		return h.RespErr(c, 500, "error getting return")
+	}
+
+	ord, err := h.ordSvc.GetByID(ret.OrderID)
+
+	if err != nil || ord == nil {
+		h.cancelApproval(uid)
+		return h.RespErr(c, 500, "error getting order")
+	}
+
+	if !amount.IsZero() {
+		refunded, err := h.pmSvc.Refund(ord.PaymentIntentID, amount, "return-"+uid.String())
+
+		if err != nil {
+			h.cancelApproval(uid)
+			return h.RespErr(c, 500, "error making the refund", err.Error())
+		}
+
+		//* From here the return stays claimed, approving it again would count the refund twice
+		if err := h.ordSvc.RegisterRefund(ord.ID, refunded); err != nil {
+			return h.RespErr(c, 500, "refund made but the order was not updated", err.Error())
+		}
+	}
+
+	if err := h.retSvc.Approve(uid, body.Note, amount); err != nil {
+		return h.RespErr(c, 500, "refund made but the return was not updated", err.Error())
+	}
+
+	return h.RespOK(c, 200, "return approved", fiber.Map{
+		"amount": amount,
+	})
+}
+
+// cancelApproval gives the return back to the moderators when it
+// could not be refunded.
+func (h *ReturnHandler) cancelApproval(ID uuid.UUID) {
+	if err := h.retSvc.CancelApproval(ID); err != nil {
+		utils.PrintColor("red", "Error cancelling the return approval: ", err)
+	}
+}
@@ -0,0 +1,54 @@
+package returns
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Create return handler
+// @Summary      Request a return
+// @Description  Ask to send back delivered items of an auth user order
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        return_data  body dtos.NewReturnDTO true "return data"
+// @Success      201  {object}  dtos.ReturnRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /return/new [post]
+func (h *ReturnHandler) CreateReturn(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	body := dtos.NewReturnDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	ret := body.AdaptToReturn(ud.ID)
+
+	if err := h.retSvc.Create(&ret); err != nil {
+		if errors.Is(err, utils.ErrInvalidReturn) {
+			return h.RespErr(c, 409, "the return cannot be requested", err.Error())
+		}
+		return h.RespErr(c, 500, "error creating return", err.Error())
+	}
+
+	return h.RespOK(c, 201, "return requested", ret)
+}
@@ -0,0 +1,24 @@
+package returns
+
+import "github.com/gofiber/fiber/v2"
+
+// * Get returns handler
+// @Summary      Get returns
+// @Description  Get all returns
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.ReturnsRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /return/all [get]
+func (h *ReturnHandler) GetReturns(c *fiber.Ctx) error {
+	rets, err := h.retSvc.GetAll()
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting returns", err.Error())
+	}
+
+	return h.RespOK(c, 200, "all returns", rets)
+}
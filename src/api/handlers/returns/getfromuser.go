@@ -0,0 +1,33 @@
+package returns
+
+import (
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get user returns handler
+// @Summary      Get auth user returns
+// @Description  Get all returns from auth user
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.ReturnsRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /return/list [get]
+func (h *ReturnHandler) GetUserReturns(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	rets, err := h.retSvc.GetAllByUserID(ud.ID)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting user returns", err.Error())
+	}
+
+	return h.RespOK(c, 200, "all returns", rets)
+}
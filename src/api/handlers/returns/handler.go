@@ -0,0 +1,30 @@
+package returns
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/payment"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+)
+
+type ReturnHandler struct {
+	shared.Responder
+	retSvc domain.ReturnService
+	ordSvc domain.OrderService
+	pmSvc  payment.PaymentService
+	vldSvc validation.ValidationService
+}
+
+func NewReturnHandler(
+	retSvc domain.ReturnService,
+	ordSvc domain.OrderService,
+	pmSvc payment.PaymentService,
+	vldSvc validation.ValidationService,
+) *ReturnHandler {
+	return &ReturnHandler{
+		retSvc: retSvc,
+		ordSvc: ordSvc,
+		pmSvc:  pmSvc,
+		vldSvc: vldSvc,
+	}
+}
@@ -0,0 +1,58 @@
+package returns
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Reject return handler
+// @Summary      Reject return
+// @Description  Reject a requested return. Its items can be asked for again
+// @Tags         return
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "return uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        moderation_data  body dtos.ModerateReturnDTO true "moderation data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /return/reject/{id} [put]
+func (h *ReturnHandler) RejectReturn(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid return id")
+	}
+
+	body := dtos.ModerateReturnDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.retSvc.Reject(uid, body.Note); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return h.RespErr(c, 404, "return not found")
+		}
+		if errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the return cannot be rejected", err.Error())
+		}
+		return h.RespErr(c, 500, "error rejecting return", err.Error())
+	}
+
+	return h.RespOK(c, 200, "return rejected")
+}
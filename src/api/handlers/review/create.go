@@ -0,0 +1,50 @@
+package review
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Create review handler
+// @Summary      Review a product
+// @Description  Review a product. Only users with a paid order of the product can review it, once
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        review_data  body dtos.NewReviewDTO true "review data"
+// @Success      201  {object}  dtos.ReviewRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Failure      403  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /review/create [post]
+func (h *ReviewHandler) CreateReview(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	body := dtos.NewReviewDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	rev := body.AdaptToReview(ud.ID)
+
+	if err := h.revSvc.Create(&rev); err != nil {
+		return h.reviewErr(c, "the review cannot be created", err)
+	}
+
+	return h.RespOK(c, 201, "review created", rev)
+}
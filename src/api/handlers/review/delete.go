@@ -0,0 +1,34 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Delete review handler
+// @Summary      Delete review
+// @Description  Delete a review
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "review uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Router       /review/delete/{id} [delete]
+func (h *ReviewHandler) DeleteReview(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid review id")
+	}
+
+	if err := h.revSvc.Delete(uid); err != nil {
+		return h.reviewErr(c, "error deleting review", err)
+	}
+
+	return h.RespOK(c, 200, "review deleted")
+}
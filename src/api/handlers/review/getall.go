@@ -0,0 +1,22 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Get all product reviews handler
+// @Summary      Get all product reviews
+// @Description  Get the reviews of a product, the hidden ones too
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.ReviewsRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /review/product/{id}/all [get]
+func (h *ReviewHandler) GetAllProductReviews(c *fiber.Ctx) error {
+	return h.productReviews(c, true)
+}
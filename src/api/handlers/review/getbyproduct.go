@@ -0,0 +1,37 @@
+package review
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Get product reviews handler
+// @Summary      Get product reviews
+// @Description  Get the visible reviews of a product, the newest first
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "product uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.ReviewsRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /review/product/{id} [get]
+func (h *ReviewHandler) GetProductReviews(c *fiber.Ctx) error {
+	return h.productReviews(c, false)
+}
+
+func (h *ReviewHandler) productReviews(c *fiber.Ctx, withHidden bool) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid product id")
+	}
+
+	revs, err := h.revSvc.GetAllByProductID(uid, withHidden)
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting reviews", err.Error())
+	}
+
+	return h.RespOK(c, 200, "product reviews", revs)
+}
@@ -0,0 +1,41 @@
+package review
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReviewHandler struct {
+	shared.Responder
+	revSvc domain.ReviewService
+	vldSvc validation.ValidationService
+}
+
+func NewReviewHandler(
+	revSvc domain.ReviewService,
+	vldSvc validation.ValidationService,
+) *ReviewHandler {
+	return &ReviewHandler{
+		revSvc: revSvc,
+		vldSvc: vldSvc,
+	}
+}
+
+// reviewErr responds with the status that fits the review error.
+func (h *ReviewHandler) reviewErr(c *fiber.Ctx, msg string, err error) error {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return h.RespErr(c, 404, msg, err.Error())
+	case errors.Is(err, utils.ErrNotPurchased):
+		return h.RespErr(c, 403, msg, err.Error())
+	case errors.Is(err, utils.ErrAlreadyReviewed):
+		return h.RespErr(c, 409, msg, err.Error())
+	default:
+		return h.RespErr(c, 500, msg, err.Error())
+	}
+}
@@ -0,0 +1,47 @@
+package review
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Hide review handler
+// @Summary      Hide review
+// @Description  Hide a review or show it again. The hidden reviews do not count for the product rating
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "review uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        hide_data  body dtos.HideReviewDTO true "hide data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      404  {object}  dtos.DetailRespErrDTO
+// @Router       /review/hide/{id} [put]
+func (h *ReviewHandler) HideReview(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid review id")
+	}
+
+	body := dtos.HideReviewDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.revSvc.SetHidden(uid, body.Hidden); err != nil {
+		return h.reviewErr(c, "error hiding review", err)
+	}
+
+	if !body.Hidden {
+		return h.RespOK(c, 200, "review shown")
+	}
+
+	return h.RespOK(c, 200, "review hidden")
+}
@@ -0,0 +1,47 @@
+package shipment
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Create shipment handler
+// @Summary      Create new shipment
+// @Description  Record a shipment of a paid order. Without items it ships everything left. The order moves to shipped once every item has been shipped
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shipment_data  body dtos.NewShipmentDTO true "shipment data"
+// @Success      201  {object}  dtos.ShipmentRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /shipment/create [post]
+func (h *ShipmentHandler) CreateShipment(c *fiber.Ctx) error {
+	body := dtos.NewShipmentDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	shp := body.AdaptToShipment()
+
+	if err := h.shpSvc.Create(&shp); err != nil {
+		if errors.Is(err, utils.ErrInvalidShipment) {
+			return h.RespErr(c, 409, "the shipment cannot be created", err.Error())
+		}
+		return h.RespErr(c, 500, "error creating shipment", err.Error())
+	}
+
+	return h.RespOK(c, 201, "shipment created", shp)
+}
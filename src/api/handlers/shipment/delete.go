@@ -0,0 +1,40 @@
+package shipment
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Delete shipment handler
+// @Summary      Delete shipment
+// @Description  Delete a shipment recorded by mistake while the order still has items to ship
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "shipment uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /shipment/delete/{id} [delete]
+func (h *ShipmentHandler) DeleteShipment(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid shipment id")
+	}
+
+	if err := h.shpSvc.Delete(uid); err != nil {
+		if errors.Is(err, utils.ErrInvalidShipment) {
+			return h.RespErr(c, 409, "the shipment cannot be deleted", err.Error())
+		}
+		return h.RespErr(c, 500, "error deleting shipment", err.Error())
+	}
+
+	return h.RespOK(c, 200, "shipment deleted")
+}
@@ -0,0 +1,26 @@
+package shipment
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+)
+
+type ShipmentHandler struct {
+	shared.Responder
+	shpSvc domain.ShipmentService
+	ordSvc domain.OrderService
+	vldSvc validation.ValidationService
+}
+
+func NewShipmentHandler(
+	shpSvc domain.ShipmentService,
+	ordSvc domain.OrderService,
+	vldSvc validation.ValidationService,
+) *ShipmentHandler {
+	return &ShipmentHandler{
+		shpSvc: shpSvc,
+		ordSvc: ordSvc,
+		vldSvc: vldSvc,
+	}
+}
@@ -0,0 +1,54 @@
+package shipment
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Update shipment handler
+// @Summary      Update shipment
+// @Description  Update the tracking data or mark the shipment as delivered. The order moves to delivered once every shipment has arrived
+// @Tags         shipment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "shipment uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        shipment_data  body dtos.UpdateShipmentDTO true "shipment data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      409  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /shipment/update/{id} [put]
+func (h *ShipmentHandler) UpdateShipment(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid shipment id")
+	}
+
+	body := dtos.UpdateShipmentDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.shpSvc.Update(uid, body.AdaptToUpdateFields()); err != nil {
+		if errors.Is(err, utils.ErrInvalidShipment) || errors.Is(err, utils.ErrInvalidTransition) {
+			return h.RespErr(c, 409, "the shipment cannot be updated", err.Error())
+		}
+		return h.RespErr(c, 500, "error updating shipment", err.Error())
+	}
+
+	return h.RespOK(c, 200, "shipment updated")
+}
@@ -0,0 +1,40 @@
+package shipping
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Create shipping method handler
+// @Summary      Create new shipping method
+// @Description  Create shipping method with its rate table
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        shipping_data  body dtos.NewShippingMethodDTO true "shipping method data"
+// @Success      201  {object}  dtos.ShippingMethodRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /shipping/create [post]
+func (h *ShippingHandler) CreateShippingMethod(c *fiber.Ctx) error {
+	body := dtos.NewShippingMethodDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	sm := body.AdaptToShippingMethod()
+
+	if err := h.shipSvc.Create(&sm); err != nil {
+		return h.RespErr(c, 500, "error creating shipping method", err.Error())
+	}
+
+	return h.RespOK(c, 201, "shipping method created", sm)
+}
@@ -0,0 +1,33 @@
+package shipping
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Delete shipping method handler
+// @Summary      Delete shipping method
+// @Description  Delete shipping method
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "shipping method uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Router       /shipping/delete/{id} [delete]
+func (h *ShippingHandler) DeleteShippingMethod(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid shipping method id")
+	}
+
+	if err := h.shipSvc.Delete(uid); err != nil {
+		return h.RespErr(c, 500, "error deleting shipping method", err.Error())
+	}
+
+	return h.RespOK(c, 200, "shipping method deleted")
+}
@@ -0,0 +1,22 @@
+package shipping
+
+import "github.com/gofiber/fiber/v2"
+
+// * Get shipping methods handler
+// @Summary      Get shipping methods
+// @Description  Get all shipping methods with their rate tables
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  dtos.ShippingMethodsRespOKDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Router       /shipping/methods [get]
+func (h *ShippingHandler) GetShippingMethods(c *fiber.Ctx) error {
+	sms, err := h.shipSvc.GetAll()
+
+	if err != nil {
+		return h.RespErr(c, 500, "error getting shipping methods", err.Error())
+	}
+
+	return h.RespOK(c, 200, "all shipping methods", sms)
+}
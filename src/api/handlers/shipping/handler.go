@@ -0,0 +1,23 @@
+package shipping
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+)
+
+type ShippingHandler struct {
+	shared.Responder
+	shipSvc domain.ShippingService
+	vldSvc  validation.ValidationService
+}
+
+func NewShippingHandler(
+	shipSvc domain.ShippingService,
+	vldSvc validation.ValidationService,
+) *ShippingHandler {
+	return &ShippingHandler{
+		shipSvc: shipSvc,
+		vldSvc:  vldSvc,
+	}
+}
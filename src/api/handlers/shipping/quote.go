@@ -0,0 +1,46 @@
+package shipping
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Quote shipping handler
+// @Summary      Quote shipping
+// @Description  Price every active shipping method for the products and address. Cheapest first
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        quote_data  body dtos.QuoteShippingDTO true "quote data"
+// @Success      200  {object}  dtos.ShippingQuotesRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /shipping/quote [post]
+func (h *ShippingHandler) QuoteShipping(c *fiber.Ctx) error {
+	body := dtos.QuoteShippingDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	quotes, err := h.shipSvc.Quote(body.AddressID, body.Products)
+
+	if err != nil {
+		if errors.Is(err, utils.ErrNoShipping) {
+			return h.RespErr(c, 400, "the shipping cannot be quoted", err.Error())
+		}
+		return h.RespErr(c, 500, "error quoting shipping", err.Error())
+	}
+
+	return h.RespOK(c, 200, "shipping quotes", quotes)
+}
@@ -0,0 +1,47 @@
+package shipping
+
+import (
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// * Update shipping method handler
+// @Summary      Update shipping method
+// @Description  Update shipping method. The rates are replaced as a whole and the code cannot be changed
+// @Tags         shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path string true "shipping method uuid" example(3afc3021-9395-11ed-a8b6-d8bbc1a27045)
+// @Param        shipping_data  body dtos.UpdateShippingMethodDTO true "shipping method data"
+// @Success      200  {object}  dtos.RespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      406  {object}  dtos.RespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /shipping/update/{id} [put]
+func (h *ShippingHandler) UpdateShippingMethod(c *fiber.Ctx) error {
+	uid, err := uuid.Parse(c.Params("id"))
+
+	if err != nil {
+		return h.RespErr(c, 406, "invalid shipping method id")
+	}
+
+	body := dtos.UpdateShippingMethodDTO{}
+
+	if err := c.BodyParser(&body); err != nil {
+		return h.RespErr(c, 422, "error parsing the request body", err.Error())
+	}
+
+	if err := h.vldSvc.Validate(&body); err != nil {
+		return h.RespValErr(c, 400, "one or more fields are invalid", err)
+	}
+
+	if err := h.shipSvc.Update(uid, body.AdaptToUpdateFields()); err != nil {
+		return h.RespErr(c, 500, "error updating shipping method", err.Error())
+	}
+
+	return h.RespOK(c, 200, "shipping method updated")
+}
@@ -3,21 +3,25 @@ package user
 import (
 	"github.com/ZaphCode/clean-arch/src/api/shared"
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/upload"
 	"github.com/ZaphCode/clean-arch/src/services/validation"
 )
 
 type UserHandler struct {
 	shared.Responder
 	usrSvc domain.UserService
+	uplSvc upload.UploadService
 	vldSvc validation.ValidationService
 }
 
 func NewUserHandler(
 	usrSvc domain.UserService,
+	uplSvc upload.UploadService,
 	vldSvc validation.ValidationService,
 ) *UserHandler {
 	return &UserHandler{
 		usrSvc: usrSvc,
+		uplSvc: uplSvc,
 		vldSvc: vldSvc,
 	}
 }
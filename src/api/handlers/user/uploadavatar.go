@@ -0,0 +1,85 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// * Upload avatar handler
+// @Summary      Upload avatar
+// @Description  Upload an image (png, jpeg, gif or webp, up to 8 MB, 6000x6000 and 24 megapixels) as the auth user avatar, the medium rendition is used and the previous avatar is deleted
+// @Tags         user
+// @Accept       mpfd
+// @Produce      json
+// @Security     BearerAuth
+// @Param        avatar  formData file true "avatar image"
+// @Success      201  {object}  dtos.URLRespOKDTO
+// @Failure      401  {object}  dtos.AuthRespErrDTO
+// @Failure      500  {object}  dtos.DetailRespErrDTO
+// @Failure      422  {object}  dtos.DetailRespErrDTO
+// @Failure      400  {object}  dtos.ValidationRespErrDTO
+// @Router       /user/avatar [post]
+func (h *UserHandler) UploadAvatar(c *fiber.Ctx) error {
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return h.RespErr(c, 500, "internal server error", "something went wrong")
+	}
+
+	fh, err := c.FormFile("avatar")
+
+	if err != nil {
+		return h.RespErr(c, 422, "error parsing the avatar", err.Error())
+	}
+
+	f, err := shared.OpenImage(fh, "avatar", h.vldSvc)
+
+	var verrs validation.ValidationErrors
+
+	if errors.As(err, &verrs) {
+		return h.RespValErr(c, 400, "invalid image", verrs)
+	}
+
+	if err != nil {
+		return h.RespErr(c, 500, "error reading the avatar", err.Error())
+	}
+
+	defer f.Close()
+
+	img, err := h.uplSvc.UploadImage(f)
+
+	if errors.As(err, &verrs) {
+		return h.RespValErr(c, 400, "invalid image", verrs)
+	}
+
+	if errors.Is(err, utils.ErrInvalidImage) {
+		return h.RespErr(c, 400, "invalid image", err.Error())
+	}
+
+	if err != nil {
+		return h.RespErr(c, 500, "error uploading the avatar", err.Error())
+	}
+
+	prev, err := h.usrSvc.SetAvatar(ud.ID, *img)
+
+	if err != nil {
+		if rerr := h.uplSvc.RemoveImage(*img); rerr != nil {
+			utils.PrintColor("red", "Error removing the unused avatar: ", rerr)
+		}
+		return h.RespErr(c, 500, "error updating user", err.Error())
+	}
+
+	//* The previous avatar is not used anymore
+	if prev != nil {
+		if rerr := h.uplSvc.RemoveImage(*prev); rerr != nil {
+			utils.PrintColor("red", "Error removing the previous avatar: ", rerr)
+		}
+	}
+
+	return h.RespOK(c, 201, "avatar uploaded", img.URL("medium"))
+}
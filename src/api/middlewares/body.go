@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/gofiber/fiber/v2"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// BodyLimit rejects the requests with a body bigger than max before
+// it is read, by their Content-Length. The server streams the bodies
+// past fiber.DefaultBodyLimit, so they stay unread until a route
+// takes them. The chunked bodies have no length, they are read here
+// up to max. The requests skip says are left to their routes.
+func BodyLimit(max int, skip func(c *fiber.Ctx) bool) fiber.Handler {
+	var rsp shared.Responder
+
+	tooLarge := func(c *fiber.Ctx) error {
+		//* The body is left unread, so the connection cannot be reused
+		c.Context().SetConnectionClose()
+		return rsp.RespErr(c, 413, "request body too large", fmt.Sprintf("the limit is %d KB", max>>10))
+	}
+
+	return func(c *fiber.Ctx) error {
+		if skip != nil && skip(c) {
+			return c.Next()
+		}
+
+		n := c.Request().Header.ContentLength()
+
+		if n > max {
+			return tooLarge(c)
+		}
+
+		stream := c.Context().RequestBodyStream()
+
+		//* -1 is a chunked body, its size is unknown until it is read
+		if n != -1 || stream == nil {
+			return c.Next()
+		}
+
+		body, err := io.ReadAll(&limitedReader{r: stream, left: max})
+
+		if errors.Is(err, errBodyTooLarge) {
+			return tooLarge(c)
+		}
+
+		if err != nil {
+			c.Context().SetConnectionClose()
+			return rsp.RespErr(c, 400, "error reading the request body", err.Error())
+		}
+
+		c.Request().SetBody(body)
+
+		return c.Next()
+	}
+}
+
+// limitedReader counts the bytes read and fails once they are more
+// than left.
+type limitedReader struct {
+	r    io.Reader
+	left int
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.left -= n
+
+	if l.left < 0 {
+		return n, errBodyTooLarge
+	}
+
+	return n, err
+}
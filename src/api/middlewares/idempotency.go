@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/ZaphCode/clean-arch/src/api/shared"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/auth"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type IdempotencyMiddleware struct {
+	shared.Responder
+	idemSvc domain.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(
+	idemSvc domain.IdempotencyService,
+) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		idemSvc: idemSvc,
+	}
+}
+
+// Idempotent replays the first response given to a user key.
+// Requests without the header are not touched.
+func (m *IdempotencyMiddleware) Idempotent(c *fiber.Ctx) error {
+	key := c.Get(utils.IdempotencyHeader)
+
+	if key == "" {
+		return c.Next()
+	}
+
+	if len(key) > 255 {
+		return m.RespErr(c, 400, "invalid idempotency key", "the key cannot be longer than 255 characters")
+	}
+
+	ud, ok := c.Locals("user-data").(*auth.Claims)
+
+	if !ok {
+		return m.RespErr(c, 500, "parsing user claims error")
+	}
+
+	rec, err := m.idemSvc.Start(ud.ID, key, requestFingerprint(c))
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrKeyInProgress):
+			return m.RespErr(c, 409, "request already in progress", err.Error())
+		case errors.Is(err, utils.ErrKeyReused):
+			return m.RespErr(c, 422, "idempotency key already used", err.Error())
+		}
+		return m.RespErr(c, 500, "error checking idempotency key", err.Error())
+	}
+
+	if rec != nil {
+		c.Set(utils.ReplayedHeader, "true")
+		c.Set(fiber.HeaderContentType, rec.ContentType)
+		return c.Status(rec.StatusCode).SendString(rec.Response)
+	}
+
+	if err := c.Next(); err != nil {
+		if err := m.idemSvc.Abort(ud.ID, key); err != nil {
+			utils.PrintColor("red", "Error releasing idempotency key: ", err)
+		}
+		return err
+	}
+
+	res := c.Response()
+
+	err = m.idemSvc.Finish(ud.ID, key, res.StatusCode(), string(res.Header.ContentType()), string(res.Body()))
+
+	if err != nil {
+		utils.PrintColor("red", "Error saving idempotent response: ", err)
+	}
+
+	return nil
+}
+
+// requestFingerprint tells apart two requests sent with the same key.
+func requestFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method() + " " + c.Path() + "\n"))
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
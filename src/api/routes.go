@@ -4,9 +4,16 @@ import (
 	addressHandler "github.com/ZaphCode/clean-arch/src/api/handlers/address"
 	authHandler "github.com/ZaphCode/clean-arch/src/api/handlers/auth"
 	cardHandler "github.com/ZaphCode/clean-arch/src/api/handlers/card"
+	cartHandler "github.com/ZaphCode/clean-arch/src/api/handlers/cart"
 	categoryHandler "github.com/ZaphCode/clean-arch/src/api/handlers/category"
+	couponHandler "github.com/ZaphCode/clean-arch/src/api/handlers/coupon"
 	orderHandler "github.com/ZaphCode/clean-arch/src/api/handlers/order"
+	paymentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/payment"
 	productHandler "github.com/ZaphCode/clean-arch/src/api/handlers/product"
+	returnHandler "github.com/ZaphCode/clean-arch/src/api/handlers/returns"
+	reviewHandler "github.com/ZaphCode/clean-arch/src/api/handlers/review"
+	shipmentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipment"
+	shippingHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipping"
 	userHandler "github.com/ZaphCode/clean-arch/src/api/handlers/user"
 	"github.com/ZaphCode/clean-arch/src/api/middlewares"
 	"github.com/ZaphCode/clean-arch/src/utils"
@@ -36,6 +43,7 @@ func (s *Server) CreateUserRoutes(
 	r.Post("/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), usrHdlr.CreateUser)
 	r.Put("/update/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), usrHdlr.UpdateUser)
 	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), usrHdlr.DeleteUser)
+	r.Post("/avatar", middlewares.BodyLimit(utils.MaxUploadBody, nil), authMdlw.AuthRequired, usrHdlr.UploadAvatar)
 }
 
 func (s *Server) CreateProductRoutes(
@@ -44,10 +52,19 @@ func (s *Server) CreateProductRoutes(
 ) {
 	r := s.app.Group("/api/product")
 	r.Get("/all", prodHdlr.GetProducts)
+	r.Get("/search", prodHdlr.SearchProducts)
+	r.Get("/latest", prodHdlr.GetLatestProducts)
+	r.Get("/tags", prodHdlr.GetProductsByTags)
+	r.Get("/category/:name", prodHdlr.GetProductsByCategory)
 	r.Get("/get/:id", prodHdlr.GetProduct)
 	r.Post("/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.CreateProduct)
 	r.Put("/update/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.UpdateProduct)
 	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.DeleteProduct)
+	r.Post("/:id/image", middlewares.BodyLimit(utils.MaxUploadBody, nil), authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.UploadProductImage)
+	r.Delete("/:id/image/:iid", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.RemoveProductImage)
+	r.Post("/:id/variant/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.AddVariant)
+	r.Put("/:id/variant/update/:vid", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.UpdateVariant)
+	r.Delete("/:id/variant/delete/:vid", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), prodHdlr.RemoveVariant)
 }
 
 func (s *Server) CreateCategoryRoutes(
@@ -74,20 +91,107 @@ func (s *Server) CreateAddressesRoutes(
 func (s *Server) CreateCardRoutes(
 	cardHdlr *cardHandler.CardHandler,
 	paymMdlw *middlewares.PaymentMiddleware,
+	idemMdlw *middlewares.IdempotencyMiddleware,
 	authMdlw *middlewares.AuthMiddleware,
 ) {
 	r := s.app.Group("/api/card")
 	r.Get("/list", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, cardHdlr.GetUserCards)
-	r.Post("/save", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, cardHdlr.SaveUserCard)
+	r.Post("/save", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, idemMdlw.Idempotent, cardHdlr.SaveUserCard)
 	r.Delete("/remove/:id", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, cardHdlr.RemoveUserCard)
 }
 
 func (s *Server) CreateOrderRoutes(
 	ordHdlr *orderHandler.OrderHandler,
 	paymMdlw *middlewares.PaymentMiddleware,
+	idemMdlw *middlewares.IdempotencyMiddleware,
 	authMdlw *middlewares.AuthMiddleware,
 ) {
 	r := s.app.Group("/api/order")
 	r.Get("/list", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, ordHdlr.GetOrders)
-	r.Post("/new", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, ordHdlr.CreateOrder)
+	r.Post("/new", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, idemMdlw.Idempotent, ordHdlr.CreateOrder)
+	r.Post("/checkout", authMdlw.AuthRequired, paymMdlw.CustomerIDRequired, idemMdlw.Idempotent, ordHdlr.CheckoutCart)
+	r.Put("/cancel/:id", authMdlw.AuthRequired, ordHdlr.CancelOrder)
+	r.Get("/get/:id", authMdlw.AuthRequired, ordHdlr.GetOrder)
+	r.Get("/:id/invoice", authMdlw.AuthRequired, ordHdlr.GetOrderInvoice)
+	r.Put("/status/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), ordHdlr.UpdateOrderStatus)
+	r.Post("/refund/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), ordHdlr.RefundOrder)
+	r.Get("/all", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), ordHdlr.GetAllOrders)
+	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), ordHdlr.DeleteOrder)
+}
+
+func (s *Server) CreateCartRoutes(
+	cartHdlr *cartHandler.CartHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/cart")
+	r.Get("/view", authMdlw.AuthRequired, cartHdlr.GetCart)
+	r.Post("/add", authMdlw.AuthRequired, cartHdlr.AddCartItem)
+	r.Put("/update/:id", authMdlw.AuthRequired, cartHdlr.UpdateCartItem)
+	r.Delete("/remove/:id", authMdlw.AuthRequired, cartHdlr.RemoveCartItem)
+	r.Delete("/clear", authMdlw.AuthRequired, cartHdlr.ClearCart)
+}
+
+func (s *Server) CreateCouponRoutes(
+	cpnHdlr *couponHandler.CouponHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/coupon")
+	r.Get("/all", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), cpnHdlr.GetCoupons)
+	r.Get("/get/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), cpnHdlr.GetCoupon)
+	r.Post("/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), cpnHdlr.CreateCoupon)
+	r.Put("/update/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), cpnHdlr.UpdateCoupon)
+	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), cpnHdlr.DeleteCoupon)
+}
+
+func (s *Server) CreateShippingRoutes(
+	shipHdlr *shippingHandler.ShippingHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/shipping")
+	r.Get("/methods", shipHdlr.GetShippingMethods)
+	r.Post("/quote", authMdlw.AuthRequired, shipHdlr.QuoteShipping)
+	r.Post("/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shipHdlr.CreateShippingMethod)
+	r.Put("/update/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shipHdlr.UpdateShippingMethod)
+	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shipHdlr.DeleteShippingMethod)
+}
+
+func (s *Server) CreateShipmentRoutes(
+	shpHdlr *shipmentHandler.ShipmentHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/shipment")
+	r.Get("/order/:id", authMdlw.AuthRequired, shpHdlr.GetOrderShipments)
+	r.Post("/create", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shpHdlr.CreateShipment)
+	r.Put("/update/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shpHdlr.UpdateShipment)
+	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.AdminRole), shpHdlr.DeleteShipment)
+}
+
+func (s *Server) CreateReturnRoutes(
+	retHdlr *returnHandler.ReturnHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/return")
+	r.Post("/new", authMdlw.AuthRequired, retHdlr.CreateReturn)
+	r.Get("/list", authMdlw.AuthRequired, retHdlr.GetUserReturns)
+	r.Get("/all", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), retHdlr.GetReturns)
+	r.Put("/approve/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), retHdlr.ApproveReturn)
+	r.Put("/reject/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), retHdlr.RejectReturn)
+	r.Put("/receive/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), retHdlr.ReceiveReturn)
+}
+
+func (s *Server) CreateReviewRoutes(
+	revHdlr *reviewHandler.ReviewHandler,
+	authMdlw *middlewares.AuthMiddleware,
+) {
+	r := s.app.Group("/api/review")
+	r.Get("/product/:id", revHdlr.GetProductReviews)
+	r.Get("/product/:id/all", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), revHdlr.GetAllProductReviews)
+	r.Post("/create", authMdlw.AuthRequired, revHdlr.CreateReview)
+	r.Put("/hide/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), revHdlr.HideReview)
+	r.Delete("/delete/:id", authMdlw.AuthRequired, authMdlw.RoleRequired(utils.ModeratorRole), revHdlr.DeleteReview)
+}
+
+func (s *Server) CreatePaymentRoutes(pmHdlr *paymentHandler.PaymentHandler) {
+	r := s.app.Group("/api/payment")
+	r.Post("/webhook", pmHdlr.Webhook)
 }
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/ZaphCode/clean-arch/config"
+	"github.com/ZaphCode/clean-arch/src/api/middlewares"
+	"github.com/ZaphCode/clean-arch/src/utils"
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -27,7 +29,12 @@ type Server struct {
 func New() *Server {
 	return &Server{
 		tasksCh: make(chan func()),
-		app:     fiber.New(),
+		app: fiber.New(fiber.Config{
+			//* The bodies past the default limit are streamed and the
+			//* forms are not parsed ahead, BodyLimit says who takes them
+			StreamRequestBody:            true,
+			DisablePreParseMultipartForm: true,
+		}),
 	}
 }
 
@@ -69,6 +76,7 @@ func (s *Server) SetGlobalMiddlewares() {
 			"Accept",
 			cfg.Api.RefreshTokenHeader,
 			cfg.Api.AccessTokenHeader,
+			utils.IdempotencyHeader,
 		}, ", "),
 		AllowMethods:     cors.ConfigDefault.AllowMethods,
 		AllowCredentials: true,
@@ -81,6 +89,7 @@ func (s *Server) SetGlobalMiddlewares() {
 	s.app.Use(cors.New(cc))
 	s.app.Use(recover.New())
 	s.app.Use(logger.New())
+	s.app.Use(middlewares.BodyLimit(fiber.DefaultBodyLimit, isUpload))
 
 	s.app.Get("/api/health", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -92,6 +101,20 @@ func (s *Server) SetGlobalMiddlewares() {
 	s.app.Get("/docs/*", swagger.HandlerDefault)
 }
 
+// ServeUploads serves the files of the local upload service.
+func (s *Server) ServeUploads(dir string) {
+	s.app.Static(utils.UploadRoute, dir)
+}
+
+// isUpload tells the upload routes, the only ones that take bodies
+// bigger than fiber.DefaultBodyLimit. They set their own limit.
+func isUpload(c *fiber.Ctx) bool {
+	p := c.Path()
+
+	return c.Method() == fiber.MethodPost &&
+		(p == "/api/user/avatar" || strings.HasPrefix(p, "/api/product/") && strings.HasSuffix(p, "/image"))
+}
+
 func (s *Server) TryRoute(req *http.Request) (*http.Response, error) {
 	return s.app.Test(req, -1) // "WITHOUT TIMEOUT"
 }
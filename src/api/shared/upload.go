@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/ZaphCode/clean-arch/src/services/validation"
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+// OpenImage opens the uploaded file of the field once its header says
+// it is an image. The server keeps the big form files on disk, so the
+// file is not in memory until it is read, and reading it fails with
+// validation errors when it is bigger than utils.MaxImageSize. The
+// upload service still reads the whole image to decode it.
+func OpenImage(
+	fh *multipart.FileHeader, field string, vldSvc validation.ValidationService,
+) (io.ReadCloser, error) {
+	f, err := fh.Open()
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening the file: %w", err)
+	}
+
+	r, err := vldSvc.ValidateFile(fh.Filename, f, validation.FileRule{
+		Field:   field,
+		Types:   utils.ImageTypes,
+		MaxSize: utils.MaxImageSize,
+	})
+
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, f}, nil
+}
@@ -21,7 +21,7 @@ type AuthRoutesSuite struct {
 }
 
 func TestAuthRoutesSuite(t *testing.T) {
-	config.MustLoadConfig("./../../../config")
+	config.MustLoadConfig(testConfigDir)
 
 	rs := new(AuthRoutesSuite)
 	jwtSvc := auth.NewJWTService()
@@ -0,0 +1,286 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestCartRoutesSuite(t *testing.T) {
+	crts := new(CartRoutesSuite)
+	crts.bp = "/api/cart"
+	suite.Run(t, crts)
+}
+
+func (s *CartRoutesSuite) TestCartRoutes_AAddItem() {
+	path := s.bp + "/add"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("POST", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unprocesable json",
+			req: s.MakeReq("POST", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusUnprocessableEntity,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid body (empty)",
+			req: s.MakeReq("POST", path, dtos.AddCartItemDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unexisting product",
+			req: s.MakeReq("POST", path, dtos.AddCartItemDTO{
+				ProductID: utils.AddrExp1.ID,
+				Quantity:  1,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("POST", path, dtos.AddCartItemDTO{
+				ProductID: utils.ProductExp1.ID,
+				Quantity:  2,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Other product",
+			req: s.MakeReq("POST", path, dtos.AddCartItemDTO{
+				ProductID: utils.ProductExpToDev1.ID,
+				Quantity:  1,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CartRoutesSuite) TestCartRoutes_BView() {
+	path := s.bp + "/view"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("GET", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "User cart",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the cart")
+				s.Len(data["items"], 2, "should have two items")
+				s.Equal(true, data["can_checkout"], "should be ready to checkout")
+			},
+		},
+		{
+			desc: "Empty cart",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the cart")
+				s.Equal(false, data["can_checkout"], "empty carts cannot checkout")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CartRoutesSuite) TestCartRoutes_CUpdateItem() {
+	path := s.bp + "/update/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("PUT", path+utils.ProductExp1.ID.String(), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid product id",
+			req: s.MakeReq("PUT", path+"asdf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Product not in cart",
+			req: s.MakeReq("PUT", path+utils.AddrExp1.ID.String(), dtos.UpdateCartItemDTO{
+				Quantity: 3,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("PUT", path+utils.ProductExp1.ID.String(), dtos.UpdateCartItemDTO{
+				Quantity: 3,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CartRoutesSuite) TestCartRoutes_DRemoveItem() {
+	path := s.bp + "/remove/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("DELETE", path+utils.ProductExpToDev1.ID.String(), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("DELETE", path+utils.ProductExpToDev1.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Already removed",
+			req: s.MakeReq("DELETE", path+utils.ProductExpToDev1.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CartRoutesSuite) TestCartRoutes_ECheckout() {
+	path := "/api/order/checkout"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("POST", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid body (empty)",
+			req: s.MakeReq("POST", path, dtos.CheckoutDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Empty cart",
+			req: s.MakeReq("POST", path, dtos.CheckoutDTO{
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("POST", path, dtos.CheckoutDTO{
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Cart was emptied",
+			req: s.MakeReq("POST", path, dtos.CheckoutDTO{
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
@@ -0,0 +1,243 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type CouponRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestCouponRoutesSuite(t *testing.T) {
+	cps := new(CouponRoutesSuite)
+	cps.bp = "/api/coupon"
+	suite.Run(t, cps)
+}
+
+func (s *CouponRoutesSuite) TestCouponRoutes_GetAll() {
+	path := s.bp + "/all"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("GET", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Get all coupons",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CouponRoutesSuite) TestCouponRoutes_GetByID() {
+	path := s.bp + "/get/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Invalid coupon id",
+			req: s.MakeReq("GET", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found coupon",
+			req: s.MakeReq("GET", path+uuid.New().String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Get coupon success",
+			req: s.MakeReq("GET", path+utils.CouponExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CouponRoutesSuite) TestCouponRoutes_Create() {
+	path := s.bp + "/create"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("POST", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unprocesable json",
+			req: s.MakeReq("POST", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusUnprocessableEntity,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Percentage without rate",
+			req: s.MakeReq("POST", path, dtos.NewCouponDTO{
+				Code: "NORATE",
+				Type: utils.CouponPercentage,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Code already used",
+			req: s.MakeReq("POST", path, dtos.NewCouponDTO{
+				Code: utils.CouponExp1.Code,
+				Type: utils.CouponPercentage,
+				Rate: 30,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Create coupon success",
+			req: s.MakeReq("POST", path, dtos.NewCouponDTO{
+				Code:           "BLACKFRIDAY",
+				Type:           utils.CouponFixed,
+				Amount:         1000,
+				MinOrderAmount: 5000,
+				MaxUses:        50,
+				MaxUsesPerUser: 1,
+				Tags:           []string{"black"},
+				Active:         true,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusCreated,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CouponRoutesSuite) TestCouponRoutes_Update() {
+	path := s.bp + "/update/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Amount without currency",
+			req: s.MakeReq("PUT", path+utils.CouponExp2.ID.String(), dtos.UpdateCouponDTO{
+				Amount: utils.PTR[int64](700),
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found coupon",
+			req: s.MakeReq("PUT", path+uuid.New().String(), dtos.UpdateCouponDTO{
+				Active: utils.PTR(false),
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Update coupon success",
+			req: s.MakeReq("PUT", path+utils.CouponExp2.ID.String(), dtos.UpdateCouponDTO{
+				Amount:   utils.PTR[int64](700),
+				Currency: utils.DefaultCurrency,
+				MaxUses:  utils.PTR[int64](200),
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *CouponRoutesSuite) TestCouponRoutes_Delete() {
+	path := s.bp + "/delete/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Invalid coupon id",
+			req: s.MakeReq("DELETE", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found coupon",
+			req: s.MakeReq("DELETE", path+uuid.New().String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Delete coupon success",
+			req: s.MakeReq("DELETE", path+utils.CouponExp1.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
@@ -1,12 +1,17 @@
 package test
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ZaphCode/clean-arch/src/api/dtos"
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/payment"
 	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -45,6 +50,118 @@ func (s *OrderRoutesSuite) TestOrderRoutes_GetAll() {
 	s.RunRequests(testCases)
 }
 
+func (s *OrderRoutesSuite) TestOrderRoutes_GetByID() {
+	path := s.bp + "/get/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Invalid order id",
+			req: s.MakeReq("GET", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found order",
+			req: s.MakeReq("GET", path+uuid.New().String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Owner",
+			req: s.MakeReq("GET", path+utils.OrderExp4.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Mod can see any order",
+			req: s.MakeReq("GET", path+utils.OrderExp4.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_GetAllAdmin() {
+	path := s.bp + "/all"
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+	}
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Mod has not permissions",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Invalid filter",
+			req:           s.MakeReq("GET", path+"?status=lost", nil, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Paid orders of the user",
+			req:        s.MakeReq("GET", path+"?paid=true&user_id="+utils.UserExp1.ID.String(), nil, hdrs),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				page, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the page")
+				s.Equal(float64(2), page["total"], "OrderExp4 and OrderExp5 are paid")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+
+	//* Walk every page
+	seen, cursor := 0, ""
+
+	for pages := 0; pages < 10; pages++ {
+		body := struct {
+			Data domain.Page[domain.Order] `json:"data"`
+		}{}
+
+		res, err := s.server.TryRoute(s.MakeReq("GET", path+"?limit=2&cursor="+cursor, nil, hdrs))
+
+		s.Require().NoError(err, "request error!")
+		s.Require().Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+
+		res.Body.Close()
+
+		s.LessOrEqual(len(body.Data.Items), 2, "wrong page size")
+
+		seen += len(body.Data.Items)
+		cursor = body.Data.Next
+
+		if cursor == "" {
+			s.Equal(body.Data.Total, seen, "every order should be listed once")
+			return
+		}
+	}
+
+	s.Fail("too many pages")
+}
+
 func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 	path := s.bp + "/new"
 
@@ -78,8 +195,9 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 		{
 			desc: "Invalid body (unexisting products)",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: s.paymentID,
-				AddressID: utils.AddrExp1.ID,
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.AddrExp1.ID, Quantity: 13},
 					{ID: utils.AddrExp2.ID, Quantity: 3},
@@ -95,8 +213,9 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 		{
 			desc: "Invalid address id",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: s.paymentID,
-				AddressID: utils.UserAdmin.ID,
+				PaymentID:      s.paymentID,
+				AddressID:      utils.UserAdmin.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.ProductExp1.ID, Quantity: 13},
 					{ID: utils.ProductExp1.ID, Quantity: 3},
@@ -106,14 +225,32 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 				"Content-Type":              "application/json",
 			}),
 			showResp:      true,
-			wantStatus:    http.StatusInternalServerError,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not enough stock",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1000},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
 			bodyValidator: s.CheckFail,
 		},
 		{
 			desc: "Invalid payment method",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: "adsfadf",
-				AddressID: utils.AddrExp1.ID,
+				PaymentID:      "adsfadf",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.ProductExp1.ID, Quantity: 13},
 					{ID: utils.ProductExpToDev1.ID, Quantity: 3},
@@ -129,8 +266,9 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 		{
 			desc: "Attached payment method",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
-				AddressID: utils.AddrExp1.ID,
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.ProductExp1.ID, Quantity: 13},
 					{ID: utils.ProductExpToDev1.ID, Quantity: 3},
@@ -143,11 +281,170 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 			wantStatus:    http.StatusInternalServerError,
 			bodyValidator: s.CheckFail,
 		},
+		{
+			desc: "Declined card",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      payment.TestCardDeclined,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 2},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid coupon",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+				CouponCode:     "TENIS5",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work with coupon",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
+				CouponCode:     "tenis5",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+					{ID: utils.ProductExpToDev1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, _ := jsm["data"].(map[string]any)
+				ord, _ := data["order"].(map[string]any)
+				s.Equal("TENIS5", ord["coupon_code"], "should record the coupon")
+				amount, _ := ord["amount"].(map[string]any)
+				discount, _ := ord["discount"].(map[string]any)
+				shippingCost, _ := ord["shipping_cost"].(map[string]any)
+				s.Equal(float64(500), discount["amount"], "wrong discount")
+				tax, _ := ord["tax"].(map[string]any)
+				taxAmount, _ := tax["amount"].(map[string]any)
+				s.Equal(float64(500), shippingCost["amount"], "wrong shipping cost")
+				s.Equal(float64(252), taxAmount["amount"], "the tax should be on the discounted price and the shipping")
+				s.Equal(float64(2064+1819-500+500+252), amount["amount"], "the discount should not touch the shipping")
+			},
+		},
+		{
+			desc: "Unknown shipping method",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "teleport",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Shipping method that does not ship there",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp2.ID,
+				ShippingMethod: "express",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work with express shipping",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "Express",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, _ := jsm["data"].(map[string]any)
+				ord, _ := data["order"].(map[string]any)
+				s.Equal("express", ord["shipping_method"], "should record the method")
+				amount, _ := ord["amount"].(map[string]any)
+				shippingCost, _ := ord["shipping_cost"].(map[string]any)
+				tax, _ := ord["tax"].(map[string]any)
+				taxAmount, _ := tax["amount"].(map[string]any)
+				s.Equal(float64(900), shippingCost["amount"], "should use the state rate")
+				s.Equal(float64(193), taxAmount["amount"], "wrong tax")
+				s.Equal(float64(2064+900+193), amount["amount"], "the shipping and the tax should be charged")
+			},
+		},
+		{
+			desc: "Proper work with tax included",
+			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp2.ID,
+				ShippingMethod: "standard",
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, _ := jsm["data"].(map[string]any)
+				ord, _ := data["order"].(map[string]any)
+				amount, _ := ord["amount"].(map[string]any)
+				tax, _ := ord["tax"].(map[string]any)
+				taxAmount, _ := tax["amount"].(map[string]any)
+				s.Equal(true, tax["inclusive"], "should be tax included")
+				s.Equal(float64(395), taxAmount["amount"], "wrong tax")
+				s.Equal(float64(2064+800), amount["amount"], "the tax is already in the prices")
+			},
+		},
 		{
 			desc: "Proper work with new payment method",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: s.paymentID,
-				AddressID: utils.AddrExp1.ID,
+				PaymentID:      s.paymentID,
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.ProductExp1.ID, Quantity: 2},
 					{ID: utils.ProductExpToDev1.ID, Quantity: 3},
@@ -156,15 +453,36 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
 				"Content-Type":              "application/json",
 			}),
-			showResp:      true,
-			wantStatus:    http.StatusOK,
-			bodyValidator: s.CheckSuccess,
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				data, _ := jsm["data"].(map[string]any)
+				ord, _ := data["order"].(map[string]any)
+				lines, ok := ord["products"].([]any)
+				s.Require().True(ok, "should contain the order lines")
+				var sum float64
+				for _, l := range lines {
+					line, _ := l.(map[string]any)
+					s.NotEmpty(line["name"], "line should have the product name")
+					lineTotal, _ := line["line_total"].(map[string]any)
+					sum += lineTotal["amount"].(float64)
+				}
+				shippingCost, _ := ord["shipping_cost"].(map[string]any)
+				sum += shippingCost["amount"].(float64)
+				tax, _ := ord["tax"].(map[string]any)
+				taxAmount, _ := tax["amount"].(map[string]any)
+				sum += taxAmount["amount"].(float64)
+				amount, _ := ord["amount"].(map[string]any)
+				s.Equal(amount["amount"], sum, "lines, shipping and tax should explain the amount")
+			},
 		},
 		{
 			desc: "Proper work with saved card",
 			req: s.MakeReq("POST", path, dtos.NewOrderDTO{
-				PaymentID: "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
-				AddressID: utils.AddrExp1.ID,
+				PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+				AddressID:      utils.AddrExp1.ID,
+				ShippingMethod: "standard",
 				Products: []domain.OrderProduct{
 					{ID: utils.ProductExp1.ID, Quantity: 2},
 					{ID: utils.ProductExpToDev1.ID, Quantity: 3},
@@ -180,3 +498,462 @@ func (s *OrderRoutesSuite) TestOrderRoutes_NewOrder() {
 	}
 	s.RunRequests(testCases)
 }
+
+func (s *OrderRoutesSuite) TestOrderRoutes_NewOrderDeclined() {
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+		"Content-Type":              "application/json",
+	}
+
+	cancelled := func() int {
+		body := struct {
+			Data []domain.Order `json:"data"`
+		}{}
+
+		res, err := s.server.TryRoute(s.MakeReq("GET", s.bp+"/list", nil, hdrs))
+
+		s.Require().NoError(err, "request error!")
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+
+		res.Body.Close()
+
+		n := 0
+
+		for _, o := range body.Data {
+			//* The declined payment intent is kept for its events
+			if o.Status == utils.StatusCancelled && o.PaymentIntentID != "" {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	before := cancelled()
+
+	res, err := s.server.TryRoute(s.MakeReq("POST", s.bp+"/new", dtos.NewOrderDTO{
+		PaymentID:      payment.TestCardDeclined,
+		AddressID:      utils.AddrExp1.ID,
+		ShippingMethod: "standard",
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp1.ID, Quantity: 1},
+		},
+	}, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusInternalServerError, res.StatusCode, "wrong status code!")
+
+	//* The declined order is cancelled instead of left pending
+	s.Equal(before+1, cancelled(), "the declined order should be cancelled")
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_NewOrderPaid() {
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+		"Content-Type":              "application/json",
+	}
+
+	body := struct {
+		Data struct {
+			Order domain.Order `json:"order"`
+		} `json:"data"`
+	}{}
+
+	res, err := s.server.TryRoute(s.MakeReq("POST", s.bp+"/new", dtos.NewOrderDTO{
+		PaymentID:      payment.TestCardVisa,
+		AddressID:      utils.AddrExp1.ID,
+		ShippingMethod: "standard",
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp1.ID, Quantity: 1},
+		},
+	}, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+
+	res.Body.Close()
+
+	//* The succeeded event comes in the background
+	s.Eventually(func() bool {
+		got := struct {
+			Data domain.Order `json:"data"`
+		}{}
+
+		res, err := s.server.TryRoute(s.MakeReq("GET", s.bp+"/get/"+body.Data.Order.ID.String(), nil, hdrs))
+
+		if err != nil || json.NewDecoder(res.Body).Decode(&got) != nil {
+			return false
+		}
+
+		res.Body.Close()
+
+		return got.Data.Status == utils.StatusPaid && got.Data.Paid
+	}, 2*time.Second, 20*time.Millisecond, "the order should be paid")
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_IdempotencyKey() {
+	path := s.bp + "/new"
+
+	body := dtos.NewOrderDTO{
+		PaymentID:      "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+		AddressID:      utils.AddrExp1.ID,
+		ShippingMethod: "standard",
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp1.ID, Quantity: 1},
+		},
+	}
+
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+		utils.IdempotencyHeader:     "a4c1e9d2-order-retry",
+		"Content-Type":              "application/json",
+	}
+
+	getOrderID := func(res *http.Response) (string, string) {
+		defer res.Body.Close()
+
+		jsm := make(map[string]any)
+
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&jsm), "unmarshall err")
+
+		data, _ := jsm["data"].(map[string]any)
+		ord, _ := data["order"].(map[string]any)
+		id, _ := ord["id"].(string)
+		piID, _ := ord["payment_intent_id"].(string)
+
+		return id, piID
+	}
+
+	first, err := s.server.TryRoute(s.MakeReq("POST", path, body, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusOK, first.StatusCode, "wrong status code!")
+	s.Empty(first.Header.Get(utils.ReplayedHeader), "the first response is not a replay")
+
+	ordID, piID := getOrderID(first)
+
+	s.NotEmpty(ordID, "should create the order")
+
+	retry, err := s.server.TryRoute(s.MakeReq("POST", path, body, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Equal(http.StatusOK, retry.StatusCode, "should replay the status")
+	s.Equal("true", retry.Header.Get(utils.ReplayedHeader), "should be a replay")
+
+	retryOrdID, retryPiID := getOrderID(retry)
+
+	s.Equal(ordID, retryOrdID, "should not create another order")
+	s.Equal(piID, retryPiID, "should not charge again")
+
+	body.Products[0].Quantity = 2
+
+	other, err := s.server.TryRoute(s.MakeReq("POST", path, body, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Equal(http.StatusUnprocessableEntity, other.StatusCode, "the key belongs to another request")
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_UpdateStatus() {
+	path := s.bp + "/status/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid order id",
+			req: s.MakeReq("PUT", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unknown status",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), dtos.UpdateOrderStatusDTO{
+				Status: "lost",
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found order",
+			req: s.MakeReq("PUT", path+uuid.New().String(), dtos.UpdateOrderStatusDTO{
+				Status: utils.StatusProcessing,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Back to pending",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), dtos.UpdateOrderStatusDTO{
+				Status: utils.StatusPending,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid transition",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), dtos.UpdateOrderStatusDTO{
+				Status: utils.StatusDelivered,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Payments come from the payment provider",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), dtos.UpdateOrderStatusDTO{
+				Status: utils.StatusPaid,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("PUT", path+utils.OrderExp1.ID.String(), dtos.UpdateOrderStatusDTO{
+				Status: utils.StatusCancelled,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_Cancel() {
+	path := s.bp + "/cancel/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("PUT", path+utils.OrderExp2.ID.String(), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid order id",
+			req: s.MakeReq("PUT", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Order from other user",
+			req: s.MakeReq("PUT", path+utils.OrderExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("PUT", path+utils.OrderExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Already cancelled",
+			req: s.MakeReq("PUT", path+utils.OrderExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_Delete() {
+	path := s.bp + "/delete/"
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+	}
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Mod has not permissions",
+			req: s.MakeReq("DELETE", path+utils.OrderExp3.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Paid order",
+			req:           s.MakeReq("DELETE", path+utils.OrderExp4.ID.String(), nil, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Proper work",
+			req:           s.MakeReq("DELETE", path+utils.OrderExp3.ID.String(), nil, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Already deleted",
+			req:           s.MakeReq("DELETE", path+utils.OrderExp3.ID.String(), nil, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_Refund() {
+	path := s.bp + "/refund/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("POST", path+utils.OrderExp2.ID.String(), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Mod has not permissions",
+			req: s.MakeReq("POST", path+utils.OrderExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Order not paid",
+			req: s.MakeReq("POST", path+utils.OrderExp2.ID.String(), dtos.RefundOrderDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *OrderRoutesSuite) TestOrderRoutes_Invoice() {
+	path := func(ID uuid.UUID, format string) string {
+		return s.bp + "/" + ID.String() + "/invoice?format=" + format
+	}
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("GET", path(utils.OrderExp4.ID, "pdf"), nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid format",
+			req: s.MakeReq("GET", path(utils.OrderExp4.ID, "docx"), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Mod cannot see other users invoices",
+			req: s.MakeReq("GET", path(utils.OrderExp4.ID, "pdf"), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Order not paid",
+			req: s.MakeReq("GET", path(utils.OrderExp2.ID, "pdf"), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work (owner)",
+			req: s.MakeReq("GET", path(utils.OrderExp4.ID, "pdf"), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			wantStatus: http.StatusOK,
+		},
+	}
+	s.RunRequests(testCases)
+
+	formats := map[string]string{
+		"pdf":  "application/pdf",
+		"html": "text/html; charset=utf-8",
+	}
+
+	for format, contentType := range formats {
+		res, err := s.server.TryRoute(s.MakeReq("GET", path(utils.OrderExp4.ID, format), nil, map[string]string{
+			s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+		}))
+
+		s.Require().NoError(err, "request error!")
+		s.Equal(http.StatusOK, res.StatusCode, "admins can get any invoice")
+		s.Equal(contentType, res.Header.Get("Content-Type"), "wrong content type")
+
+		body, err := io.ReadAll(res.Body)
+
+		s.Require().NoError(err, "reading response error!")
+		s.Contains(string(body), "INV-000001", "the order keeps its number")
+
+		res.Body.Close()
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/stripe/stripe-go/v74/webhook"
+)
+
+type PaymentRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestPaymentRoutesSuite(t *testing.T) {
+	pms := new(PaymentRoutesSuite)
+	pms.bp = "/api/payment"
+	suite.Run(t, pms)
+}
+
+func (s *PaymentRoutesSuite) TestPaymentRoutes_Webhook() {
+	path := s.bp + "/webhook"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Without signature",
+			req:           s.makeEventReq(path, "payment_succeeded.json", ""),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Wrong signature",
+			req:           s.makeEventReq(path, "payment_succeeded.json", "whsec_other"),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Payment succeeded",
+			req:           s.makeEventReq(path, "payment_succeeded.json", s.cfg.Stripe.WebhookSecret),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Same event again",
+			req:           s.makeEventReq(path, "payment_succeeded.json", s.cfg.Stripe.WebhookSecret),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Charge refunded",
+			req:           s.makeEventReq(path, "charge_refunded.json", s.cfg.Stripe.WebhookSecret),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+// makeEventReq signs a fixture event like the payment provider does.
+// An empty secret sends the event without signature.
+func (s *PaymentRoutesSuite) makeEventReq(path, fixture, secret string) *http.Request {
+	payload, err := os.ReadFile("./../../services/payment/testdata/" + fixture)
+
+	s.Require().NoError(err, "reading fixture error")
+
+	req, err := http.NewRequest("POST", path, bytes.NewBuffer(payload))
+
+	s.Require().NoError(err, "request error")
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret != "" {
+		sp := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+			Payload:   payload,
+			Secret:    secret,
+			Timestamp: time.Now(),
+		})
+		req.Header.Set("Stripe-Signature", sp.Header)
+	}
+
+	return req
+}
@@ -1,11 +1,21 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/services/upload"
 	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -22,16 +32,104 @@ func TestProductRoutesSuite(t *testing.T) {
 }
 
 func (s *ProductRoutesSuite) TestProductRoutes_GetAll() {
+	path := s.bp + "/all"
+
+	//* The Create test adds the Logitech g613 (1400, not available)
+	//* and the Delete test removes the ProductExp1.
+	checkPage := func(total int, names ...string) func(map[string]any) {
+		return func(jsm map[string]any) {
+			s.CheckSuccess(jsm)
+			page, ok := jsm["data"].(map[string]any)
+			s.Require().True(ok, "should contain the page")
+			s.Equal(float64(total), page["total"], "wrong total")
+			items, _ := page["items"].([]any)
+			got := []string{}
+			for _, it := range items {
+				got = append(got, it.(map[string]any)["name"].(string))
+			}
+			s.Equal(names, got, "wrong products")
+		}
+	}
+
 	testCases := []TryRouteTestCase{
 		{
-			desc:          "Get all products",
-			req:           s.MakeReq("GET", s.bp+"/all", nil),
+			desc:          "Invalid sort",
+			req:           s.MakeReq("GET", path+"?sort_by=stock", nil),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Price range sorted by name",
+			req:           s.MakeReq("GET", path+"?min_price=1000&sort_by=name", nil),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Sorted by price desc",
+			req:           s.MakeReq("GET", path+"?sort_by=price&order=desc", nil),
 			showResp:      true,
 			wantStatus:    http.StatusOK,
-			bodyValidator: s.CheckSuccess,
+			bodyValidator: checkPage(2, utils.ProductExpToDev1.Name, "Logitech g613"),
+		},
+		{
+			desc:          "Price range",
+			req:           s.MakeReq("GET", path+"?min_price=2000&max_price=3000", nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkPage(1, utils.ProductExpToDev1.Name),
+		},
+		{
+			desc:          "Any of the tags",
+			req:           s.MakeReq("GET", path+"?tags=tech,Adidas&sort_by=name", nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkPage(2, utils.ProductExpToDev1.Name, "Logitech g613"),
+		},
+		{
+			desc:          "Category and availability",
+			req:           s.MakeReq("GET", path+"?category=headsets&available=false", nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkPage(1, "Logitech g613"),
+		},
+		{
+			desc:          "First page",
+			req:           s.MakeReq("GET", path+"?sort_by=price&limit=1", nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkPage(2, "Logitech g613"),
 		},
 	}
 	s.RunRequests(testCases)
+
+	//* Walk every page
+	seen, cursor := 0, ""
+
+	for pages := 0; pages < 10; pages++ {
+		body := struct {
+			Data domain.Page[domain.Product] `json:"data"`
+		}{}
+
+		res, err := s.server.TryRoute(s.MakeReq("GET", path+"?limit=1&cursor="+cursor, nil))
+
+		s.Require().NoError(err, "request error!")
+		s.Require().Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+
+		res.Body.Close()
+
+		seen += len(body.Data.Items)
+		cursor = body.Data.Next
+
+		if cursor == "" {
+			s.Equal(body.Data.Total, seen, "every product should be listed once")
+			return
+		}
+	}
+
+	s.Fail("too many pages")
 }
 
 func (s *ProductRoutesSuite) TestProductRoutes_GetByID() {
@@ -61,6 +159,139 @@ func (s *ProductRoutesSuite) TestProductRoutes_GetByID() {
 	s.RunRequests(testCases)
 }
 
+func (s *ProductRoutesSuite) TestProductRoutes_GetByCategory() {
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Category not found",
+			req:           s.MakeReq("GET", s.bp+"/category/toys", nil),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Products of the category",
+			req:        s.MakeReq("GET", s.bp+"/category/tenis", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				s.Len(jsm["data"], 1, "only ProductExpToDev1 is of tenis")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ProductRoutesSuite) TestProductRoutes_GetByTags() {
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "No tags",
+			req:           s.MakeReq("GET", s.bp+"/tags", nil),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Any of the tags",
+			req:        s.MakeReq("GET", s.bp+"/tags?tags=Adidas,tech,toys", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				s.Len(jsm["data"], 2, "ProductExpToDev1 and the Logitech g613")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ProductRoutesSuite) TestProductRoutes_GetLatest() {
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Invalid limit",
+			req:           s.MakeReq("GET", s.bp+"/latest?limit=1000", nil),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Newest product",
+			req:        s.MakeReq("GET", s.bp+"/latest?limit=1", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				ps, _ := jsm["data"].([]any)
+				s.Require().Len(ps, 1, "wrong limit")
+				s.Equal(utils.ProductExpToDev1.Name, ps[0].(map[string]any)["name"], "created a day ahead")
+			},
+		},
+		{
+			desc:       "Limit bigger than the catalog",
+			req:        s.MakeReq("GET", s.bp+"/latest?limit=50", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				s.Len(jsm["data"], 2, "every product")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ProductRoutesSuite) TestProductRoutes_Search() {
+	path := s.bp + "/search"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "No search text",
+			req:           s.MakeReq("GET", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Deleted products are not found",
+			req:        s.MakeReq("GET", path+"?q=black+t-shirt", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				res := jsm["data"].(map[string]any)
+				s.Equal(float64(1), res["total"], "ProductExp1 was deleted")
+			},
+		},
+		{
+			desc:       "Created products with typos",
+			req:        s.MakeReq("GET", path+"?q=logtech", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				res := jsm["data"].(map[string]any)
+				hits, _ := res["hits"].([]any)
+				s.Require().Len(hits, 1, "the Logitech g613 should be found")
+				hit := hits[0].(map[string]any)
+				s.Equal("<mark>Logitech</mark> g613", hit["highlights"].(map[string]any)["name"], "wrong highlight")
+				facets := res["facets"].(map[string]any)
+				s.Equal([]any{map[string]any{"value": "headsets", "count": float64(1)}}, facets["categories"], "wrong facets")
+			},
+		},
+		{
+			desc:       "Category filter",
+			req:        s.MakeReq("GET", path+"?q=logitech&category=tenis", nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				s.Equal(float64(0), jsm["data"].(map[string]any)["total"], "the Logitech g613 is of headsets")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
 func (s *ProductRoutesSuite) TestProductRoutes_Delete() {
 	testCases := []TryRouteTestCase{
 		{
@@ -128,6 +359,37 @@ func (s *ProductRoutesSuite) TestProductRoutes_Create() {
 			wantStatus:    http.StatusUnauthorized,
 			bodyValidator: s.CheckFail,
 		},
+		{
+			desc: "Body too large",
+			req: s.MakeReq("POST", s.bp+"/create", strings.Repeat("a", fiber.DefaultBodyLimit), map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      false,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Chunked body too large",
+			req: s.Chunked(s.MakeReq("POST", s.bp+"/create", strings.Repeat("a", fiber.DefaultBodyLimit), map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			})),
+			showResp:      false,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Chunked body",
+			req: s.Chunked(s.MakeReq("POST", s.bp+"/create", dtos.NewProductDTO{
+				Price:        0,
+				DiscountRate: 120,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			})),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
 		{
 			desc: "User has not permissions",
 			req: s.MakeReq("POST", s.bp+"/create", nil, map[string]string{
@@ -297,6 +559,7 @@ func (s *ProductRoutesSuite) TestProductRoutes_Update() {
 			req: s.MakeReq("PUT", path+utils.ProductExpToDev1.ID.String(), dtos.UpdateProductDTO{
 				Category:     "clothes",
 				Price:        utils.PTR[int64](1500),
+				Currency:     utils.DefaultCurrency,
 				DiscountRate: utils.PTR[int64](12),
 			}, map[string]string{
 				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
@@ -309,3 +572,279 @@ func (s *ProductRoutesSuite) TestProductRoutes_Update() {
 	}
 	s.RunRequests(testCases)
 }
+
+func (s *ProductRoutesSuite) TestProductRoutes_Variants() {
+	path := s.bp + "/" + utils.ProductExpToDev1.ID.String() + "/variant"
+	admin := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+		"Content-Type":              "application/json",
+	}
+
+	var varID string
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc: "Mod has not permissions",
+			req: s.MakeReq("POST", path+"/create", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Product without options",
+			req: s.MakeReq("POST", path+"/create", dtos.NewVariantDTO{
+				SKU:     "TENIS-BLK-M",
+				Options: map[string]string{"size": "M"},
+			}, admin),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Set the options",
+			req: s.MakeReq("PUT", s.bp+"/update/"+utils.ProductExpToDev1.ID.String(), dtos.UpdateProductDTO{
+				Options: []dtos.ProductOptionDTO{{Name: "size", Values: []string{"M", "L"}}},
+			}, admin),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "Invalid option value",
+			req: s.MakeReq("POST", path+"/create", dtos.NewVariantDTO{
+				SKU:     "TENIS-XL",
+				Options: map[string]string{"size": "XL"},
+			}, admin),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Add success",
+			req: s.MakeReq("POST", path+"/create", dtos.NewVariantDTO{
+				SKU:       "TENIS-M",
+				Options:   map[string]string{"size": "M"},
+				Price:     2799,
+				Stock:     4,
+				ImagesUrl: []string{"https://example.com/tenis-m.png"},
+			}, admin),
+			showResp:   true,
+			wantStatus: http.StatusCreated,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				v, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the variant")
+				s.Equal("TENIS-M", v["sku"])
+				imgs, _ := v["images"].([]any)
+				s.Require().Len(imgs, 1, "should have the image")
+				rds, _ := imgs[0].(map[string]any)["renditions"].([]any)
+				s.Require().Len(rds, 1, "should have the original")
+				s.Equal("https://example.com/tenis-m.png", rds[0].(map[string]any)["url"])
+				varID, _ = v["id"].(string)
+			},
+		},
+	})
+
+	s.Require().NotEmpty(varID, "the variant should be created")
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc:          "Invalid variant id",
+			req:           s.MakeReq("PUT", path+"/update/abc", nil, admin),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Update success",
+			req: s.MakeReq("PUT", path+"/update/"+varID, dtos.UpdateVariantDTO{
+				Stock: utils.PTR[int64](9),
+			}, admin),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				v, _ := jsm["data"].(map[string]any)
+				s.Equal(float64(9), v["stock"])
+			},
+		},
+		{
+			desc:       "Product stock is the variants stock",
+			req:        s.MakeReq("GET", s.bp+"/get/"+utils.ProductExpToDev1.ID.String(), nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				p, _ := jsm["data"].(map[string]any)
+				s.Equal(float64(9), p["stock"])
+				s.Equal([]any{"TENIS-M"}, p["skus"])
+			},
+		},
+		{
+			desc:          "Remove success",
+			req:           s.MakeReq("DELETE", path+"/delete/"+varID, nil, admin),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Variant not found",
+			req:           s.MakeReq("DELETE", path+"/delete/"+varID, nil, admin),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+	})
+}
+
+func (s *ProductRoutesSuite) TestProductRoutes_UploadImage() {
+	path := s.bp + "/" + utils.ProductExpToDev1.ID.String() + "/image"
+	admin := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+	}
+
+	var url, imgID string
+
+	//* One pixel wider than allowed, it is small in bytes
+	wide := &bytes.Buffer{}
+	s.Require().NoError(png.Encode(wide, image.NewGray(image.Rect(0, 0, utils.MaxImageWidth+1, 1))))
+
+	//* A valid header, the size is found while reading the image
+	heavy := append(s.PNGImage(), make([]byte, utils.MaxImageSize)...)
+
+	fieldErr := func(jsm map[string]any) {
+		s.CheckFail(jsm)
+		errs, _ := jsm["errors"].([]any)
+		s.Require().Len(errs, 1, "should have the field error")
+		fe, _ := errs[0].(map[string]any)
+		s.Equal("image", fe["field"])
+	}
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeUploadReq(path, "image", "upload.png", s.PNGImage(), map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Missing image",
+			req:           s.MakeUploadReq(path, "file", "upload.png", s.PNGImage(), admin),
+			showResp:      true,
+			wantStatus:    http.StatusUnprocessableEntity,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Not an image",
+			req:           s.MakeUploadReq(path, "image", "notes.txt", []byte("just some text"), admin),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: fieldErr,
+		},
+		{
+			desc:          "Extension does not match",
+			req:           s.MakeUploadReq(path, "image", "upload.jpg", s.PNGImage(), admin),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: fieldErr,
+		},
+		{
+			desc:          "Image too heavy",
+			req:           s.MakeUploadReq(path, "image", "upload.png", heavy, admin),
+			showResp:      false,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: fieldErr,
+		},
+		{
+			desc:          "Form too big",
+			req:           s.MakeUploadReq(path, "image", "upload.png", make([]byte, utils.MaxUploadBody), admin),
+			showResp:      false,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Image too wide",
+			req:           s.MakeUploadReq(path, "image", "upload.png", wide.Bytes(), admin),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Product not found",
+			req:           s.MakeUploadReq(s.bp+"/"+uuid.NewString()+"/image", "image", "upload.png", s.PNGImage(), admin),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Upload success",
+			req:        s.MakeUploadReq(path, "image", "upload.png", s.PNGImage(), admin),
+			showResp:   true,
+			wantStatus: http.StatusCreated,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				img, _ := jsm["data"].(map[string]any)
+				imgID, _ = img["id"].(string)
+				rds, _ := img["renditions"].([]any)
+				if upload.WebP {
+					s.Require().Len(rds, 6, "should have the thumbnail, medium and large, also as webp")
+					wrd, _ := rds[1].(map[string]any)
+					s.Equal("thumbnail", wrd["name"])
+					s.Equal("webp", wrd["format"])
+				} else {
+					s.Require().Len(rds, 3, "should have the thumbnail, medium and large")
+				}
+				rd, _ := rds[0].(map[string]any)
+				s.Equal("thumbnail", rd["name"])
+				s.Equal("png", rd["format"], "the transparent images stay png")
+				url, _ = rd["url"].(string)
+				s.True(strings.HasSuffix(url, ".png"), "should have the extension")
+			},
+		},
+		{
+			desc:       "Product has the image",
+			req:        s.MakeReq("GET", s.bp+"/get/"+utils.ProductExpToDev1.ID.String(), nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				p, _ := jsm["data"].(map[string]any)
+				imgs, _ := p["images"].([]any)
+				s.Require().NotEmpty(imgs)
+				last, _ := imgs[len(imgs)-1].(map[string]any)
+				s.Equal(imgID, last["id"])
+			},
+		},
+	})
+
+	res, err := s.server.TryRoute(s.MakeReq("GET", strings.TrimPrefix(url, s.cfg.Api.ServerHost), nil))
+
+	s.Require().NoError(err, "request error!")
+	s.Equal(http.StatusOK, res.StatusCode, "the image should be served")
+	s.Equal("image/png", res.Header.Get("Content-Type"))
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc:          "Remove unexisting image",
+			req:           s.MakeReq("DELETE", path+"/"+uuid.NewString(), nil, admin),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Remove image success",
+			req:           s.MakeReq("DELETE", path+"/"+imgID, nil, admin),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	})
+
+	_, err = os.Stat(filepath.Join(s.uploadDir, filepath.Base(url)))
+
+	s.True(os.IsNotExist(err), "the image files should be removed")
+}
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReturnRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestReturnRoutesSuite(t *testing.T) {
+	rs := new(ReturnRoutesSuite)
+	rs.bp = "/api/return"
+	suite.Run(t, rs)
+}
+
+func (s *ReturnRoutesSuite) TestReturnRoutes_Create() {
+	path := s.bp + "/new"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("POST", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid body (empty)",
+			req: s.MakeReq("POST", path, dtos.NewReturnDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Order not delivered",
+			req: s.MakeReq("POST", path, dtos.NewReturnDTO{
+				OrderID: utils.OrderExp4.ID,
+				Reason:  "The shoes are too small",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 1}},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("POST", path, dtos.NewReturnDTO{
+				OrderID: utils.OrderExp5.ID,
+				Reason:  "The shoes are too small",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 1}},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:   true,
+			wantStatus: http.StatusCreated,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				ret, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the return")
+				s.Equal(utils.ReturnRequested, ret["status"], "wrong status")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ReturnRoutesSuite) TestReturnRoutes_GetAll() {
+	path := s.bp + "/all"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("GET", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ReturnRoutesSuite) TestReturnRoutes_Moderate() {
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+		"Content-Type":              "application/json",
+	}
+
+	res, err := s.server.TryRoute(s.MakeReq("POST", s.bp+"/new", dtos.NewReturnDTO{
+		OrderID: utils.OrderExp5.ID,
+		Reason:  "It does not turn on",
+		Items:   []domain.ReturnItem{{ProductID: utils.ProductExpToDev1.ID, Quantity: 1}},
+	}, map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+		"Content-Type":              "application/json",
+	}))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusCreated, res.StatusCode, "wrong status code!")
+
+	defer res.Body.Close()
+
+	body := struct {
+		Data domain.ReturnRequest `json:"data"`
+	}{}
+
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+
+	ID := body.Data.ID.String()
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("PUT", s.bp+"/reject/"+ID, dtos.ModerateReturnDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Not found return",
+			req:           s.MakeReq("PUT", s.bp+"/approve/"+uuid.New().String(), dtos.ModerateReturnDTO{}, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Not approved return cannot be received",
+			req:           s.MakeReq("PUT", s.bp+"/receive/"+ID, dtos.ReceiveReturnDTO{Restock: true}, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Proper work",
+			req:           s.MakeReq("PUT", s.bp+"/reject/"+ID, dtos.ModerateReturnDTO{Note: "The seal is broken"}, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Already rejected",
+			req:           s.MakeReq("PUT", s.bp+"/approve/"+ID, dtos.ModerateReturnDTO{}, hdrs),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+
+	rets := []domain.ReturnRequest{}
+
+	res, err = s.server.TryRoute(s.MakeReq("GET", s.bp+"/list", nil, map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+	}))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+
+	defer res.Body.Close()
+
+	list := struct {
+		Data *[]domain.ReturnRequest `json:"data"`
+	}{Data: &rets}
+
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&list), "unmarshall err")
+	s.Len(rets, 2, "wrong returns")
+}
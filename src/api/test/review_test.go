@@ -0,0 +1,163 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestReviewRoutesSuite(t *testing.T) {
+	rvs := new(ReviewRoutesSuite)
+	rvs.bp = "/api/review"
+	suite.Run(t, rvs)
+}
+
+func (s *ReviewRoutesSuite) TestReviewRoutes_Workflow() {
+	user := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+		"Content-Type":              "application/json",
+	}
+	mod := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+		"Content-Type":              "application/json",
+	}
+	prodPath := s.bp + "/product/" + utils.ProductExp1.ID.String()
+
+	var revID string
+
+	checkReviews := func(n int) func(map[string]any) {
+		return func(jsm map[string]any) {
+			s.CheckSuccess(jsm)
+			revs, _ := jsm["data"].([]any)
+			s.Len(revs, n, "wrong number of reviews")
+		}
+	}
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc:          "No token provided",
+			req:           s.MakeReq("POST", s.bp+"/create", nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid rating",
+			req: s.MakeReq("POST", s.bp+"/create", dtos.NewReviewDTO{
+				ProductID: utils.ProductExp1.ID,
+				Rating:    7,
+				Title:     "Great",
+			}, user),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Product not bought",
+			req: s.MakeReq("POST", s.bp+"/create", dtos.NewReviewDTO{
+				ProductID: utils.ProductExp1.ID,
+				Rating:    4,
+				Title:     "Great",
+			}, mod),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Create success",
+			req: s.MakeReq("POST", s.bp+"/create", dtos.NewReviewDTO{
+				ProductID: utils.ProductExp1.ID,
+				Rating:    4,
+				Title:     "Great",
+				Body:      "Works as expected.",
+			}, user),
+			showResp:   true,
+			wantStatus: http.StatusCreated,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				rev, ok := jsm["data"].(map[string]any)
+				s.Require().True(ok, "should contain the review")
+				s.Equal(utils.UserExp1.Username, rev["author"])
+				revID, _ = rev["id"].(string)
+			},
+		},
+		{
+			desc: "Already reviewed",
+			req: s.MakeReq("POST", s.bp+"/create", dtos.NewReviewDTO{
+				ProductID: utils.ProductExp1.ID,
+				Rating:    1,
+				Title:     "Bad",
+			}, user),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:       "Product rating",
+			req:        s.MakeReq("GET", "/api/product/get/"+utils.ProductExp1.ID.String(), nil),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				p, _ := jsm["data"].(map[string]any)
+				s.Equal(float64(4), p["rating_avg"])
+				s.Equal(float64(1), p["rating_count"])
+			},
+		},
+	})
+
+	s.Require().NotEmpty(revID, "the review should be created")
+
+	s.RunRequests([]TryRouteTestCase{
+		{
+			desc:          "User cannot hide",
+			req:           s.MakeReq("PUT", s.bp+"/hide/"+revID, dtos.HideReviewDTO{Hidden: true}, user),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Hide success",
+			req:           s.MakeReq("PUT", s.bp+"/hide/"+revID, dtos.HideReviewDTO{Hidden: true}, mod),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Hidden reviews are not listed",
+			req:           s.MakeReq("GET", prodPath, nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkReviews(0),
+		},
+		{
+			desc:          "Moderators list the hidden reviews",
+			req:           s.MakeReq("GET", prodPath+"/all", nil, mod),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: checkReviews(1),
+		},
+		{
+			desc:          "Delete success",
+			req:           s.MakeReq("DELETE", s.bp+"/delete/"+revID, nil, mod),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc:          "Review not found",
+			req:           s.MakeReq("DELETE", s.bp+"/delete/"+revID, nil, mod),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+	})
+}
@@ -3,7 +3,10 @@ package test
 import (
 	"bytes"
 	"encoding/json"
+	"image"
+	"image/png"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"time"
 
@@ -12,28 +15,48 @@ import (
 	addressHandler "github.com/ZaphCode/clean-arch/src/api/handlers/address"
 	authHandler "github.com/ZaphCode/clean-arch/src/api/handlers/auth"
 	cardHandler "github.com/ZaphCode/clean-arch/src/api/handlers/card"
+	cartHandler "github.com/ZaphCode/clean-arch/src/api/handlers/cart"
 	categoryHandler "github.com/ZaphCode/clean-arch/src/api/handlers/category"
+	couponHandler "github.com/ZaphCode/clean-arch/src/api/handlers/coupon"
 	orderHandler "github.com/ZaphCode/clean-arch/src/api/handlers/order"
+	paymentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/payment"
 	productHandler "github.com/ZaphCode/clean-arch/src/api/handlers/product"
+	returnHandler "github.com/ZaphCode/clean-arch/src/api/handlers/returns"
+	reviewHandler "github.com/ZaphCode/clean-arch/src/api/handlers/review"
+	shipmentHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipment"
+	shippingHandler "github.com/ZaphCode/clean-arch/src/api/handlers/shipping"
 	userHandler "github.com/ZaphCode/clean-arch/src/api/handlers/user"
 	"github.com/ZaphCode/clean-arch/src/api/middlewares"
 	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/cart"
 	"github.com/ZaphCode/clean-arch/src/repositories/category"
+	"github.com/ZaphCode/clean-arch/src/repositories/coupon"
+	"github.com/ZaphCode/clean-arch/src/repositories/idempotency"
+	invoiceRepo "github.com/ZaphCode/clean-arch/src/repositories/invoice"
 	"github.com/ZaphCode/clean-arch/src/repositories/order"
 	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/returns"
+	"github.com/ZaphCode/clean-arch/src/repositories/review"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipment"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipping"
 	"github.com/ZaphCode/clean-arch/src/repositories/user"
 	"github.com/ZaphCode/clean-arch/src/services/auth"
 	"github.com/ZaphCode/clean-arch/src/services/core"
 	"github.com/ZaphCode/clean-arch/src/services/email"
+	"github.com/ZaphCode/clean-arch/src/services/invoice"
 	"github.com/ZaphCode/clean-arch/src/services/payment"
+	"github.com/ZaphCode/clean-arch/src/services/search"
+	"github.com/ZaphCode/clean-arch/src/services/tax"
+	"github.com/ZaphCode/clean-arch/src/services/upload"
 	"github.com/ZaphCode/clean-arch/src/services/validation"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/stretchr/testify/suite"
-	"github.com/stripe/stripe-go/v74"
-
-	"github.com/stripe/stripe-go/v74/paymentmethod"
 )
 
+// testConfigDir has the config the suites run with, so they do not
+// need the one of the project.
+const testConfigDir = "./testdata"
+
 type TryRouteTestCase struct {
 	desc          string
 	req           *http.Request
@@ -50,50 +73,101 @@ type ServerSuite struct {
 	modAccessToken   string
 	userAccessToken  string
 	paymentID        string
+	uploadDir        string
 }
 
 func (s *ServerSuite) SetupSuite() {
 	s.T().Logf("\n----------- SETUP ------------")
 
-	config.MustLoadConfig("./../../../config")
+	config.MustLoadConfig(testConfigDir)
 	s.cfg = config.Get()
+	s.uploadDir = s.T().TempDir()
 
 	// Repos
 	userRepo := user.NewMemoryUserRepository(utils.UserAdmin, utils.UserExp1, utils.UserExp2)
 	prodRepo := product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExpToDev1)
 	catRepo := category.NewMemoryCategoryRepository(utils.CategoryExp1, utils.CategoryExp2, utils.CategoryExp3)
 	addrRepo := address.NewMemoryAddressRepository(utils.AddrExp1, utils.AddrExp2)
-	ordRepo := order.NewMemoryOrderRepository()
+	ordRepo := order.NewMemoryOrderRepository(utils.OrderExp1, utils.OrderExp2, utils.OrderExp3, utils.OrderExp4, utils.OrderExp5)
+	cartRepo := cart.NewMemoryCartRepository()
+	cpnRepo := coupon.NewMemoryCouponRepository(utils.CouponExp1, utils.CouponExp2)
+	idemRepo := idempotency.NewMemoryIdempotencyRepository()
+	shipRepo := shipping.NewMemoryShippingRepository(utils.ShippingExp1, utils.ShippingExp2)
+	shpRepo := shipment.NewMemoryShipmentRepository()
+	retRepo := returns.NewMemoryReturnRepository()
+	invRepo := invoiceRepo.NewMemoryInvoiceRepository()
+	revRepo := review.NewMemoryReviewRepository()
 
 	// Services
 	userSvc := core.NewUserService(userRepo)
-	prodSvc := core.NewProductService(prodRepo, catRepo)
+	srchSvc := search.NewMemorySearchService(utils.ProductExp1, utils.ProductExpToDev1)
+	prodSvc := core.NewProductService(prodRepo, catRepo, revRepo, srchSvc)
 	catSvc := core.NewCategoryService(catRepo, prodRepo)
 	addrSvc := core.NewAddressService(addrRepo, userRepo)
-	ordSvc := core.NewOrderService(ordRepo, addrRepo)
-	pmSvc := payment.NewStripePaymentService(s.cfg.Stripe.SecretKey, userRepo)
+	cartSvc := core.NewCartService(cartRepo, prodRepo)
+	cpnSvc := core.NewCouponService(cpnRepo, prodRepo)
+	ordSvc := core.NewOrderService(ordRepo, addrRepo, prodRepo, cpnSvc, nil)
+	shipSvc := core.NewShippingService(shipRepo, addrRepo, prodRepo)
+	shpSvc := core.NewShipmentService(shpRepo, ordSvc)
+	retSvc := core.NewReturnService(retRepo, ordSvc, prodRepo)
+	invSvc := core.NewInvoiceService(invRepo, ordRepo)
+	revSvc := core.NewReviewService(revRepo, ordRepo, prodRepo, userRepo)
+	invGen := invoice.NewInvoiceGenerator(invoice.Issuer{Name: "Clean Arch Store"})
+	taxCalc := tax.NewTableTaxCalculator(
+		tax.Rate{Name: "Washintong sales tax", Country: "USA", State: "Washintong", Rate: 650, TaxShipping: true},
+		tax.Rate{Name: "IVA", Country: "Mexico", Rate: 1600, Inclusive: true, TaxShipping: true},
+	)
+	idemSvc := core.NewIdempotencyService(idemRepo, time.Duration(s.cfg.Api.IdempotencyWindow)*time.Second)
+	pmSvc := payment.NewMemoryPaymentService(s.cfg.Stripe.WebhookSecret, userRepo,
+		payment.Card{
+			CustomerID: utils.UserExp1.CustomerID,
+			PaymentID:  "pm_1NKP27G8UXDxPRbaNZRE6Ajd",
+			Brand:      "visa",
+			Last4:      "4242",
+		},
+		payment.Card{
+			PaymentID: "pm_1NKQ9aG8UXDxPRbaSrvSuite",
+			Name:      "FROM SERVER SUITE",
+			Brand:     "mastercard",
+			Last4:     "4444",
+		},
+	)
 	emailSvc := email.NewSmtpEmailService()
+	uplSvc := upload.NewLocalUploadService(s.uploadDir, s.cfg.Api.ServerHost+utils.UploadRoute)
 	vldSvc := validation.NewValidationService()
 	jwtSvc := auth.NewJWTService()
 
+	if src, ok := pmSvc.(payment.EventSource); ok {
+		src.OnEvent(ordSvc.HandlePaymentEvent)
+	}
+
 	// Midlewares
 	authMdlw := middlewares.NewAuthMiddleware(jwtSvc)
 	paymMdlw := middlewares.NewPaymentMiddleware(pmSvc)
+	idemMdlw := middlewares.NewIdempotencyMiddleware(idemSvc)
 
 	// Handlers
-	usrHdlr := userHandler.NewUserHandler(userSvc, vldSvc)
+	usrHdlr := userHandler.NewUserHandler(userSvc, uplSvc, vldSvc)
 	addrHdlr := addressHandler.NewAddressHandler(userSvc, addrSvc, vldSvc)
 	authHdlr := authHandler.NewAuthHandler(userSvc, emailSvc, jwtSvc, vldSvc)
-	prodHdlr := productHandler.NewProductHandler(prodSvc, catSvc, vldSvc)
+	prodHdlr := productHandler.NewProductHandler(prodSvc, catSvc, srchSvc, uplSvc, vldSvc)
 	catHdlr := categoryHandler.NewCategoryHandler(prodSvc, catSvc, vldSvc)
 	cardHdlr := cardHandler.NewCardHandler(userSvc, pmSvc, vldSvc)
-	ordHdlr := orderHandler.NewOrderHandler(userSvc, ordSvc, prodSvc, pmSvc, vldSvc)
+	cartHdlr := cartHandler.NewCartHandler(cartSvc, prodSvc, vldSvc)
+	ordHdlr := orderHandler.NewOrderHandler(userSvc, ordSvc, prodSvc, cartSvc, cpnSvc, shipSvc, addrSvc, invSvc, taxCalc, invGen, pmSvc, vldSvc)
+	pmHdlr := paymentHandler.NewPaymentHandler(ordSvc, pmSvc)
+	cpnHdlr := couponHandler.NewCouponHandler(cpnSvc, vldSvc)
+	shipHdlr := shippingHandler.NewShippingHandler(shipSvc, vldSvc)
+	shpHdlr := shipmentHandler.NewShipmentHandler(shpSvc, ordSvc, vldSvc)
+	retHdlr := returnHandler.NewReturnHandler(retSvc, ordSvc, pmSvc, vldSvc)
+	revHdlr := reviewHandler.NewReviewHandler(revSvc, vldSvc)
 
 	// Server
 	server := api.New()
 
 	// Setup
 	server.SetGlobalMiddlewares()
+	server.ServeUploads(s.uploadDir)
 
 	// Routes
 	server.CreateAuthRoutes(authHdlr, authMdlw)
@@ -101,8 +175,15 @@ func (s *ServerSuite) SetupSuite() {
 	server.CreateProductRoutes(prodHdlr, authMdlw)
 	server.CreateCategoryRoutes(catHdlr, authMdlw)
 	server.CreateAddressesRoutes(addrHdlr, authMdlw)
-	server.CreateOrderRoutes(ordHdlr, paymMdlw, authMdlw)
-	server.CreateCardRoutes(cardHdlr, paymMdlw, authMdlw)
+	server.CreateOrderRoutes(ordHdlr, paymMdlw, idemMdlw, authMdlw)
+	server.CreateCartRoutes(cartHdlr, authMdlw)
+	server.CreatePaymentRoutes(pmHdlr)
+	server.CreateCouponRoutes(cpnHdlr, authMdlw)
+	server.CreateShippingRoutes(shipHdlr, authMdlw)
+	server.CreateShipmentRoutes(shpHdlr, authMdlw)
+	server.CreateReturnRoutes(retHdlr, authMdlw)
+	server.CreateReviewRoutes(revHdlr, authMdlw)
+	server.CreateCardRoutes(cardHdlr, paymMdlw, idemMdlw, authMdlw)
 
 	s.server = server
 
@@ -136,24 +217,7 @@ func (s *ServerSuite) SetupSuite() {
 
 	s.userAccessToken = ut
 
-	params := &stripe.PaymentMethodParams{
-		Card: &stripe.PaymentMethodCardParams{
-			Number:   stripe.String("5555555555554444"),
-			ExpMonth: stripe.Int64(4),
-			ExpYear:  stripe.Int64(2025),
-			CVC:      stripe.String("314"),
-		},
-		BillingDetails: &stripe.PaymentMethodBillingDetailsParams{
-			Name: stripe.String("FROM SERVER SUITE"),
-		},
-		Type: stripe.String("card"),
-	}
-
-	pm, err := paymentmethod.New(params)
-
-	s.NoError(err, "should not be error")
-
-	s.paymentID = pm.ID
+	s.paymentID = "pm_1NKQ9aG8UXDxPRbaSrvSuite"
 }
 
 func (s *ServerSuite) RunRequests(testCases []TryRouteTestCase) {
@@ -228,6 +292,58 @@ func (s *ServerSuite) MakeReq(met, path string, body any, hdrs ...map[string]str
 	return req
 }
 
+// Chunked sends the body of the request in chunks, with no length.
+func (s *ServerSuite) Chunked(req *http.Request) *http.Request {
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+
+	return req
+}
+
+// PNGImage is a small png to upload.
+func (s *ServerSuite) PNGImage() []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	buf := &bytes.Buffer{}
+
+	if err := png.Encode(buf, img); err != nil {
+		s.FailNow("png encode error")
+	}
+
+	return buf.Bytes()
+}
+
+// MakeUploadReq makes a multipart request with one file in the field.
+func (s *ServerSuite) MakeUploadReq(path, field, filename string, file []byte, hdrs ...map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+
+	fw, err := mw.CreateFormFile(field, filename)
+
+	if err != nil {
+		s.FailNow("multipart error")
+	}
+
+	if _, err := fw.Write(file); err != nil || mw.Close() != nil {
+		s.FailNow("multipart error")
+	}
+
+	req, err := http.NewRequest("POST", path, body)
+
+	if err != nil {
+		s.FailNow("request error")
+	}
+
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	if len(hdrs) > 0 {
+		for h, v := range hdrs[0] {
+			req.Header.Set(h, v)
+		}
+	}
+
+	return req
+}
+
 func (s *ServerSuite) CheckSuccess(jsm map[string]any) {
 	status, ok := jsm["status"]
 	s.True(ok, "should contain status")
@@ -0,0 +1,210 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShipmentRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestShipmentRoutesSuite(t *testing.T) {
+	shs := new(ShipmentRoutesSuite)
+	shs.bp = "/api/shipment"
+	suite.Run(t, shs)
+}
+
+func (s *ShipmentRoutesSuite) TestShipmentRoutes_Create() {
+	path := s.bp + "/create"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("POST", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid body (empty)",
+			req: s.MakeReq("POST", path, dtos.NewShipmentDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Order not paid",
+			req: s.MakeReq("POST", path, dtos.NewShipmentDTO{
+				OrderID:        utils.OrderExp2.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456784",
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work (partial)",
+			req: s.MakeReq("POST", path, dtos.NewShipmentDTO{
+				OrderID:        utils.OrderExp4.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456784",
+				Items: []domain.ShipmentItem{
+					{ProductID: utils.ProductExp1.ID, Quantity: 2},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusCreated,
+			bodyValidator: s.CheckSuccess,
+		},
+		{
+			desc: "More items than left",
+			req: s.MakeReq("POST", path, dtos.NewShipmentDTO{
+				OrderID:        utils.OrderExp4.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456785",
+				Items: []domain.ShipmentItem{
+					{ProductID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusConflict,
+			bodyValidator: s.CheckFail,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShipmentRoutesSuite) TestShipmentRoutes_GetByOrder() {
+	path := s.bp + "/order/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Invalid order id",
+			req: s.MakeReq("GET", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Not found order",
+			req: s.MakeReq("GET", path+uuid.New().String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotFound,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("GET", path+utils.OrderExp4.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				shps, ok := jsm["data"].([]any)
+				s.Require().True(ok, "should contain the shipments")
+				s.Len(shps, 1, "wrong shipments")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShipmentRoutesSuite) TestShipmentRoutes_Lifecycle() {
+	hdrs := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+		"Content-Type":              "application/json",
+	}
+
+	res, err := s.server.TryRoute(s.MakeReq("POST", s.bp+"/create", dtos.NewShipmentDTO{
+		OrderID:        utils.OrderExp4.ID,
+		Carrier:        "FedEx",
+		TrackingNumber: "449044304137821",
+	}, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusCreated, res.StatusCode, "should ship everything left")
+
+	shps := []domain.Shipment{}
+
+	s.getData(s.bp+"/order/"+utils.OrderExp4.ID.String(), &shps)
+	s.Require().Len(shps, 2, "wrong shipments")
+
+	for _, shp := range shps {
+		delivered := shp.ShippedAt + 3600
+
+		res, err := s.server.TryRoute(s.MakeReq("PUT", s.bp+"/update/"+shp.ID.String(), dtos.UpdateShipmentDTO{
+			DeliveredAt: &delivered,
+		}, hdrs))
+
+		s.Require().NoError(err, "request error!")
+		s.Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+	}
+
+	ords := []domain.Order{}
+
+	s.getData("/api/order/list", &ords)
+
+	status := ""
+
+	for _, ord := range ords {
+		if ord.ID == utils.OrderExp4.ID {
+			status = ord.Status
+		}
+	}
+
+	s.Equal(utils.StatusDelivered, status, "every shipment has arrived")
+
+	res, err = s.server.TryRoute(s.MakeReq("DELETE", s.bp+"/delete/"+shps[0].ID.String(), nil, hdrs))
+
+	s.Require().NoError(err, "request error!")
+	s.Equal(http.StatusConflict, res.StatusCode, "the order has been delivered")
+}
+
+// Helper functions
+
+// getData decodes the data of a successful user request.
+func (s *ShipmentRoutesSuite) getData(path string, data any) {
+	res, err := s.server.TryRoute(s.MakeReq("GET", path, nil, map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+	}))
+
+	s.Require().NoError(err, "request error!")
+	s.Require().Equal(http.StatusOK, res.StatusCode, "wrong status code!")
+
+	defer res.Body.Close()
+
+	body := struct {
+		Data any `json:"data"`
+	}{Data: data}
+
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&body), "unmarshall err")
+}
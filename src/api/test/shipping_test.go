@@ -0,0 +1,248 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/api/dtos"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShippingRoutesSuite struct {
+	ServerSuite
+	bp string
+}
+
+func TestShippingRoutesSuite(t *testing.T) {
+	shs := new(ShippingRoutesSuite)
+	shs.bp = "/api/shipping"
+	suite.Run(t, shs)
+}
+
+func (s *ShippingRoutesSuite) TestShippingRoutes_GetAll() {
+	path := s.bp + "/methods"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Get all shipping methods",
+			req:           s.MakeReq("GET", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShippingRoutesSuite) TestShippingRoutes_Quote() {
+	path := s.bp + "/quote"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "Not authenticated",
+			req:           s.MakeReq("POST", path, nil),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Invalid body (empty)",
+			req: s.MakeReq("POST", path, dtos.QuoteShippingDTO{}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unexisting address",
+			req: s.MakeReq("POST", path, dtos.QuoteShippingDTO{
+				AddressID: uuid.New(),
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("POST", path, dtos.QuoteShippingDTO{
+				AddressID: utils.AddrExp1.ID,
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 5},
+				},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:   true,
+			wantStatus: http.StatusOK,
+			bodyValidator: func(jsm map[string]any) {
+				s.CheckSuccess(jsm)
+				quotes, ok := jsm["data"].([]any)
+				s.Require().True(ok, "should contain the quotes")
+				costs := map[any]float64{}
+				last := float64(-1)
+				for _, q := range quotes {
+					quote, _ := q.(map[string]any)
+					cost, _ := quote["cost"].(map[string]any)
+					amount, _ := cost["amount"].(float64)
+					s.GreaterOrEqual(amount, last, "cheapest first")
+					costs[quote["method"]], last = amount, amount
+				}
+				s.Equal(float64(900), costs["express"], "should use the state rate")
+			},
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShippingRoutesSuite) TestShippingRoutes_Create() {
+	path := s.bp + "/create"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("POST", path, nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Without rates",
+			req: s.MakeReq("POST", path, dtos.NewShippingMethodDTO{
+				Code: "pickup",
+				Name: "Store pickup",
+				Type: utils.ShippingFlat,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Code already used",
+			req: s.MakeReq("POST", path, dtos.NewShippingMethodDTO{
+				Code:  "Standard",
+				Name:  "Another standard",
+				Type:  utils.ShippingFlat,
+				Rates: []dtos.ShippingRateDTO{{Price: 700}},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("POST", path, dtos.NewShippingMethodDTO{
+				Code: "pickup",
+				Name: "Store pickup",
+				Type: utils.ShippingFlat,
+				Rates: []dtos.ShippingRateDTO{
+					{Country: "Mexico", State: "Baja California Sur", Price: 0},
+				},
+				Active: true,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusCreated,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShippingRoutesSuite) TestShippingRoutes_Update() {
+	path := s.bp + "/update/"
+	active := false
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "Invalid shipping method id",
+			req: s.MakeReq("PUT", path+"dafadf", nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusNotAcceptable,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "State without country",
+			req: s.MakeReq("PUT", path+utils.ShippingExp2.ID.String(), dtos.UpdateShippingMethodDTO{
+				Rates: []dtos.ShippingRateDTO{{State: "Texas", Price: 900}},
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("PUT", path+utils.ShippingExp2.ID.String(), dtos.UpdateShippingMethodDTO{
+				Active: &active,
+			}, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+				"Content-Type":              "application/json",
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
+
+func (s *ShippingRoutesSuite) TestShippingRoutes_Delete() {
+	path := s.bp + "/delete/"
+
+	testCases := []TryRouteTestCase{
+		{
+			desc: "User has not permissions",
+			req: s.MakeReq("DELETE", path+utils.ShippingExp2.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.modAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusForbidden,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Unexisting shipping method",
+			req: s.MakeReq("DELETE", path+uuid.New().String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusInternalServerError,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc: "Proper work",
+			req: s.MakeReq("DELETE", path+utils.ShippingExp1.ID.String(), nil, map[string]string{
+				s.cfg.Api.AccessTokenHeader: s.adminAccessToken,
+			}),
+			showResp:      true,
+			wantStatus:    http.StatusOK,
+			bodyValidator: s.CheckSuccess,
+		},
+	}
+	s.RunRequests(testCases)
+}
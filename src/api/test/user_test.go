@@ -2,6 +2,8 @@ package test
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ZaphCode/clean-arch/src/api/dtos"
@@ -340,3 +342,57 @@ func (s *UserRoutesSuite) TestUserRoutes_Update() {
 	}
 	s.RunRequests(testCases)
 }
+
+func (s *UserRoutesSuite) TestUserRoutes_UploadAvatar() {
+	path := s.bp + "/avatar"
+	user := map[string]string{
+		s.cfg.Api.AccessTokenHeader: s.userAccessToken,
+	}
+
+	var first string
+
+	upload := func(url *string) func(jsm map[string]any) {
+		return func(jsm map[string]any) {
+			s.CheckSuccess(jsm)
+			*url, _ = jsm["data"].(string)
+			s.Contains(*url, utils.UploadRoute+"/", "should be a local upload")
+		}
+	}
+
+	testCases := []TryRouteTestCase{
+		{
+			desc:          "No token provided",
+			req:           s.MakeUploadReq(path, "avatar", "upload.png", s.PNGImage()),
+			showResp:      true,
+			wantStatus:    http.StatusUnauthorized,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Not an image",
+			req:           s.MakeUploadReq(path, "avatar", "avatar.html", []byte("<html></html>"), user),
+			showResp:      true,
+			wantStatus:    http.StatusBadRequest,
+			bodyValidator: s.CheckFail,
+		},
+		{
+			desc:          "Upload success",
+			req:           s.MakeUploadReq(path, "avatar", "upload.png", s.PNGImage(), user),
+			showResp:      true,
+			wantStatus:    http.StatusCreated,
+			bodyValidator: upload(&first),
+		},
+		{
+			desc:          "Upload another avatar",
+			req:           s.MakeUploadReq(path, "avatar", "upload.png", s.PNGImage(), user),
+			showResp:      true,
+			wantStatus:    http.StatusCreated,
+			bodyValidator: upload(new(string)),
+		},
+	}
+
+	s.RunRequests(testCases)
+
+	_, err := os.Stat(filepath.Join(s.uploadDir, filepath.Base(first)))
+
+	s.True(os.IsNotExist(err), "the previous avatar should be removed")
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// Cart shares its ID with the user that owns it.
+type Cart struct {
+	Model
+	UserID uuid.UUID      `json:"user_id"`
+	Items  []OrderProduct `json:"items"`
+}
+
+//* Service
+
+type CartService interface {
+	GetByUserID(usrID uuid.UUID) (*Cart, error)
+	AddItem(usrID uuid.UUID, ref ProductRef, qty uint) error
+	UpdateItem(usrID uuid.UUID, ref ProductRef, qty uint) error
+	RemoveItem(usrID uuid.UUID, ref ProductRef) error
+	Clear(usrID uuid.UUID) error
+}
+
+//* Repository
+
+type CartRepository interface {
+	RepositoryCrudOperations[Cart]
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// Coupon takes a percentage (Rate) or a fixed Amount off the products
+// it applies to. Empty Categories and Tags mean every product. Zero
+// limits and ExpiresAt mean no limit.
+type Coupon struct {
+	Model
+	Code           string           `json:"code"`
+	Type           string           `json:"type"`
+	Rate           int64            `json:"rate"`
+	Amount         Money            `json:"amount"`
+	MinOrderAmount Money            `json:"min_order_amount"`
+	ExpiresAt      int64            `json:"expires_at"`
+	MaxUses        int64            `json:"max_uses"`
+	MaxUsesPerUser int64            `json:"max_uses_per_user"`
+	Uses           int64            `json:"uses"`
+	UsesByUser     map[string]int64 `json:"uses_by_user"`
+	Categories     []string         `json:"categories"`
+	Tags           []string         `json:"tags"`
+	Active         bool             `json:"active"`
+}
+
+//* Service
+
+type CouponService interface {
+	ServiceCrudOperations[Coupon]
+	GetByCode(code string) (*Coupon, error)
+	// Apply returns the discount of the coupon for lines already priced
+	Apply(code string, usrID uuid.UUID, ops []OrderProduct) (Money, error)
+	Redeem(code string, usrID uuid.UUID) error
+	Release(code string, usrID uuid.UUID) error
+}
+
+//* Repository
+
+type CouponRepository interface {
+	RepositoryCrudOperations[Coupon]
+	FindByField(fld string, val any) (*Coupon, error)
+}
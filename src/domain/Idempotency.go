@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// IdempotencyRecord keeps the first response given to a request
+// so it can be replayed when the client retries with the same key.
+// StatusCode is zero while the first request is still running.
+type IdempotencyRecord struct {
+	Model
+	UserID      uuid.UUID `json:"user_id"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	Response    string    `json:"response"`
+	ExpiresAt   int64     `json:"expires_at"`
+}
+
+//* Service
+
+type IdempotencyService interface {
+	// Start claims the key for the user. If the key was already used
+	// it returns the saved record instead
+	Start(usrID uuid.UUID, key, fingerprint string) (*IdempotencyRecord, error)
+	Finish(usrID uuid.UUID, key string, status int, contentType, response string) error
+	Abort(usrID uuid.UUID, key string) error
+}
+
+//* Repository
+
+type IdempotencyRepository interface {
+	RepositoryCrudOperations[IdempotencyRecord]
+}
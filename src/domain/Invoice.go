@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// Invoice gives an order its invoice number. Numbers are
+// sequential and an order keeps the same one forever.
+type Invoice struct {
+	Model
+	Number  int64     `json:"number"`
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+//* Service
+
+type InvoiceService interface {
+	// GetOrIssue returns the invoice of the order, issuing it the first time
+	GetOrIssue(ordID uuid.UUID) (*Invoice, error)
+}
+
+//* Repository
+
+type InvoiceRepository interface {
+	RepositoryCrudOperations[Invoice]
+	FindByField(fld string, val any) (*Invoice, error)
+	// SaveNext saves the invoice with the number after the last one
+	SaveNext(inv *Invoice) error
+}
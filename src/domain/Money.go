@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+//* Model
+
+// Money is an amount in the minor unit of its currency (cents for usd).
+// A zero amount without currency can be mixed with any currency.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToLower(currency)}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.sharedCurrency(o)
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount + o.Amount, Currency: cur}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	cur, err := m.sharedCurrency(o)
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount - o.Amount, Currency: cur}, nil
+}
+
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// ApplyDiscount takes a percentage off the amount. The result is
+// rounded half up to the nearest minor unit, so 2.5 cents become 3.
+func (m Money) ApplyDiscount(rate int64) (Money, error) {
+	if rate < 0 || rate > 100 {
+		return Money{}, fmt.Errorf("invalid discount rate %d", rate)
+	}
+
+	n := m.Amount * (100 - rate)
+	amount := n / 100
+
+	if n%100*2 >= 100 {
+		amount++
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Scale multiplies the amount by num/den rounded half up.
+// It is used for rates with decimals and proportional splits.
+func (m Money) Scale(num, den int64) Money {
+	n := m.Amount * num
+	amount := n / den
+
+	if n%den*2 >= den {
+		amount++
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}
+}
+
+func (m Money) String() string {
+	sign, amount := "", m.Amount
+
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, strings.ToUpper(m.Currency))
+}
+
+func (m Money) sharedCurrency(o Money) (string, error) {
+	switch {
+	case m.Currency == o.Currency:
+		return m.Currency, nil
+	case o.Currency == "" && o.IsZero():
+		return m.Currency, nil
+	case m.Currency == "" && m.IsZero():
+		return o.Currency, nil
+	}
+
+	return "", fmt.Errorf("cannot mix %q and %q amounts", m.Currency, o.Currency)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestMoneyApplyDiscount(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		amount  int64
+		rate    int64
+		want    int64
+		wantErr bool
+	}{
+		{desc: "no discount", amount: 1549, rate: 0, want: 1549},
+		{desc: "exact", amount: 2400, rate: 14, want: 2064},
+		{desc: "rounds down", amount: 2599, rate: 30, want: 1819},
+		{desc: "rounds half up", amount: 5, rate: 50, want: 3},
+		{desc: "rounds up", amount: 1999, rate: 75, want: 500},
+		{desc: "one cent", amount: 1, rate: 50, want: 1},
+		{desc: "free", amount: 4500, rate: 100, want: 0},
+		{desc: "big amount", amount: 123456789012, rate: 15, want: 104938270660},
+		{desc: "negative rate", amount: 100, rate: -1, wantErr: true},
+		{desc: "rate over 100", amount: 100, rate: 101, wantErr: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := NewMoney(tC.amount, "usd").ApplyDiscount(tC.rate)
+
+			if (err != nil) != tC.wantErr {
+				t.Fatalf("expect err fail: %v", err)
+			}
+
+			if !tC.wantErr && got.Amount != tC.want {
+				t.Errorf("got %d, want %d", got.Amount, tC.want)
+			}
+		})
+	}
+}
+
+func TestMoneyAddAndSub(t *testing.T) {
+	usd := NewMoney(1050, "USD")
+
+	if usd.Currency != "usd" {
+		t.Errorf("currency should be lower case, got %q", usd.Currency)
+	}
+
+	sum, err := usd.Add(NewMoney(950, "usd"))
+
+	if err != nil || sum.Amount != 2000 {
+		t.Errorf("wrong sum %v: %v", sum, err)
+	}
+
+	sum, err = Money{}.Add(usd)
+
+	if err != nil || sum != usd {
+		t.Errorf("zero money should take the other currency, got %v: %v", sum, err)
+	}
+
+	diff, err := usd.Sub(NewMoney(1100, "usd"))
+
+	if err != nil || diff.Amount != -50 || diff.String() != "-0.50 USD" {
+		t.Errorf("wrong difference %v: %v", diff, err)
+	}
+
+	if _, err := usd.Add(NewMoney(100, "eur")); err == nil {
+		t.Error("should not mix currencies")
+	}
+
+	if got := usd.Mul(3); got.Amount != 3150 || got.String() != "31.50 USD" {
+		t.Errorf("wrong product %v", got)
+	}
+}
+
+func TestMoneyScale(t *testing.T) {
+	testCases := []struct {
+		desc                   string
+		amount, num, den, want int64
+	}{
+		{desc: "exact", amount: 10000, num: 825, den: 10000, want: 825},
+		{desc: "rounds down", amount: 2064, num: 825, den: 10000, want: 170},
+		{desc: "rounds half up", amount: 1, num: 1, den: 2, want: 1},
+		{desc: "tax included", amount: 1160, num: 1600, den: 11600, want: 160},
+		{desc: "zero", amount: 0, num: 3, den: 7, want: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := NewMoney(tC.amount, "usd").Scale(tC.num, tC.den)
+
+			if got.Amount != tC.want || got.Currency != "usd" {
+				t.Errorf("got %s, want %d", got, tC.want)
+			}
+		})
+	}
+}
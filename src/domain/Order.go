@@ -8,18 +8,76 @@ import (
 
 type Order struct {
 	Model
-	UserID    uuid.UUID      `json:"user_id"`
-	AddressID uuid.UUID      `json:"address_id"`
-	PaymentID string         `json:"payment_id"`
-	Amount    int64          `json:"amount"`
-	Status    string         `json:"status"`
-	Paid      bool           `json:"paid"`
-	Products  []OrderProduct `json:"products"`
+	UserID          uuid.UUID      `json:"user_id"`
+	AddressID       uuid.UUID      `json:"address_id"`
+	PaymentIntentID string         `json:"payment_intent_id"`
+	Amount          Money          `json:"amount"`
+	RefundedAmount  Money          `json:"refunded_amount"`
+	CouponCode      string         `json:"coupon_code,omitempty"`
+	Discount        Money          `json:"discount"`
+	ShippingMethod  string         `json:"shipping_method"`
+	ShippingCost    Money          `json:"shipping_cost"`
+	Tax             OrderTax       `json:"tax"`
+	Status          string         `json:"status"`
+	Paid            bool           `json:"paid"`
+	StockReserved   bool           `json:"stock_reserved"`
+	CouponRedeemed  bool           `json:"coupon_redeemed"`
+	Products        []OrderProduct `json:"products"`
+	StatusHistory   []StatusChange `json:"status_history"`
+	PaymentEvents   []string       `json:"payment_events"`
 }
 
+type StatusChange struct {
+	Status    string `json:"status"`
+	ChangedAt int64  `json:"changed_at"`
+}
+
+// PaymentEvent is the part of a payment provider
+// notification the orders care about.
+type PaymentEvent struct {
+	ID              string
+	Type            string
+	PaymentIntentID string
+	AmountRefunded  Money
+}
+
+// OrderProduct keeps the product data as it was when
+// the order was placed, so the order amount can be explained later.
 type OrderProduct struct {
-	ID       uuid.UUID `json:"product_id"`
-	Quantity uint      `json:"quantity"`
+	ID           uuid.UUID `json:"product_id"`
+	VariantID    uuid.UUID `json:"variant_id"`
+	Quantity     uint      `json:"quantity"`
+	Name         string    `json:"name"`
+	Variant      string    `json:"variant,omitempty"`
+	SKU          string    `json:"sku,omitempty"`
+	Category     string    `json:"category"`
+	UnitPrice    Money     `json:"unit_price"`
+	DiscountRate int64     `json:"discount_rate"`
+	LineTotal    Money     `json:"line_total"`
+}
+
+func (op OrderProduct) Ref() ProductRef {
+	return ProductRef{ProductID: op.ID, VariantID: op.VariantID}
+}
+
+// OrderTax is the tax line of the order. Inclusive taxes are
+// already part of the prices, so they are not added to the amount.
+type OrderTax struct {
+	Name      string `json:"name"`
+	Rate      int64  `json:"rate"` // basis points, 825 is 8.25%
+	Amount    Money  `json:"amount"`
+	Inclusive bool   `json:"inclusive"`
+}
+
+// OrderFilter narrows the order listing. Zero values do not filter.
+type OrderFilter struct {
+	Status string
+	Paid   *bool
+	UserID uuid.UUID
+	From   int64 // created at or after, unix seconds
+	To     int64 // created before, unix seconds
+	Limit  int
+	Cursor string
 }
 
 //* Service
@@ -27,18 +85,42 @@ type OrderProduct struct {
 type OrderService interface {
 	Create(ord *Order) error
 	GetAll() ([]Order, error)
+	GetByID(ID uuid.UUID) (*Order, error)
 	GetAllByUserID(ursID uuid.UUID) ([]Order, error)
+	GetPage(f OrderFilter) (*Page[Order], error)
 	UpdateStatus(ID uuid.UUID, status string) error
 	SetPaidStatus(ID uuid.UUID, paid bool) error
+	SetPaymentIntentID(ID uuid.UUID, piID string) error
+	ReleaseStock(ID uuid.UUID) error
+	Cancel(ordID, usrID uuid.UUID) error
+	RefundableAmount(ID uuid.UUID) (Money, error)
+	// RegisterRefund takes the total refunded by the payment provider
+	RegisterRefund(ID uuid.UUID, refunded Money) error
+	HandlePaymentEvent(evt *PaymentEvent) error
 	Delete(ID uuid.UUID) error
 }
 
+// OrderNotifier tells the customer what happens to their order.
+// The calls must not block, the notifications go in the background.
+// OrderPlaced is sent when the payment is accepted, not before.
+type OrderNotifier interface {
+	OrderPlaced(ord Order)
+	PaymentFailed(ord Order)
+	OrderShipped(ord Order)
+	OrderDelivered(ord Order)
+	OrderRefunded(ord Order, amount Money)
+}
+
 //* Repository
 
 type OrderRepository interface {
 	Save(ord *Order) error
 	Find() ([]Order, error)
+	FindByID(ID uuid.UUID) (*Order, error)
 	FindWhere(field, cond string, val any) ([]Order, error)
+	FindPage(pq PageQuery) (*Page[Order], error)
+	Update(ID uuid.UUID, uf UpdateFields) error
 	UpdateField(ID uuid.UUID, field string, val any) error
+	UpdateWith(ID uuid.UUID, fn func(ord *Order) error) error
 	Remove(ID uuid.UUID) error
 }
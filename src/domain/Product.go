@@ -6,27 +6,162 @@ import (
 
 //* Model
 
+// Product with variants is sold through them. Its Stock is then the
+// sum of the variants stock and SKUs has their skus, so they can be
+// searched with array-contains.
 type Product struct {
 	Model
-	Category     string   `json:"category"`
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Price        int64    `json:"price"`
-	DiscountRate int64    `json:"discount_rate"`
-	ImagesUrl    []string `json:"images_url"`
-	Tags         []string `json:"tags"`
-	Available    bool     `json:"available"`
+	Category     string           `json:"category"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Price        Money            `json:"price"`
+	DiscountRate int64            `json:"discount_rate"`
+	Images       []Image          `json:"images"`
+	Tags         []string         `json:"tags"`
+	Available    bool             `json:"available"`
+	Stock        int64            `json:"stock"`
+	Weight       int64            `json:"weight"` // grams
+	Options      []ProductOption  `json:"options"`
+	Variants     []ProductVariant `json:"variants"`
+	SKUs         []string         `json:"skus"`
+	RatingAvg    float64          `json:"rating_avg"` // of the visible reviews
+	RatingCount  int64            `json:"rating_count"`
+}
+
+// ProductOption is an axis the variants change along, like the size.
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// ProductVariant is a sellable version of the product, with a value
+// for every option. A zero Price or no images take the product ones.
+type ProductVariant struct {
+	ID      uuid.UUID         `json:"id"`
+	SKU     string            `json:"sku"`
+	Options map[string]string `json:"options"`
+	Price   Money             `json:"price"`
+	Stock   int64             `json:"stock"`
+	Images  []Image           `json:"images"`
+}
+
+// Image is an uploaded picture stored in several renditions. The
+// images set by url have a single "original" rendition.
+type Image struct {
+	ID         uuid.UUID        `json:"id"`
+	Renditions []ImageRendition `json:"renditions"`
+}
+
+// ImageRendition is one stored size and format of an image.
+type ImageRendition struct {
+	Name   string `json:"name"`   // thumbnail, medium, large or original
+	Format string `json:"format"` // jpeg, png or webp
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Size   int64  `json:"size"` // bytes
+}
+
+// NewURLImage is an image hosted somewhere else.
+func NewURLImage(url string) Image {
+	return Image{
+		ID:         uuid.New(),
+		Renditions: []ImageRendition{{Name: "original", URL: url}},
+	}
+}
+
+// URL returns the first rendition with that name, or the first
+// one when there is none.
+func (img Image) URL(name string) string {
+	for _, r := range img.Renditions {
+		if r.Name == name {
+			return r.URL
+		}
+	}
+	if len(img.Renditions) > 0 {
+		return img.Renditions[0].URL
+	}
+	return ""
+}
+
+// ProductRef points at a product, or at one of its variants.
+type ProductRef struct {
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+}
+
+func (p Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
+
+func (p Product) Variant(ID uuid.UUID) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].ID == ID {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// VariantPrice is what the variant costs before the discount.
+func (p Product) VariantPrice(v ProductVariant) Money {
+	if v.Price.IsZero() {
+		return p.Price
+	}
+	return v.Price
+}
+
+// VariantLabel names the variant by its option values, "M / Black".
+func (p Product) VariantLabel(v ProductVariant) string {
+	label := ""
+
+	for i, o := range p.Options {
+		if i > 0 {
+			label += " / "
+		}
+		label += v.Options[o.Name]
+	}
+
+	return label
+}
+
+// ProductFilter narrows the product listing. Zero values do not filter.
+type ProductFilter struct {
+	Category  string
+	Tags      []string // any of them
+	MinPrice  int64    // minor units
+	MaxPrice  int64
+	Available *bool
+	SortBy    string // price, name or created_at
+	Desc      bool
+	Limit     int
+	Cursor    string
 }
 
 //* Service
 
 type ProductService interface {
 	ServiceCrudOperations[Product]
-	CalculateTotalPrice(ops []OrderProduct) (int64, error)
+	// CalculateTotalPrice also fills the price snapshot of every line
+	CalculateTotalPrice(ops []OrderProduct) (Money, error)
 	GetLatestProds(lim ...int) ([]Product, error)
 	GetByTags(tags ...string) ([]Product, error)
 	GetByCategory(c string) ([]Product, error)
+	GetPage(f ProductFilter) (*Page[Product], error)
 	SetAvailable(ID uuid.UUID, avl bool) error
+	AddVariant(prodID uuid.UUID, v *ProductVariant) error
+	UpdateVariant(prodID uuid.UUID, v ProductVariant) error
+	RemoveVariant(prodID, varID uuid.UUID) error
+	// AddImages appends the images unless they do not fit
+	AddImages(prodID uuid.UUID, imgs ...Image) error
+	// RemoveImage takes the image out and returns it
+	RemoveImage(prodID, imgID uuid.UUID) (*Image, error)
+}
+
+// ProductIndexer keeps a search index in step with the catalog.
+type ProductIndexer interface {
+	Index(prod Product)
+	Remove(ID uuid.UUID)
 }
 
 //* Repository
@@ -35,5 +170,14 @@ type ProductRepository interface {
 	RepositoryCrudOperations[Product]
 	FindOrderBy(field string, ord string) ([]Product, error)
 	FindWhere(field string, cond string, val any) ([]Product, error)
+	FindPage(pq PageQuery) (*Page[Product], error)
 	UpdateField(ID uuid.UUID, field string, val any) error
+	// AdjustStock changes the stock of products or variants at once
+	AdjustStock(deltas map[ProductRef]int64) error
+	// RestoreStock puts units back, skipping the products and
+	// variants that no longer exist
+	RestoreStock(deltas map[ProductRef]int64) error
+	// UpdateVariants runs fn over the stored product and saves the
+	// options, variants, stock and skus it leaves, all at once.
+	UpdateVariants(ID uuid.UUID, fn func(p *Product) error) error
 }
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// ReturnRequest asks to send back delivered items of an order.
+// The refund is made when a moderator approves it.
+type ReturnRequest struct {
+	Model
+	OrderID        uuid.UUID      `json:"order_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	Items          []ReturnItem   `json:"items"`
+	Reason         string         `json:"reason"`
+	Status         string         `json:"status"`
+	ModeratorNote  string         `json:"moderator_note"`
+	RefundedAmount Money          `json:"refunded_amount"`
+	Restocked      bool           `json:"restocked"`
+	StatusHistory  []StatusChange `json:"status_history"`
+}
+
+type ReturnItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	VariantID uuid.UUID `json:"variant_id"`
+	Quantity  uint      `json:"quantity"`
+}
+
+func (it ReturnItem) Ref() ProductRef {
+	return ProductRef{ProductID: it.ProductID, VariantID: it.VariantID}
+}
+
+//* Service
+
+type ReturnService interface {
+	Create(ret *ReturnRequest) error
+	GetAll() ([]ReturnRequest, error)
+	GetByID(ID uuid.UUID) (*ReturnRequest, error)
+	GetAllByUserID(usrID uuid.UUID) ([]ReturnRequest, error)
+	// StartApproval claims a requested return before its refund is made,
+	// CancelApproval gives it back if the refund could not be made
+	StartApproval(ID uuid.UUID) error
+	CancelApproval(ID uuid.UUID) error
+	// RefundAmount is the part of the order payment that pays for the items
+	RefundAmount(ID uuid.UUID) (Money, error)
+	Approve(ID uuid.UUID, note string, refunded Money) error
+	Reject(ID uuid.UUID, note string) error
+	Receive(ID uuid.UUID, restock bool) error
+}
+
+//* Repository
+
+type ReturnRepository interface {
+	RepositoryCrudOperations[ReturnRequest]
+	FindWhere(fld, cond string, val any) ([]ReturnRequest, error)
+}
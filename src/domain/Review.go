@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// Review is the opinion of a customer on a product they paid for
+// (verified purchase). The hidden reviews are kept but do not count for the rating.
+type Review struct {
+	Model
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Author    string    `json:"author"`
+	Rating    int64     `json:"rating"` // 1 to 5
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Hidden    bool      `json:"hidden"`
+}
+
+//* Service
+
+// ReviewService keeps the rating of the products in step with
+// their visible reviews.
+type ReviewService interface {
+	Create(rev *Review) error
+	GetByID(ID uuid.UUID) (*Review, error)
+	GetAllByProductID(prodID uuid.UUID, withHidden bool) ([]Review, error)
+	SetHidden(ID uuid.UUID, hidden bool) error
+	Delete(ID uuid.UUID) error
+}
+
+//* Repository
+
+type ReviewRepository interface {
+	RepositoryCrudOperations[Review]
+	FindWhere(fld, cond string, val any) ([]Review, error)
+}
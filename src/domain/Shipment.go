@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// Shipment is a package sent for an order. An order can be sent in
+// several shipments, each one with some of its items.
+type Shipment struct {
+	Model
+	OrderID        uuid.UUID      `json:"order_id"`
+	Carrier        string         `json:"carrier"`
+	TrackingNumber string         `json:"tracking_number"`
+	Items          []ShipmentItem `json:"items"`
+	ShippedAt      int64          `json:"shipped_at"`
+	DeliveredAt    int64          `json:"delivered_at"` // zero until delivered
+}
+
+type ShipmentItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	VariantID uuid.UUID `json:"variant_id"`
+	Quantity  uint      `json:"quantity"`
+}
+
+func (it ShipmentItem) Ref() ProductRef {
+	return ProductRef{ProductID: it.ProductID, VariantID: it.VariantID}
+}
+
+//* Service
+
+// ShipmentService moves the order to shipped once every item has left
+// and to delivered once every shipment has arrived.
+type ShipmentService interface {
+	ServiceCrudOperations[Shipment]
+	GetAllByOrderID(ordID uuid.UUID) ([]Shipment, error)
+}
+
+//* Repository
+
+type ShipmentRepository interface {
+	RepositoryCrudOperations[Shipment]
+	FindWhere(fld, cond string, val any) ([]Shipment, error)
+}
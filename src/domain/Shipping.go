@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+//* Model
+
+// ShippingMethod prices the shipping of an order with its rate table.
+// Flat methods charge the same for any weight; weight methods pick the
+// smallest bracket the order fits in.
+type ShippingMethod struct {
+	Model
+	Code   string         `json:"code"`
+	Name   string         `json:"name"`
+	Type   string         `json:"type"`
+	Rates  []ShippingRate `json:"rates"`
+	Active bool           `json:"active"`
+}
+
+// ShippingRate is a row of a rate table. An empty Country or State
+// matches any destination and a zero MaxWeight (grams) any weight.
+// The most specific destination wins.
+type ShippingRate struct {
+	Country   string `json:"country"`
+	State     string `json:"state"`
+	MaxWeight int64  `json:"max_weight"`
+	Price     Money  `json:"price"`
+}
+
+type ShippingQuote struct {
+	Method string `json:"method"`
+	Name   string `json:"name"`
+	Cost   Money  `json:"cost"`
+}
+
+//* Service
+
+type ShippingService interface {
+	ServiceCrudOperations[ShippingMethod]
+	GetByCode(code string) (*ShippingMethod, error)
+	// Quote prices every active method that ships to the address
+	Quote(addrID uuid.UUID, ops []OrderProduct) ([]ShippingQuote, error)
+	QuoteMethod(code string, addrID uuid.UUID, ops []OrderProduct) (Money, error)
+}
+
+//* Repository
+
+type ShippingRepository interface {
+	RepositoryCrudOperations[ShippingMethod]
+	FindByField(fld string, val any) (*ShippingMethod, error)
+}
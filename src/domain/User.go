@@ -15,6 +15,7 @@ type User struct {
 	Password      string `json:"password,omitempty"`
 	VerifiedEmail bool   `json:"verified_email"`
 	ImageUrl      string `json:"image_url"`
+	Avatar        *Image `json:"avatar,omitempty"` // the uploaded avatar, if any
 	Age           uint16 `json:"age"`
 }
 
@@ -26,6 +27,8 @@ type UserService interface {
 	GetByCredentials(email, pass string) (*User, error)
 	VerifyEmail(ID uuid.UUID) error
 	UpdatePassword(ID uuid.UUID, pass string) error
+	// SetAvatar sets the uploaded avatar and returns the previous one
+	SetAvatar(ID uuid.UUID, img Image) (*Image, error)
 }
 
 //* Repository
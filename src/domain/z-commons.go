@@ -19,13 +19,15 @@ type RepositoryCrudOperations[T DomainModel] interface {
 	Find() ([]T, error)
 	FindByID(ID uuid.UUID) (*T, error)
 	Update(ID uuid.UUID, uf UpdateFields) error
+	// UpdateWith changes the model with fn atomically
+	UpdateWith(ID uuid.UUID, fn func(m *T) error) error
 	Remove(ID uuid.UUID) error
 }
 
 // ---------------------------------------------------------------
 
 type DomainModel interface {
-	User | Address | Category | Product | Order | ExampleModel
+	User | Address | Category | Product | Order | Cart | Coupon | IdempotencyRecord | ShippingMethod | Shipment | ReturnRequest | Invoice | Review | ExampleModel
 
 	GetStringID() string
 	GetCreatedDate() int64
@@ -47,6 +49,31 @@ func (m Model) GetCreatedDate() int64 {
 
 type UpdateFields map[string]interface{}
 
+// Filter is a condition of a listing. Cond takes the firestore operators.
+type Filter struct {
+	Field string
+	Cond  string
+	Value any
+}
+
+// PageQuery asks for a page of a listing sorted by OrderBy. Cursor
+// is the Next of the previous page, empty for the first one.
+type PageQuery struct {
+	Filters []Filter
+	OrderBy string
+	Desc    bool
+	Limit   int
+	Cursor  string
+}
+
+// Page is a part of a listing. Total counts every match
+// and Next is empty on the last page.
+type Page[T DomainModel] struct {
+	Items []T    `json:"items"`
+	Total int    `json:"total"`
+	Next  string `json:"next"`
+}
+
 type ExampleModel struct {
 	Model
 	Name  string   `json:"name"`
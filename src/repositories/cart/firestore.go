@@ -0,0 +1,28 @@
+package cart
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreCartRepo struct {
+	shared.FirestoreRepo[domain.Cart]
+}
+
+//* Constructor
+
+func NewFirestoreCartRepository(
+	client *firestore.Client,
+	collName string,
+) domain.CartRepository {
+	return &firestoreCartRepo{
+		shared.FirestoreRepo[domain.Cart]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "cart",
+		},
+	}
+}
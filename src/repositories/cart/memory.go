@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryCartRepo struct {
+	shared.MemoryRepo[domain.Cart]
+}
+
+//* Constructor
+
+func NewMemoryCartRepository(im ...domain.Cart) domain.CartRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Cart]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryCartRepo{
+		shared.MemoryRepo[domain.Cart]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentCartRepository(filename string) domain.CartRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Cart](filename)
+
+	return &memoryCartRepo{
+		shared.MemoryRepo[domain.Cart]{
+			Store: store,
+		},
+	}
+}
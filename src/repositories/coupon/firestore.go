@@ -0,0 +1,28 @@
+package coupon
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreCouponRepo struct {
+	shared.FirestoreRepo[domain.Coupon]
+}
+
+//* Constructor
+
+func NewFirestoreCouponRepository(
+	client *firestore.Client,
+	collName string,
+) domain.CouponRepository {
+	return &firestoreCouponRepo{
+		shared.FirestoreRepo[domain.Coupon]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "coupon",
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package coupon
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryCouponRepo struct {
+	shared.MemoryRepo[domain.Coupon]
+}
+
+//* Constructor
+
+func NewMemoryCouponRepository(im ...domain.Coupon) domain.CouponRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Coupon]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryCouponRepo{
+		shared.MemoryRepo[domain.Coupon]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentCouponRepository(filename string) domain.CouponRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Coupon](filename)
+
+	return &memoryCouponRepo{
+		shared.MemoryRepo[domain.Coupon]{
+			Store: store,
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreIdempotencyRepo struct {
+	shared.FirestoreRepo[domain.IdempotencyRecord]
+}
+
+//* Constructor
+
+func NewFirestoreIdempotencyRepository(
+	client *firestore.Client,
+	collName string,
+) domain.IdempotencyRepository {
+	return &firestoreIdempotencyRepo{
+		shared.FirestoreRepo[domain.IdempotencyRecord]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "idempotency record",
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryIdempotencyRepo struct {
+	shared.MemoryRepo[domain.IdempotencyRecord]
+}
+
+//* Constructor
+
+func NewMemoryIdempotencyRepository(im ...domain.IdempotencyRecord) domain.IdempotencyRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.IdempotencyRecord]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryIdempotencyRepo{
+		shared.MemoryRepo[domain.IdempotencyRecord]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentIdempotencyRepository(filename string) domain.IdempotencyRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.IdempotencyRecord](filename)
+
+	return &memoryIdempotencyRepo{
+		shared.MemoryRepo[domain.IdempotencyRecord]{
+			Store: store,
+		},
+	}
+}
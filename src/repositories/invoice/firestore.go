@@ -0,0 +1,59 @@
+package invoice
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreInvoiceRepo struct {
+	shared.FirestoreRepo[domain.Invoice]
+}
+
+//* Constructor
+
+func NewFirestoreInvoiceRepository(
+	client *firestore.Client,
+	collName string,
+) domain.InvoiceRepository {
+	return &firestoreInvoiceRepo{
+		shared.FirestoreRepo[domain.Invoice]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "invoice",
+		},
+	}
+}
+
+func (r *firestoreInvoiceRepo) SaveNext(inv *domain.Invoice) error {
+	coll := r.Client.Collection(r.CollName)
+
+	return r.Client.RunTransaction(context.TODO(), func(ctx context.Context, tx *firestore.Transaction) error {
+		ss, err := tx.Documents(coll.OrderBy("Number", firestore.Desc).Limit(1)).GetAll()
+
+		if err != nil {
+			return fmt.Errorf("tx.Documents(): %w", err)
+		}
+
+		var last domain.Invoice
+
+		if len(ss) > 0 {
+			if err := ss[0].DataTo(&last); err != nil {
+				return fmt.Errorf("snapshot.DataTo(): %w", err)
+			}
+		}
+
+		inv.Number = last.Number + 1
+
+		if err := tx.Create(coll.Doc(inv.GetStringID()), inv); err != nil {
+			return fmt.Errorf("tx.Create(): %w", err)
+		}
+
+		return nil
+	})
+}
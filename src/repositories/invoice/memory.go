@@ -0,0 +1,69 @@
+package invoice
+
+import (
+	"log"
+	"sync"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryInvoiceRepo struct {
+	shared.MemoryRepo[domain.Invoice]
+	mu sync.Mutex
+}
+
+//* Constructor
+
+func NewMemoryInvoiceRepository(im ...domain.Invoice) domain.InvoiceRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Invoice]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryInvoiceRepo{
+		MemoryRepo: shared.MemoryRepo[domain.Invoice]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentInvoiceRepository(filename string) domain.InvoiceRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Invoice](filename)
+
+	return &memoryInvoiceRepo{
+		MemoryRepo: shared.MemoryRepo[domain.Invoice]{
+			Store: store,
+		},
+	}
+}
+
+func (r *memoryInvoiceRepo) SaveNext(inv *domain.Invoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invs, err := r.Store.GetAll()
+
+	if err != nil {
+		return err
+	}
+
+	var last int64
+
+	for _, i := range invs {
+		if i.Number > last {
+			last = i.Number
+		}
+	}
+
+	inv.Number = last + 1
+
+	return r.Save(inv)
+}
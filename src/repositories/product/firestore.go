@@ -2,11 +2,17 @@ package product
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //* Implementation
@@ -62,3 +68,128 @@ func (r *firestoreProductRepo) FindOrderBy(field string, ord string) ([]domain.P
 
 	return ps, nil
 }
+
+func (r *firestoreProductRepo) AdjustStock(deltas map[domain.ProductRef]int64) error {
+	coll := r.Client.Collection(r.CollName)
+
+	return r.Client.RunTransaction(context.TODO(), func(ctx context.Context, tx *firestore.Transaction) error {
+		grouped := byProduct(deltas)
+		prods := make(map[uuid.UUID]domain.Product, len(grouped))
+
+		//* All reads must happen before any write in a transaction
+		for ID, ds := range grouped {
+			p, err := r.txGet(tx, ID)
+
+			if err != nil {
+				return err
+			}
+
+			if err := applyStock(&p, ds); err != nil {
+				return err
+			}
+
+			prods[ID] = p
+		}
+
+		for ID, p := range prods {
+			err := tx.Update(coll.Doc(ID.String()), []firestore.Update{
+				{Path: "Stock", Value: p.Stock},
+				{Path: "Variants", Value: p.Variants},
+				{Path: "UpdatedAt", Value: time.Now().Unix()},
+			})
+
+			if err != nil {
+				return fmt.Errorf("tx.Update(): %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *firestoreProductRepo) RestoreStock(deltas map[domain.ProductRef]int64) error {
+	coll := r.Client.Collection(r.CollName)
+
+	return r.Client.RunTransaction(context.TODO(), func(ctx context.Context, tx *firestore.Transaction) error {
+		grouped := byProduct(deltas)
+		prods := make(map[uuid.UUID]domain.Product, len(grouped))
+
+		for ID, ds := range grouped {
+			p, err := r.txGet(tx, ID)
+
+			if errors.Is(err, utils.ErrNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			restoreStock(&p, ds)
+			prods[ID] = p
+		}
+
+		for ID, p := range prods {
+			err := tx.Update(coll.Doc(ID.String()), []firestore.Update{
+				{Path: "Stock", Value: p.Stock},
+				{Path: "Variants", Value: p.Variants},
+				{Path: "UpdatedAt", Value: time.Now().Unix()},
+			})
+
+			if err != nil {
+				return fmt.Errorf("tx.Update(): %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *firestoreProductRepo) UpdateVariants(ID uuid.UUID, fn func(p *domain.Product) error) error {
+	return r.Client.RunTransaction(context.TODO(), func(ctx context.Context, tx *firestore.Transaction) error {
+		p, err := r.txGet(tx, ID)
+
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&p); err != nil {
+			return err
+		}
+
+		syncVariants(&p)
+
+		err = tx.Update(r.Client.Collection(r.CollName).Doc(ID.String()), []firestore.Update{
+			{Path: "Options", Value: p.Options},
+			{Path: "Variants", Value: p.Variants},
+			{Path: "Stock", Value: p.Stock},
+			{Path: "SKUs", Value: p.SKUs},
+			{Path: "UpdatedAt", Value: time.Now().Unix()},
+		})
+
+		if err != nil {
+			return fmt.Errorf("tx.Update(): %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *firestoreProductRepo) txGet(tx *firestore.Transaction, ID uuid.UUID) (domain.Product, error) {
+	var p domain.Product
+
+	s, err := tx.Get(r.Client.Collection(r.CollName).Doc(ID.String()))
+
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return p, fmt.Errorf("%w: product %s", utils.ErrNotFound, ID)
+		}
+		return p, fmt.Errorf("tx.Get(): %w", err)
+	}
+
+	if err := s.DataTo(&p); err != nil {
+		return p, fmt.Errorf("snapshot.DataTo(): %w", err)
+	}
+
+	return p, nil
+}
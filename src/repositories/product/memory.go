@@ -2,6 +2,7 @@ package product
 
 import (
 	"log"
+	"time"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/shared"
@@ -42,3 +43,60 @@ func NewMemoryPersistentProductRepository(filename string) domain.ProductReposit
 		},
 	}
 }
+
+func (r *memoryProductRepo) AdjustStock(deltas map[domain.ProductRef]int64) error {
+	grouped := byProduct(deltas)
+	fns := make(map[uuid.UUID]func(domain.Product) (domain.Product, error), len(grouped))
+
+	for ID, ds := range grouped {
+		ds := ds
+
+		fns[ID] = func(p domain.Product) (domain.Product, error) {
+			if err := applyStock(&p, ds); err != nil {
+				return p, err
+			}
+
+			p.UpdatedAt = time.Now().Unix()
+
+			return p, nil
+		}
+	}
+
+	return r.Store.UpdateMany(fns)
+}
+
+func (r *memoryProductRepo) RestoreStock(deltas map[domain.ProductRef]int64) error {
+	grouped := byProduct(deltas)
+	fns := make(map[uuid.UUID]func(domain.Product) (domain.Product, error), len(grouped))
+
+	for ID, ds := range grouped {
+		ds := ds
+
+		fns[ID] = func(p domain.Product) (domain.Product, error) {
+			restoreStock(&p, ds)
+			p.UpdatedAt = time.Now().Unix()
+
+			return p, nil
+		}
+	}
+
+	return r.Store.UpdateExisting(fns)
+}
+
+func (r *memoryProductRepo) UpdateVariants(ID uuid.UUID, fn func(p *domain.Product) error) error {
+	return r.Store.UpdateMany(map[uuid.UUID]func(domain.Product) (domain.Product, error){
+		ID: func(p domain.Product) (domain.Product, error) {
+			p.Options = append([]domain.ProductOption(nil), p.Options...)
+			p.Variants = cloneVariants(p.Variants)
+
+			if err := fn(&p); err != nil {
+				return p, err
+			}
+
+			syncVariants(&p)
+			p.UpdatedAt = time.Now().Unix()
+
+			return p, nil
+		},
+	})
+}
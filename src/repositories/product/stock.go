@@ -0,0 +1,120 @@
+package product
+
+import (
+	"fmt"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+// byProduct groups the stock deltas by product.
+func byProduct(deltas map[domain.ProductRef]int64) map[uuid.UUID]map[uuid.UUID]int64 {
+	grouped := make(map[uuid.UUID]map[uuid.UUID]int64)
+
+	for ref, delta := range deltas {
+		if grouped[ref.ProductID] == nil {
+			grouped[ref.ProductID] = make(map[uuid.UUID]int64)
+		}
+		grouped[ref.ProductID][ref.VariantID] += delta
+	}
+
+	return grouped
+}
+
+// applyStock changes the stock of the product or its variants. The
+// products with variants only change through them.
+func applyStock(p *domain.Product, deltas map[uuid.UUID]int64) error {
+	p.Variants = cloneVariants(p.Variants)
+
+	for varID, delta := range deltas {
+		if varID == uuid.Nil {
+			if p.HasVariants() {
+				return fmt.Errorf("%w: choose a variant of %q", utils.ErrOutOfStock, p.Name)
+			}
+
+			if p.Stock+delta < 0 {
+				return fmt.Errorf("%w: %q only has %d units", utils.ErrOutOfStock, p.Name, p.Stock)
+			}
+
+			p.Stock += delta
+			continue
+		}
+
+		v := p.Variant(varID)
+
+		if v == nil {
+			return fmt.Errorf("%w: %q has no variant %s", utils.ErrOutOfStock, p.Name, varID)
+		}
+
+		if v.Stock+delta < 0 {
+			return fmt.Errorf("%w: %q %s only has %d units", utils.ErrOutOfStock, p.Name, p.VariantLabel(*v), v.Stock)
+		}
+
+		v.Stock += delta
+	}
+
+	syncVariants(p)
+
+	return nil
+}
+
+// restoreStock puts units back. The variants that no longer exist and
+// the units of a product that has variants now are skipped, there is
+// nowhere to put them.
+func restoreStock(p *domain.Product, deltas map[uuid.UUID]int64) {
+	p.Variants = cloneVariants(p.Variants)
+
+	for varID, delta := range deltas {
+		if varID == uuid.Nil {
+			if !p.HasVariants() {
+				p.Stock += delta
+			}
+			continue
+		}
+
+		if v := p.Variant(varID); v != nil {
+			v.Stock += delta
+		}
+	}
+
+	syncVariants(p)
+}
+
+// syncVariants keeps the stock and the skus of a product with variants.
+func syncVariants(p *domain.Product) {
+	if !p.HasVariants() {
+		p.SKUs = []string{}
+		return
+	}
+
+	p.Stock = 0
+	p.SKUs = make([]string, len(p.Variants))
+
+	for i, v := range p.Variants {
+		p.Stock += v.Stock
+		p.SKUs[i] = v.SKU
+	}
+}
+
+// cloneVariants copies the variants, so changing them does not
+// touch the product that is stored.
+func cloneVariants(vs []domain.ProductVariant) []domain.ProductVariant {
+	if vs == nil {
+		return nil
+	}
+
+	cp := make([]domain.ProductVariant, len(vs))
+
+	for i, v := range vs {
+		cp[i] = v
+		cp[i].Images = append([]domain.Image(nil), v.Images...)
+		cp[i].Options = make(map[string]string, len(v.Options))
+
+		for k, val := range v.Options {
+			cp[i].Options[k] = val
+		}
+	}
+
+	return cp
+}
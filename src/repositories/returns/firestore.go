@@ -0,0 +1,28 @@
+package returns
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreReturnRepo struct {
+	shared.FirestoreRepo[domain.ReturnRequest]
+}
+
+//* Constructor
+
+func NewFirestoreReturnRepository(
+	client *firestore.Client,
+	collName string,
+) domain.ReturnRepository {
+	return &firestoreReturnRepo{
+		shared.FirestoreRepo[domain.ReturnRequest]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "return request",
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package returns
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryReturnRepo struct {
+	shared.MemoryRepo[domain.ReturnRequest]
+}
+
+//* Constructor
+
+func NewMemoryReturnRepository(im ...domain.ReturnRequest) domain.ReturnRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.ReturnRequest]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryReturnRepo{
+		shared.MemoryRepo[domain.ReturnRequest]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentReturnRepository(filename string) domain.ReturnRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.ReturnRequest](filename)
+
+	return &memoryReturnRepo{
+		shared.MemoryRepo[domain.ReturnRequest]{
+			Store: store,
+		},
+	}
+}
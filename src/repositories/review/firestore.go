@@ -0,0 +1,28 @@
+package review
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreReviewRepo struct {
+	shared.FirestoreRepo[domain.Review]
+}
+
+//* Constructor
+
+func NewFirestoreReviewRepository(
+	client *firestore.Client,
+	collName string,
+) domain.ReviewRepository {
+	return &firestoreReviewRepo{
+		shared.FirestoreRepo[domain.Review]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "review",
+		},
+	}
+}
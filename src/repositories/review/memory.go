@@ -0,0 +1,44 @@
+package review
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryReviewRepo struct {
+	shared.MemoryRepo[domain.Review]
+}
+
+//* Constructor
+
+func NewMemoryReviewRepository(im ...domain.Review) domain.ReviewRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Review]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryReviewRepo{
+		shared.MemoryRepo[domain.Review]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentReviewRepository(filename string) domain.ReviewRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Review](filename)
+
+	return &memoryReviewRepo{
+		shared.MemoryRepo[domain.Review]{
+			Store: store,
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/google/uuid"
+	firestorepb "google.golang.org/genproto/googleapis/firestore/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -171,6 +172,79 @@ func (r *FirestoreRepo[T]) FindWhere(fld, cond string, val interface{}) ([]T, er
 	return ms, nil
 }
 
+// FindPage sorts by the OrderBy field and then by document id. Filters
+// with ranges need OrderBy to be that field and a composite index.
+func (r *FirestoreRepo[T]) FindPage(pq domain.PageQuery) (*domain.Page[T], error) {
+	if pq.Limit <= 0 {
+		return nil, fmt.Errorf("the page limit must be positive")
+	}
+
+	coll := r.Client.Collection(r.CollName)
+	q := coll.Query
+
+	for _, f := range pq.Filters {
+		q = q.Where(f.Field, f.Cond, f.Value)
+	}
+
+	//* Counted by the server, the documents are not read
+	res, err := q.NewAggregationQuery().WithCount("total").Get(context.TODO())
+
+	if err != nil {
+		return nil, fmt.Errorf("error counting %s documents: %w", r.ModelName, err)
+	}
+
+	total, ok := res["total"].(*firestorepb.Value)
+
+	if !ok {
+		return nil, fmt.Errorf("error counting %s documents: no count in the result", r.ModelName)
+	}
+
+	dir := firestore.Asc
+
+	if pq.Desc {
+		dir = firestore.Desc
+	}
+
+	if pq.OrderBy != "" {
+		q = q.OrderBy(pq.OrderBy, dir)
+	}
+
+	q = q.OrderBy(firestore.DocumentID, dir)
+
+	if pq.Cursor != "" {
+		cur, err := coll.Doc(pq.Cursor).Get(context.TODO())
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+
+		q = q.StartAfter(cur)
+	}
+
+	ss, err := q.Limit(pq.Limit + 1).Documents(context.TODO()).GetAll()
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s documents: %w", r.ModelName, err)
+	}
+
+	page := &domain.Page[T]{Total: int(total.GetIntegerValue())}
+
+	if len(ss) > pq.Limit {
+		ss = ss[:pq.Limit]
+		page.Next = ss[len(ss)-1].Ref.ID
+	}
+
+	page.Items = make([]T, len(ss))
+
+	for i, s := range ss {
+		if err := s.DataTo(&page.Items[i]); err != nil {
+			return nil, fmt.Errorf("snapshot.DataTo(): %w", err)
+		}
+	}
+
+	return page, nil
+}
+
 func (r *FirestoreRepo[T]) Update(ID uuid.UUID, uf domain.UpdateFields) error {
 	if uf == nil {
 		return fmt.Errorf("cannot accept nil value")
@@ -222,6 +296,43 @@ func (r *FirestoreRepo[T]) UpdateField(ID uuid.UUID, fld string, val any) error
 	return nil
 }
 
+// UpdateWith saves what fn leaves in the model in a transaction, fn
+// runs again when the model changes in between.
+func (r *FirestoreRepo[T]) UpdateWith(ID uuid.UUID, fn func(m *T) error) error {
+	ref := r.Client.Collection(r.CollName).Doc(ID.String())
+
+	return r.Client.RunTransaction(context.TODO(), func(ctx context.Context, tx *firestore.Transaction) error {
+		s, err := tx.Get(ref)
+
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("%w: %s %s", utils.ErrNotFound, r.ModelName, ID)
+			}
+			return fmt.Errorf("tx.Get(): %w", err)
+		}
+
+		var m T
+
+		if err := s.DataTo(&m); err != nil {
+			return fmt.Errorf("snapshot.DataTo(): %w", err)
+		}
+
+		if err := fn(&m); err != nil {
+			return err
+		}
+
+		if err := utils.SetStructField(&m, "UpdatedAt", time.Now().Unix()); err != nil {
+			return err
+		}
+
+		if err := tx.Set(ref, m); err != nil {
+			return fmt.Errorf("tx.Set(): %w", err)
+		}
+
+		return nil
+	})
+}
+
 func (r *FirestoreRepo[T]) Remove(ID uuid.UUID) error {
 	m, err := r.FindByID(ID)
 
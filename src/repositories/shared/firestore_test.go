@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -372,6 +373,65 @@ func (s *FirestoreRepoSuite) TestFirestoreRepo_UpdateField() {
 	}
 }
 
+func (s *FirestoreRepoSuite) TestFirestoreRepo_UpdateWith() {
+	failure := errors.New("do not save")
+
+	testCases := []struct {
+		desc     string
+		id       uuid.UUID
+		fn       func(m *domain.ExampleModel) error
+		wantErr  error
+		wantName string
+	}{
+		{
+			desc: "proper work",
+			id:   m1.ID,
+			fn: func(m *domain.ExampleModel) error {
+				m.Name = "updated with"
+				return nil
+			},
+			wantName: "updated with",
+		},
+		{
+			desc: "fn fails",
+			id:   m2.ID,
+			fn: func(m *domain.ExampleModel) error {
+				m.Name = "not saved"
+				return failure
+			},
+			wantErr:  failure,
+			wantName: m2.Name,
+		},
+		{
+			desc:    "model that doest't exist",
+			id:      uuid.New(),
+			fn:      func(m *domain.ExampleModel) error { return nil },
+			wantErr: utils.ErrNotFound,
+		},
+	}
+	for i, tC := range testCases {
+		tC = testCases[i]
+		s.Run(tC.desc, func() {
+			err := s.repo.UpdateWith(tC.id, tC.fn)
+
+			if tC.wantErr != nil {
+				s.Require().ErrorIs(err, tC.wantErr, "expect error fail")
+			} else {
+				s.Require().NoError(err, "expect error fail")
+			}
+
+			if tC.wantName == "" {
+				return
+			}
+
+			m, err := s.repo.FindByID(tC.id)
+
+			s.Require().NoError(err)
+			s.Equal(tC.wantName, m.Name, "wrong name")
+		})
+	}
+}
+
 func (s *FirestoreRepoSuite) TestFirestoreRepo_Remove() {
 	testCases := []struct {
 		desc    string
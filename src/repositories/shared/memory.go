@@ -3,8 +3,9 @@ package shared
 import (
 	"errors"
 	"fmt"
-	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/utils"
@@ -87,6 +88,7 @@ func (r *MemoryRepo[T]) FindByField(fld string, val any) (*T, error) {
 	return model, nil
 }
 
+// FindWhere takes the same conditions as firestore.
 func (r *MemoryRepo[T]) FindWhere(fld, cond string, val any) ([]T, error) {
 	mdls, err := r.Store.GetAll()
 
@@ -97,24 +99,13 @@ func (r *MemoryRepo[T]) FindWhere(fld, cond string, val any) ([]T, error) {
 	ms := []T{}
 
 	for _, mdl := range mdls {
-		fv, err := utils.GetStructField(mdl, fld)
+		ok, err := matchFilters(mdl, []domain.Filter{{Field: fld, Cond: cond, Value: val}})
 
 		if err != nil {
 			return nil, err
 		}
 
-		var statement bool
-
-		switch cond {
-		case "==":
-			statement = reflect.DeepEqual(fv, val)
-		case "!=":
-			statement = !reflect.DeepEqual(fv, val)
-		default:
-			return nil, fmt.Errorf("invalid condition")
-		}
-
-		if statement {
+		if ok {
 			ms = append(ms, mdl)
 		}
 	}
@@ -123,28 +114,121 @@ func (r *MemoryRepo[T]) FindWhere(fld, cond string, val any) ([]T, error) {
 }
 
 func (r *MemoryRepo[T]) FindOrderBy(field string, ord string) ([]T, error) {
-	ps, err := r.Store.GetAll()
-
-	if err != nil {
-		return nil, err
-	}
+	pq := domain.PageQuery{OrderBy: field}
 
 	switch ord {
 	case "ASC":
-		sort.Slice(ps, func(i, j int) bool {
-			return ps[i].GetCreatedDate() < ps[j].GetCreatedDate()
-		})
 	case "DESC":
-		sort.Slice(ps, func(i, j int) bool {
-			return ps[i].GetCreatedDate() > ps[j].GetCreatedDate()
-		})
+		pq.Desc = true
 	default:
 		return nil, fmt.Errorf("invalid order method. use 'ASC' or 'DESC'")
 	}
 
+	ps, err := r.Store.GetAll()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var sortErr error
+
+	sort.SliceStable(ps, func(i, j int) bool {
+		c, err := comparePosition(ps[i], ps[j], pq)
+
+		if err != nil {
+			sortErr = err
+		}
+
+		return c < 0
+	})
+
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
 	return ps, nil
 }
 
+// FindPage sorts by the OrderBy field and then by ID, so the
+// cursor model marks the same place even if it was updated.
+func (r *MemoryRepo[T]) FindPage(pq domain.PageQuery) (*domain.Page[T], error) {
+	if pq.Limit <= 0 {
+		return nil, fmt.Errorf("the page limit must be positive")
+	}
+
+	mdls, err := r.Store.GetAll()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ms := []T{}
+
+	for _, mdl := range mdls {
+		ok, err := matchFilters(mdl, pq.Filters)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			ms = append(ms, mdl)
+		}
+	}
+
+	var sortErr error
+
+	sort.SliceStable(ms, func(i, j int) bool {
+		c, err := comparePosition(ms[i], ms[j], pq)
+
+		if err != nil {
+			sortErr = err
+		}
+
+		return c < 0
+	})
+
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	start := 0
+
+	if pq.Cursor != "" {
+		ID, err := uuid.Parse(pq.Cursor)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+
+		cur, err := r.Store.Get(ID)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+
+		for start < len(ms) {
+			if c, _ := comparePosition(ms[start], cur, pq); c > 0 {
+				break
+			}
+			start++
+		}
+	}
+
+	end := start + pq.Limit
+	page := &domain.Page[T]{Total: len(ms)}
+
+	if end < len(ms) {
+		page.Next = ms[end-1].GetStringID()
+	} else {
+		end = len(ms)
+	}
+
+	page.Items = ms[start:end]
+
+	return page, nil
+}
+
 func (r *MemoryRepo[T]) Update(ID uuid.UUID, uf domain.UpdateFields) error {
 	if uf == nil {
 		return fmt.Errorf("you cant insert nil")
@@ -185,6 +269,20 @@ func (r *MemoryRepo[T]) UpdateField(ID uuid.UUID, fld string, val any) error {
 	return r.Store.Update(ID, *m)
 }
 
+// UpdateWith saves what fn leaves in the model, under the store lock
+// so nothing changes it in between. Nothing is saved when fn fails.
+func (r *MemoryRepo[T]) UpdateWith(ID uuid.UUID, fn func(m *T) error) error {
+	return r.Store.UpdateMany(map[uuid.UUID]func(T) (T, error){
+		ID: func(m T) (T, error) {
+			if err := fn(&m); err != nil {
+				return m, err
+			}
+
+			return m, utils.SetStructField(&m, "UpdatedAt", time.Now().Unix())
+		},
+	})
+}
+
 func (r *MemoryRepo[T]) Remove(ID uuid.UUID) error {
 	return r.Store.Remove(ID)
 }
@@ -193,3 +291,55 @@ func (r *MemoryRepo[T]) clear() error {
 	r.Store.Clear()
 	return nil
 }
+
+// Helper functions
+
+func matchFilters[T domain.DomainModel](mdl T, fs []domain.Filter) (bool, error) {
+	for _, f := range fs {
+		fv, err := fieldValue(mdl, f.Field)
+
+		if err != nil {
+			return false, err
+		}
+
+		ok, err := matchCondition(fv, f.Cond, f.Value)
+
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func comparePosition[T domain.DomainModel](a, b T, pq domain.PageQuery) (int, error) {
+	c := 0
+
+	if pq.OrderBy != "" {
+		fa, err := fieldValue(a, pq.OrderBy)
+
+		if err != nil {
+			return 0, err
+		}
+
+		fb, err := fieldValue(b, pq.OrderBy)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if c, err = compareValues(fa, fb); err != nil {
+			return 0, err
+		}
+	}
+
+	if c == 0 {
+		c = strings.Compare(a.GetStringID(), b.GetStringID())
+	}
+
+	if pq.Desc {
+		c = -c
+	}
+
+	return c, nil
+}
@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -41,7 +42,7 @@ func (s *MemoryRepoSuite) TearDownTest() {
 }
 
 func (s *MemoryRepoSuite) BeforeTest(suiteName, testName string) {
-	if testName == "TestMemoryRepo_Remove" {
+	if testName == "TestMemoryRepo_Remove" || testName == "TestMemoryRepo_FindPage" {
 		s.NoError(s.repo.Save(m3), "error creating model 3")
 	}
 }
@@ -258,6 +259,156 @@ func (s *MemoryRepoSuite) TestMemoryRepo_FindWhere() {
 	}
 }
 
+func (s *MemoryRepoSuite) TestMemoryRepo_FindWhereOperators() {
+	testCases := []struct {
+		desc    string
+		field   string
+		cond    string
+		val     any
+		wantErr bool
+		wantIDs []uuid.UUID
+	}{
+		{
+			desc:    "equal numbers of other type",
+			field:   "Num",
+			cond:    "==",
+			val:     int64(143),
+			wantIDs: []uuid.UUID{m1.ID, m2.ID},
+		},
+		{
+			desc:    "not equal",
+			field:   "Name",
+			cond:    "!=",
+			val:     "model 1",
+			wantIDs: []uuid.UUID{m2.ID},
+		},
+		{
+			desc:    "greater than a float",
+			field:   "Num",
+			cond:    ">",
+			val:     142.5,
+			wantIDs: []uuid.UUID{m1.ID, m2.ID},
+		},
+		{
+			desc:    "range with other type does not match",
+			field:   "Name",
+			cond:    ">=",
+			val:     10,
+			wantIDs: []uuid.UUID{},
+		},
+		{
+			desc:    "array contains",
+			field:   "Tags",
+			cond:    "array-contains",
+			val:     "B",
+			wantIDs: []uuid.UUID{m1.ID},
+		},
+		{
+			desc:    "array contains any",
+			field:   "Tags",
+			cond:    "array-contains-any",
+			val:     []string{"Z", "C"},
+			wantIDs: []uuid.UUID{m1.ID},
+		},
+		{
+			desc:    "in",
+			field:   "Name",
+			cond:    "in",
+			val:     []string{"model 2", "model 9"},
+			wantIDs: []uuid.UUID{m2.ID},
+		},
+		{
+			desc:    "not in",
+			field:   "Name",
+			cond:    "not-in",
+			val:     []string{"model 2", "model 9"},
+			wantIDs: []uuid.UUID{m1.ID},
+		},
+		{
+			desc:    "in without array",
+			field:   "Name",
+			cond:    "in",
+			val:     "model 2",
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			ms, err := s.repo.FindWhere(tC.field, tC.cond, tC.val)
+
+			s.Require().Equal(tC.wantErr, (err != nil), "expect error fail")
+
+			if err != nil {
+				return
+			}
+
+			ids := []uuid.UUID{}
+
+			for _, m := range ms {
+				ids = append(ids, m.ID)
+			}
+
+			s.ElementsMatch(tC.wantIDs, ids, "wrong models")
+		})
+	}
+}
+
+func (s *MemoryRepoSuite) TestMemoryRepo_FindOrderBy() {
+	ms, err := s.repo.FindOrderBy("Name", "DESC")
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().Len(ms, 2, "should return every model")
+	s.Equal(m2.ID, ms[0].ID, "wrong order")
+
+	_, err = s.repo.FindOrderBy("Name", "UP")
+
+	s.Error(err, "invalid order method")
+}
+
+func (s *MemoryRepoSuite) TestMemoryRepo_FindPage() {
+	pq := domain.PageQuery{OrderBy: "Num", Desc: true, Limit: 2}
+
+	page, err := s.repo.FindPage(pq)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(3, page.Total, "wrong total")
+	s.Require().Len(page.Items, 2, "wrong page size")
+	s.Equal(m2.ID, page.Items[0].ID, "same num is sorted by id")
+	s.Equal(m1.ID, page.Items[1].ID, "same num is sorted by id")
+	s.Equal(m1.GetStringID(), page.Next, "wrong cursor")
+
+	pq.Cursor = page.Next
+
+	page, err = s.repo.FindPage(pq)
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().Len(page.Items, 1, "wrong page size")
+	s.Equal(m3.ID, page.Items[0].ID, "wrong model")
+	s.Empty(page.Next, "should be the last page")
+
+	page, err = s.repo.FindPage(domain.PageQuery{
+		Filters: []domain.Filter{{Field: "Num", Cond: "<", Value: 100}},
+		Limit:   10,
+	})
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(1, page.Total, "wrong total")
+
+	page, err = s.repo.FindPage(domain.PageQuery{
+		Filters: []domain.Filter{{Field: "Tags", Cond: "array-contains-any", Value: []string{"C", "Z"}}},
+		OrderBy: "Model.ID",
+		Limit:   10,
+	})
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().Len(page.Items, 1, "only model 1 has the tag C")
+	s.Equal(m1.ID, page.Items[0].ID, "wrong model")
+
+	_, err = s.repo.FindPage(domain.PageQuery{Limit: 10, Cursor: uuid.NewString()})
+
+	s.Error(err, "the cursor does not exist")
+}
+
 func (s *MemoryRepoSuite) TestMemoryRepo_Update() {
 	testCases := []struct {
 		desc      string
@@ -339,6 +490,65 @@ func (s *MemoryRepoSuite) TestMemoryRepo_UpdateField() {
 	}
 }
 
+func (s *MemoryRepoSuite) TestMemoryRepo_UpdateWith() {
+	failure := errors.New("do not save")
+
+	testCases := []struct {
+		desc     string
+		id       uuid.UUID
+		fn       func(m *domain.ExampleModel) error
+		wantErr  error
+		wantName string
+	}{
+		{
+			desc: "proper work",
+			id:   m1.ID,
+			fn: func(m *domain.ExampleModel) error {
+				m.Name = "updated with"
+				return nil
+			},
+			wantName: "updated with",
+		},
+		{
+			desc: "fn fails",
+			id:   m2.ID,
+			fn: func(m *domain.ExampleModel) error {
+				m.Name = "not saved"
+				return failure
+			},
+			wantErr:  failure,
+			wantName: m2.Name,
+		},
+		{
+			desc:    "model that doest't exist",
+			id:      uuid.New(),
+			fn:      func(m *domain.ExampleModel) error { return nil },
+			wantErr: utils.ErrNotFound,
+		},
+	}
+	for i, tC := range testCases {
+		tC = testCases[i]
+		s.Run(tC.desc, func() {
+			err := s.repo.UpdateWith(tC.id, tC.fn)
+
+			if tC.wantErr != nil {
+				s.Require().ErrorIs(err, tC.wantErr, "expect error fail")
+			} else {
+				s.Require().NoError(err, "expect error fail")
+			}
+
+			if tC.wantName == "" {
+				return
+			}
+
+			m, err := s.repo.FindByID(tC.id)
+
+			s.Require().NoError(err)
+			s.Equal(tC.wantName, m.Name, "wrong name")
+		})
+	}
+}
+
 func (s *MemoryRepoSuite) TestMemoryRepo_Remove() {
 	testCases := []struct {
 		desc    string
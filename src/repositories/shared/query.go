@@ -0,0 +1,236 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+// fieldValue reads a field of the model. Nested fields are reached
+// with dots, like firestore does with maps ("Price.Amount").
+func fieldValue(mdl any, path string) (any, error) {
+	v := mdl
+
+	for _, fld := range strings.Split(path, ".") {
+		fv, err := utils.GetStructField(v, fld)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v = fv
+	}
+
+	return v, nil
+}
+
+// errMismatch means the values are of types firestore does not compare.
+var errMismatch = errors.New("mismatched types")
+
+// compareValues orders two values like firestore does. Every number
+// is compared by its value, no matter its go type, and strings and
+// booleans only with their own kind. Other types are compared by
+// their string form, which keeps uuids in a stable order.
+func compareValues(a, b any) (int, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if isNumber(va) && isNumber(vb) {
+		return compareNumbers(va, vb), nil
+	}
+
+	if va.Kind() != vb.Kind() {
+		return 0, fmt.Errorf("%w: cannot compare %T and %T", errMismatch, a, b)
+	}
+
+	switch va.Kind() {
+	case reflect.String:
+		return strings.Compare(va.String(), vb.String()), nil
+	case reflect.Bool:
+		return compareOrdered(boolToInt(va.Bool()), boolToInt(vb.Bool())), nil
+	}
+
+	if sa, ok := a.(fmt.Stringer); ok {
+		if sb, ok := b.(fmt.Stringer); ok {
+			return strings.Compare(sa.String(), sb.String()), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: cannot compare %T values", errMismatch, a)
+}
+
+// equalValues is the firestore equality, 2 and 2.0 are the same.
+func equalValues(a, b any) bool {
+	if c, err := compareValues(a, b); err == nil {
+		return c == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// matchCondition tells if the field value fv meets "fv cond val". It
+// takes the same operators firestore does. Like firestore, ranges on
+// values of another type do not match instead of failing.
+func matchCondition(fv any, cond string, val any) (bool, error) {
+	switch cond {
+	case "==":
+		return equalValues(fv, val), nil
+	case "!=":
+		return !equalValues(fv, val), nil
+	case "<", "<=", ">", ">=":
+		c, err := compareValues(fv, val)
+
+		if errors.Is(err, errMismatch) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		switch cond {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "in", "not-in":
+		vals, err := toSlice(val)
+
+		if err != nil {
+			return false, err
+		}
+
+		in := false
+
+		for _, v := range vals {
+			if equalValues(fv, v) {
+				in = true
+				break
+			}
+		}
+
+		return in == (cond == "in"), nil
+	case "array-contains":
+		if !isArray(fv) {
+			return false, nil
+		}
+
+		return containsAny(fv, []any{val})
+	case "array-contains-any":
+		vals, err := toSlice(val)
+
+		if err != nil {
+			return false, err
+		}
+
+		if !isArray(fv) {
+			return false, nil
+		}
+
+		return containsAny(fv, vals)
+	}
+
+	return false, fmt.Errorf("invalid condition %q", cond)
+}
+
+// containsAny tells if the slice arr has one of the values.
+func containsAny(arr any, vals []any) (bool, error) {
+	items, err := toSlice(arr)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, it := range items {
+		for _, v := range vals {
+			if equalValues(it, v) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func toSlice(val any) ([]any, error) {
+	v := reflect.ValueOf(val)
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%T is not an array", val)
+	}
+
+	items := make([]any, v.Len())
+
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+
+	return items, nil
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isArray(val any) bool {
+	k := reflect.ValueOf(val).Kind()
+	return k == reflect.Slice || k == reflect.Array
+}
+
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case a.CanInt() && b.CanInt():
+		return compareOrdered(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return compareOrdered(a.Uint(), b.Uint())
+	case a.CanInt() && b.CanUint():
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareOrdered(uint64(a.Int()), b.Uint())
+	case a.CanUint() && b.CanInt():
+		return -compareNumbers(b, a)
+	}
+
+	return compareOrdered(toFloat(a), toFloat(b))
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func compareOrdered[V int64 | uint64 | float64](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
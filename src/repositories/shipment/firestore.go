@@ -0,0 +1,28 @@
+package shipment
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreShipmentRepo struct {
+	shared.FirestoreRepo[domain.Shipment]
+}
+
+//* Constructor
+
+func NewFirestoreShipmentRepository(
+	client *firestore.Client,
+	collName string,
+) domain.ShipmentRepository {
+	return &firestoreShipmentRepo{
+		shared.FirestoreRepo[domain.Shipment]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "shipment",
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package shipment
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryShipmentRepo struct {
+	shared.MemoryRepo[domain.Shipment]
+}
+
+//* Constructor
+
+func NewMemoryShipmentRepository(im ...domain.Shipment) domain.ShipmentRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Shipment]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryShipmentRepo{
+		shared.MemoryRepo[domain.Shipment]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentShipmentRepository(filename string) domain.ShipmentRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.Shipment](filename)
+
+	return &memoryShipmentRepo{
+		shared.MemoryRepo[domain.Shipment]{
+			Store: store,
+		},
+	}
+}
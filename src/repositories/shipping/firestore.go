@@ -0,0 +1,28 @@
+package shipping
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+)
+
+//* Implementation
+
+type firestoreShippingRepo struct {
+	shared.FirestoreRepo[domain.ShippingMethod]
+}
+
+//* Constructor
+
+func NewFirestoreShippingRepository(
+	client *firestore.Client,
+	collName string,
+) domain.ShippingRepository {
+	return &firestoreShippingRepo{
+		shared.FirestoreRepo[domain.ShippingMethod]{
+			Client:    client,
+			CollName:  collName,
+			ModelName: "shipping method",
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package shipping
+
+import (
+	"log"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/shared"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Implementation
+
+type memoryShippingRepo struct {
+	shared.MemoryRepo[domain.ShippingMethod]
+}
+
+//* Constructor
+
+func NewMemoryShippingRepository(im ...domain.ShippingMethod) domain.ShippingRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.ShippingMethod]()
+
+	for _, m := range im {
+		if err := store.Set(m.ID, m); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &memoryShippingRepo{
+		shared.MemoryRepo[domain.ShippingMethod]{
+			Store: store,
+		},
+	}
+}
+
+func NewMemoryPersistentShippingRepository(filename string) domain.ShippingRepository {
+	store := utils.NewSyncMap[uuid.UUID, domain.ShippingMethod](filename)
+
+	return &memoryShippingRepo{
+		shared.MemoryRepo[domain.ShippingMethod]{
+			Store: store,
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/google/uuid"
+)
+
+type cartService struct {
+	cartRepo domain.CartRepository
+	prodRepo domain.ProductRepository
+}
+
+func NewCartService(
+	cartRepo domain.CartRepository,
+	prodRepo domain.ProductRepository,
+) domain.CartService {
+	return &cartService{
+		cartRepo: cartRepo,
+		prodRepo: prodRepo,
+	}
+}
+
+// GetByUserID returns an empty cart if the user has not added anything yet.
+func (s *cartService) GetByUserID(usrID uuid.UUID) (*domain.Cart, error) {
+	cart, err := s.cartRepo.FindByID(usrID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cart == nil {
+		return &domain.Cart{
+			Model:  domain.Model{ID: usrID},
+			UserID: usrID,
+			Items:  []domain.OrderProduct{},
+		}, nil
+	}
+
+	return cart, nil
+}
+
+func (s *cartService) AddItem(usrID uuid.UUID, ref domain.ProductRef, qty uint) error {
+	if qty == 0 {
+		return fmt.Errorf("invalid quantity")
+	}
+
+	p, err := s.prodRepo.FindByID(ref.ProductID)
+
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		return fmt.Errorf("product not found")
+	}
+
+	if !p.Available {
+		return fmt.Errorf("product %q is not available", p.Name)
+	}
+
+	if p.HasVariants() && p.Variant(ref.VariantID) == nil {
+		return fmt.Errorf("choose a variant of %q", p.Name)
+	}
+
+	if !p.HasVariants() && ref.VariantID != uuid.Nil {
+		return fmt.Errorf("product %q has no variants", p.Name)
+	}
+
+	cart, err := s.GetByUserID(usrID)
+
+	if err != nil {
+		return err
+	}
+
+	for i, item := range cart.Items {
+		if item.Ref() == ref {
+			cart.Items[i].Quantity += qty
+			return s.saveItems(cart)
+		}
+	}
+
+	cart.Items = append(cart.Items, domain.OrderProduct{
+		ID: ref.ProductID, VariantID: ref.VariantID, Quantity: qty,
+	})
+
+	return s.saveItems(cart)
+}
+
+func (s *cartService) UpdateItem(usrID uuid.UUID, ref domain.ProductRef, qty uint) error {
+	if qty == 0 {
+		return s.RemoveItem(usrID, ref)
+	}
+
+	cart, err := s.GetByUserID(usrID)
+
+	if err != nil {
+		return err
+	}
+
+	for i, item := range cart.Items {
+		if item.Ref() == ref {
+			cart.Items[i].Quantity = qty
+			return s.saveItems(cart)
+		}
+	}
+
+	return fmt.Errorf("the product is not in the cart")
+}
+
+func (s *cartService) RemoveItem(usrID uuid.UUID, ref domain.ProductRef) error {
+	cart, err := s.GetByUserID(usrID)
+
+	if err != nil {
+		return err
+	}
+
+	for i, item := range cart.Items {
+		if item.Ref() == ref {
+			cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+			return s.saveItems(cart)
+		}
+	}
+
+	return fmt.Errorf("the product is not in the cart")
+}
+
+func (s *cartService) Clear(usrID uuid.UUID) error {
+	cart, err := s.cartRepo.FindByID(usrID)
+
+	if err != nil {
+		return err
+	}
+
+	if cart == nil {
+		return nil
+	}
+
+	return s.cartRepo.Remove(usrID)
+}
+
+// Helper functions
+
+func (s *cartService) saveItems(cart *domain.Cart) error {
+	if cart.CreatedAt == 0 {
+		cart.CreatedAt = time.Now().Unix()
+		cart.UpdatedAt = time.Now().Unix()
+		return s.cartRepo.Save(cart)
+	}
+
+	return s.cartRepo.Update(cart.ID, domain.UpdateFields{
+		"Items": cart.Items,
+	})
+}
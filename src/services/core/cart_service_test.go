@@ -0,0 +1,163 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/cart"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartServiceSuite struct {
+	suite.Suite
+	service domain.CartService
+}
+
+func TestCartServiceSuite(t *testing.T) {
+	suite.Run(t, new(CartServiceSuite))
+}
+
+func (s *CartServiceSuite) SetupSuite() {
+	s.T().Logf("\n-------------- init ---------------")
+
+	unavailable := utils.ProductExpToDev2
+	unavailable.Available = false
+
+	s.service = &cartService{
+		cartRepo: cart.NewMemoryCartRepository(),
+		prodRepo: product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExp2, unavailable),
+	}
+}
+
+//* Tests
+
+func (s *CartServiceSuite) TestCartService_Items() {
+	usrID := utils.UserExp1.ID
+
+	c, err := s.service.GetByUserID(usrID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Empty(c.Items, "new carts are empty")
+
+	testCases := []struct {
+		desc      string
+		fn        func() error
+		wantErr   bool
+		wantItems []domain.OrderProduct
+	}{
+		{
+			desc:      "unexisting product",
+			fn:        func() error { return s.service.AddItem(usrID, domain.ProductRef{ProductID: uuid.New()}, 1) },
+			wantErr:   true,
+			wantItems: []domain.OrderProduct{},
+		},
+		{
+			desc: "unavailable product",
+			fn: func() error {
+				return s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExpToDev2.ID}, 1)
+			},
+			wantErr:   true,
+			wantItems: []domain.OrderProduct{},
+		},
+		{
+			desc:      "zero quantity",
+			fn:        func() error { return s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExp1.ID}, 0) },
+			wantErr:   true,
+			wantItems: []domain.OrderProduct{},
+		},
+		{
+			desc:    "add product",
+			fn:      func() error { return s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExp1.ID}, 2) },
+			wantErr: false,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp1.ID, Quantity: 2},
+			},
+		},
+		{
+			desc:    "add same product again",
+			fn:      func() error { return s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExp1.ID}, 1) },
+			wantErr: false,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp1.ID, Quantity: 3},
+			},
+		},
+		{
+			desc:    "add other product",
+			fn:      func() error { return s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExp2.ID}, 1) },
+			wantErr: false,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp1.ID, Quantity: 3},
+				{ID: utils.ProductExp2.ID, Quantity: 1},
+			},
+		},
+		{
+			desc: "update product not in cart",
+			fn: func() error {
+				return s.service.UpdateItem(usrID, domain.ProductRef{ProductID: utils.ProductExpToDev2.ID}, 4)
+			},
+			wantErr: true,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp1.ID, Quantity: 3},
+				{ID: utils.ProductExp2.ID, Quantity: 1},
+			},
+		},
+		{
+			desc: "update quantity",
+			fn: func() error {
+				return s.service.UpdateItem(usrID, domain.ProductRef{ProductID: utils.ProductExp2.ID}, 5)
+			},
+			wantErr: false,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp1.ID, Quantity: 3},
+				{ID: utils.ProductExp2.ID, Quantity: 5},
+			},
+		},
+		{
+			desc:    "remove product",
+			fn:      func() error { return s.service.RemoveItem(usrID, domain.ProductRef{ProductID: utils.ProductExp1.ID}) },
+			wantErr: false,
+			wantItems: []domain.OrderProduct{
+				{ID: utils.ProductExp2.ID, Quantity: 5},
+			},
+		},
+		{
+			desc: "zero quantity removes",
+			fn: func() error {
+				return s.service.UpdateItem(usrID, domain.ProductRef{ProductID: utils.ProductExp2.ID}, 0)
+			},
+			wantErr:   false,
+			wantItems: []domain.OrderProduct{},
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := tC.fn()
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail: %v", err)
+
+			c, err := s.service.GetByUserID(usrID)
+
+			s.Require().NoError(err, "should not be error")
+
+			s.ElementsMatch(tC.wantItems, c.Items, "wrong items")
+		})
+	}
+}
+
+func (s *CartServiceSuite) TestCartService_Clear() {
+	usrID := utils.UserExp2.ID
+
+	s.NoError(s.service.Clear(usrID), "clearing an empty cart is fine")
+
+	s.Require().NoError(s.service.AddItem(usrID, domain.ProductRef{ProductID: utils.ProductExp1.ID}, 1))
+
+	s.Require().NoError(s.service.Clear(usrID), "should not be error")
+
+	c, err := s.service.GetByUserID(usrID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Empty(c.Items, "should be empty")
+}
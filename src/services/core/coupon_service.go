@@ -0,0 +1,316 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type couponService struct {
+	cpnRepo  domain.CouponRepository
+	prodRepo domain.ProductRepository
+}
+
+func NewCouponService(
+	cpnRepo domain.CouponRepository,
+	prodRepo domain.ProductRepository,
+) domain.CouponService {
+	return &couponService{
+		cpnRepo:  cpnRepo,
+		prodRepo: prodRepo,
+	}
+}
+
+func (s *couponService) Create(cpn *domain.Coupon) error {
+	cpn.Code = strings.ToUpper(cpn.Code)
+
+	if err := validateCoupon(cpn); err != nil {
+		return err
+	}
+
+	c, err := s.GetByCode(cpn.Code)
+
+	if err != nil {
+		return err
+	}
+
+	if c != nil {
+		return fmt.Errorf("the coupon %q already exists", cpn.Code)
+	}
+
+	ID, err := uuid.NewUUID()
+
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %s", err)
+	}
+
+	cpn.ID = ID
+	cpn.Uses = 0
+	cpn.UsesByUser = map[string]int64{}
+	cpn.CreatedAt = time.Now().Unix()
+	cpn.UpdatedAt = time.Now().Unix()
+
+	return s.cpnRepo.Save(cpn)
+}
+
+func (s *couponService) GetAll() ([]domain.Coupon, error) {
+	return s.cpnRepo.Find()
+}
+
+func (s *couponService) GetByID(ID uuid.UUID) (*domain.Coupon, error) {
+	return s.cpnRepo.FindByID(ID)
+}
+
+func (s *couponService) GetByCode(code string) (*domain.Coupon, error) {
+	return s.cpnRepo.FindByField("Code", strings.ToUpper(code))
+}
+
+func (s *couponService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
+	cpn, err := s.cpnRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if cpn == nil {
+		return fmt.Errorf("coupon not found")
+	}
+
+	//* Check the coupon as it would be after the update
+	updated := *cpn
+
+	if err := utils.UpdateStructFields(&updated, uf); err != nil {
+		return err
+	}
+
+	if err := validateCoupon(&updated); err != nil {
+		return err
+	}
+
+	return s.cpnRepo.Update(ID, uf)
+}
+
+func (s *couponService) Delete(ID uuid.UUID) error {
+	cpn, err := s.cpnRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if cpn == nil {
+		return fmt.Errorf("coupon not found")
+	}
+
+	return s.cpnRepo.Remove(ID)
+}
+
+// Apply checks the coupon rules against the lines and returns how much
+// is taken off. The lines must be priced by CalculateTotalPrice first.
+func (s *couponService) Apply(code string, usrID uuid.UUID, ops []domain.OrderProduct) (domain.Money, error) {
+	cpn, err := s.usableCoupon(code, usrID)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	subtotal, eligible := domain.Money{}, domain.Money{}
+
+	for _, op := range ops {
+		if subtotal, err = subtotal.Add(op.LineTotal); err != nil {
+			return domain.Money{}, err
+		}
+
+		ok, err := s.appliesTo(cpn, op.ID)
+
+		if err != nil {
+			return domain.Money{}, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if eligible, err = eligible.Add(op.LineTotal); err != nil {
+			return domain.Money{}, err
+		}
+	}
+
+	if !cpn.MinOrderAmount.IsZero() {
+		if cpn.MinOrderAmount.Currency != subtotal.Currency || subtotal.Amount < cpn.MinOrderAmount.Amount {
+			return domain.Money{}, fmt.Errorf("%w: the order must be at least %s", utils.ErrInvalidCoupon, cpn.MinOrderAmount)
+		}
+	}
+
+	if eligible.IsZero() {
+		return domain.Money{}, fmt.Errorf("%w: it does not apply to any of the products", utils.ErrInvalidCoupon)
+	}
+
+	switch cpn.Type {
+	case utils.CouponPercentage:
+		rest, err := eligible.ApplyDiscount(cpn.Rate)
+
+		if err != nil {
+			return domain.Money{}, err
+		}
+
+		return eligible.Sub(rest)
+	case utils.CouponFixed:
+		if cpn.Amount.Currency != eligible.Currency {
+			return domain.Money{}, fmt.Errorf("%w: it is only valid for %s orders", utils.ErrInvalidCoupon, cpn.Amount.Currency)
+		}
+
+		//* Never more than what the products cost
+		if cpn.Amount.Amount > eligible.Amount {
+			return eligible, nil
+		}
+
+		return cpn.Amount, nil
+	}
+
+	return domain.Money{}, fmt.Errorf("%w: unknown type %q", utils.ErrInvalidCoupon, cpn.Type)
+}
+
+// Redeem counts a use of the coupon. The limits are checked again
+// along with the count, other orders may have used it since it was applied.
+func (s *couponService) Redeem(code string, usrID uuid.UUID) error {
+	cpn, err := s.usableCoupon(code, usrID)
+
+	if err != nil {
+		return err
+	}
+
+	return s.cpnRepo.UpdateWith(cpn.ID, func(c *domain.Coupon) error {
+		if err := checkCoupon(c, usrID); err != nil {
+			return err
+		}
+
+		addUse(c, usrID, 1)
+
+		return nil
+	})
+}
+
+func (s *couponService) Release(code string, usrID uuid.UUID) error {
+	cpn, err := s.GetByCode(code)
+
+	if err != nil {
+		return err
+	}
+
+	if cpn == nil {
+		return fmt.Errorf("the coupon was not used by that user")
+	}
+
+	return s.cpnRepo.UpdateWith(cpn.ID, func(c *domain.Coupon) error {
+		if c.UsesByUser[usrID.String()] == 0 {
+			return fmt.Errorf("the coupon was not used by that user")
+		}
+
+		addUse(c, usrID, -1)
+
+		return nil
+	})
+}
+
+// Helper functions
+
+func (s *couponService) usableCoupon(code string, usrID uuid.UUID) (*domain.Coupon, error) {
+	cpn, err := s.GetByCode(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cpn == nil {
+		return nil, fmt.Errorf("%w: %q does not exist", utils.ErrInvalidCoupon, code)
+	}
+
+	if err := checkCoupon(cpn, usrID); err != nil {
+		return nil, err
+	}
+
+	return cpn, nil
+}
+
+func (s *couponService) appliesTo(cpn *domain.Coupon, prodID uuid.UUID) (bool, error) {
+	if len(cpn.Categories) == 0 && len(cpn.Tags) == 0 {
+		return true, nil
+	}
+
+	p, err := s.prodRepo.FindByID(prodID)
+
+	if err != nil {
+		return false, err
+	}
+
+	if p == nil {
+		return false, nil
+	}
+
+	if utils.ItemInSlice(p.Category, cpn.Categories) {
+		return true, nil
+	}
+
+	for _, tag := range p.Tags {
+		if utils.ItemInSlice(tag, cpn.Tags) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// checkCoupon tells if the user can still use the coupon.
+func checkCoupon(cpn *domain.Coupon, usrID uuid.UUID) error {
+	switch {
+	case !cpn.Active:
+		return fmt.Errorf("%w: %q is not active", utils.ErrInvalidCoupon, cpn.Code)
+	case cpn.ExpiresAt != 0 && cpn.ExpiresAt <= time.Now().Unix():
+		return fmt.Errorf("%w: %q has expired", utils.ErrInvalidCoupon, cpn.Code)
+	case cpn.MaxUses != 0 && cpn.Uses >= cpn.MaxUses:
+		return fmt.Errorf("%w: %q has been used up", utils.ErrInvalidCoupon, cpn.Code)
+	case cpn.MaxUsesPerUser != 0 && cpn.UsesByUser[usrID.String()] >= cpn.MaxUsesPerUser:
+		return fmt.Errorf("%w: you already used %q", utils.ErrInvalidCoupon, cpn.Code)
+	}
+
+	return nil
+}
+
+func addUse(cpn *domain.Coupon, usrID uuid.UUID, n int64) {
+	//* The stored map must not be touched in place
+	byUser := make(map[string]int64, len(cpn.UsesByUser)+1)
+
+	for k, v := range cpn.UsesByUser {
+		byUser[k] = v
+	}
+
+	byUser[usrID.String()] += n
+
+	cpn.Uses += n
+	cpn.UsesByUser = byUser
+}
+
+func validateCoupon(cpn *domain.Coupon) error {
+	switch cpn.Type {
+	case utils.CouponPercentage:
+		if cpn.Rate <= 0 || cpn.Rate > 100 {
+			return fmt.Errorf("percentage coupons need a rate between 1 and 100")
+		}
+	case utils.CouponFixed:
+		if cpn.Amount.Amount <= 0 || cpn.Amount.Currency == "" {
+			return fmt.Errorf("fixed coupons need a positive amount and a currency")
+		}
+	default:
+		return fmt.Errorf("invalid coupon type %q", cpn.Type)
+	}
+
+	if cpn.MaxUses < 0 || cpn.MaxUsesPerUser < 0 || cpn.MinOrderAmount.Amount < 0 {
+		return fmt.Errorf("the limits cannot be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/coupon"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type CouponServiceSuite struct {
+	suite.Suite
+	service *couponService
+}
+
+func TestCouponServiceSuite(t *testing.T) {
+	suite.Run(t, new(CouponServiceSuite))
+}
+
+func (s *CouponServiceSuite) SetupSuite() {
+	s.T().Logf("\n-------------- init ---------------")
+
+	expired := domain.Coupon{
+		Model:     domain.Model{ID: uuid.New()},
+		Code:      "OLD20",
+		Type:      utils.CouponPercentage,
+		Rate:      20,
+		ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+		Active:    true,
+	}
+
+	usedUp := domain.Coupon{
+		Model:   domain.Model{ID: uuid.New()},
+		Code:    "ONCE50",
+		Type:    utils.CouponPercentage,
+		Rate:    50,
+		MaxUses: 1,
+		Uses:    1,
+		Active:  true,
+	}
+
+	inactive := domain.Coupon{
+		Model: domain.Model{ID: uuid.New()},
+		Code:  "SOON15",
+		Type:  utils.CouponPercentage,
+		Rate:  15,
+	}
+
+	big := domain.Coupon{
+		Model:  domain.Model{ID: uuid.New()},
+		Code:   "BIG100",
+		Type:   utils.CouponFixed,
+		Amount: domain.NewMoney(10000, utils.DefaultCurrency),
+		Tags:   []string{"black"},
+		Active: true,
+	}
+
+	s.service = &couponService{
+		cpnRepo: coupon.NewMemoryCouponRepository(
+			utils.CouponExp1, utils.CouponExp2, expired, usedUp, inactive, big,
+		),
+		prodRepo: product.NewMemoryProductRepository(
+			utils.ProductExp1, utils.ProductExp2, utils.ProductExpToDev1,
+		),
+	}
+}
+
+//* Tests
+
+func (s *CouponServiceSuite) TestCouponService_Create() {
+	testCases := []struct {
+		desc    string
+		input   domain.Coupon
+		wantErr bool
+	}{
+		{
+			desc: "code already used",
+			input: domain.Coupon{
+				Code: "welcome10",
+				Type: utils.CouponPercentage,
+				Rate: 5,
+			},
+			wantErr: true,
+		},
+		{
+			desc: "invalid rate",
+			input: domain.Coupon{
+				Code: "HALF",
+				Type: utils.CouponPercentage,
+				Rate: 120,
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fixed without currency",
+			input: domain.Coupon{
+				Code:   "FIVE",
+				Type:   utils.CouponFixed,
+				Amount: domain.Money{Amount: 500},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "proper work",
+			input: domain.Coupon{
+				Code:   "spring5",
+				Type:   utils.CouponFixed,
+				Amount: domain.NewMoney(500, utils.DefaultCurrency),
+				Active: true,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			if tC.wantErr {
+				s.Error(err, "should be error")
+				return
+			}
+
+			s.Require().NoError(err, "should not be error")
+
+			cpn, err := s.service.GetByCode("SPRING5")
+
+			s.NoError(err, "should not be error")
+			s.NotNil(cpn, "the code should be saved in upper case")
+		})
+	}
+}
+
+func (s *CouponServiceSuite) TestCouponService_Apply() {
+	p1 := domain.OrderProduct{ID: utils.ProductExp1.ID, Quantity: 1, LineTotal: domain.NewMoney(2064, utils.DefaultCurrency)}
+	p2 := domain.OrderProduct{ID: utils.ProductExp2.ID, Quantity: 1, LineTotal: domain.NewMoney(5460, utils.DefaultCurrency)}
+	tenis := domain.OrderProduct{ID: utils.ProductExpToDev1.ID, Quantity: 1, LineTotal: domain.NewMoney(1819, utils.DefaultCurrency)}
+
+	testCases := []struct {
+		desc         string
+		code         string
+		input        []domain.OrderProduct
+		wantDiscount int64
+		wantErr      bool
+	}{
+		{
+			desc:    "unexisting coupon",
+			code:    "NOPE",
+			input:   []domain.OrderProduct{p1},
+			wantErr: true,
+		},
+		{
+			desc:    "expired coupon",
+			code:    "OLD20",
+			input:   []domain.OrderProduct{p1},
+			wantErr: true,
+		},
+		{
+			desc:    "used up coupon",
+			code:    "ONCE50",
+			input:   []domain.OrderProduct{p1},
+			wantErr: true,
+		},
+		{
+			desc:    "inactive coupon",
+			code:    "SOON15",
+			input:   []domain.OrderProduct{p1},
+			wantErr: true,
+		},
+		{
+			desc:         "percentage rounds half up",
+			code:         "welcome10",
+			input:        []domain.OrderProduct{p1},
+			wantDiscount: 206,
+			wantErr:      false,
+		},
+		{
+			desc:         "percentage on every product",
+			code:         "WELCOME10",
+			input:        []domain.OrderProduct{p1, p2},
+			wantDiscount: 752,
+			wantErr:      false,
+		},
+		{
+			desc:    "no product of the category",
+			code:    "TENIS5",
+			input:   []domain.OrderProduct{p1},
+			wantErr: true,
+		},
+		{
+			desc:    "order under the minimum",
+			code:    "TENIS5",
+			input:   []domain.OrderProduct{tenis},
+			wantErr: true,
+		},
+		{
+			desc:         "fixed amount",
+			code:         "TENIS5",
+			input:        []domain.OrderProduct{tenis, p1},
+			wantDiscount: 500,
+			wantErr:      false,
+		},
+		{
+			desc:         "fixed amount bigger than the products",
+			code:         "BIG100",
+			input:        []domain.OrderProduct{p1, p2},
+			wantDiscount: 2064,
+			wantErr:      false,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			got, err := s.service.Apply(tC.code, utils.UserExp1.ID, tC.input)
+
+			if tC.wantErr {
+				s.True(errors.Is(err, utils.ErrInvalidCoupon), "should be an invalid coupon error")
+				return
+			}
+
+			s.Require().NoError(err, "should not be error")
+			s.Equal(tC.wantDiscount, got.Amount, "wrong discount")
+			s.Equal(utils.DefaultCurrency, got.Currency, "wrong currency")
+		})
+	}
+}
+
+func (s *CouponServiceSuite) TestCouponService_Redeem() {
+	lines := []domain.OrderProduct{
+		{ID: utils.ProductExp1.ID, Quantity: 1, LineTotal: domain.NewMoney(2064, utils.DefaultCurrency)},
+	}
+
+	s.Require().NoError(s.service.Redeem("WELCOME10", utils.UserExp2.ID), "should not be error")
+
+	cpn, err := s.service.GetByCode("WELCOME10")
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(1), cpn.Uses, "should count the use")
+	s.Equal(int64(1), cpn.UsesByUser[utils.UserExp2.ID.String()], "should count the user use")
+	s.Empty(utils.CouponExp1.UsesByUser, "the example should not change")
+
+	_, err = s.service.Apply("WELCOME10", utils.UserExp2.ID, lines)
+
+	s.True(errors.Is(err, utils.ErrInvalidCoupon), "one use per user")
+
+	s.Error(s.service.Redeem("WELCOME10", utils.UserExp2.ID), "one use per user")
+
+	_, err = s.service.Apply("WELCOME10", utils.UserAdmin.ID, lines)
+
+	s.NoError(err, "other users can still use it")
+
+	s.Require().NoError(s.service.Release("WELCOME10", utils.UserExp2.ID), "should not be error")
+
+	_, err = s.service.Apply("WELCOME10", utils.UserExp2.ID, lines)
+
+	s.NoError(err, "the use was given back")
+
+	s.Error(s.service.Release("WELCOME10", utils.UserExp2.ID), "nothing to give back")
+}
+
+func (s *CouponServiceSuite) TestCouponService_Redeem_Concurrent() {
+	cpn := domain.Coupon{
+		Code:    "RUSH5",
+		Type:    utils.CouponPercentage,
+		Rate:    5,
+		MaxUses: 3,
+		Active:  true,
+	}
+
+	s.Require().NoError(s.service.Create(&cpn), "should not be error")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	redeemed := 0
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := s.service.Redeem(cpn.Code, uuid.New()); err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	got, err := s.service.GetByID(cpn.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(3, redeemed, "only the max uses can be redeemed")
+	s.Equal(int64(3), got.Uses, "every use should be counted once")
+	s.Len(got.UsesByUser, 3, "every user should be counted")
+}
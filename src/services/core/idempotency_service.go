@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+// Used when the config does not set a window
+const defaultIdempotencyWindow = 24 * time.Hour
+
+type idempotencyService struct {
+	idemRepo domain.IdempotencyRepository
+	window   time.Duration
+}
+
+func NewIdempotencyService(
+	idemRepo domain.IdempotencyRepository,
+	window time.Duration,
+) domain.IdempotencyService {
+	if window <= 0 {
+		window = defaultIdempotencyWindow
+	}
+
+	return &idempotencyService{
+		idemRepo: idemRepo,
+		window:   window,
+	}
+}
+
+func (s *idempotencyService) Start(usrID uuid.UUID, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	ID := idempotencyRecordID(usrID, key)
+
+	rec, err := s.idemRepo.FindByID(ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rec != nil && rec.ExpiresAt <= time.Now().Unix() {
+		if err := s.idemRepo.Remove(ID); err != nil {
+			return nil, err
+		}
+		rec = nil
+	}
+
+	if rec != nil {
+		if rec.Fingerprint != fingerprint {
+			return nil, fmt.Errorf("%w: it was used for another request", utils.ErrKeyReused)
+		}
+
+		if rec.StatusCode == 0 {
+			return nil, fmt.Errorf("%w: the first request has not finished", utils.ErrKeyInProgress)
+		}
+
+		return rec, nil
+	}
+
+	now := time.Now()
+
+	//* Saving fails if another request claimed the key first
+	err = s.idemRepo.Save(&domain.IdempotencyRecord{
+		Model: domain.Model{
+			ID:        ID,
+			CreatedAt: now.Unix(),
+			UpdatedAt: now.Unix(),
+		},
+		UserID:      usrID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.window).Unix(),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrKeyInProgress, err)
+	}
+
+	return nil, nil
+}
+
+func (s *idempotencyService) Finish(usrID uuid.UUID, key string, status int, contentType, response string) error {
+	return s.idemRepo.Update(idempotencyRecordID(usrID, key), domain.UpdateFields{
+		"StatusCode":  status,
+		"ContentType": contentType,
+		"Response":    response,
+	})
+}
+
+func (s *idempotencyService) Abort(usrID uuid.UUID, key string) error {
+	return s.idemRepo.Remove(idempotencyRecordID(usrID, key))
+}
+
+// Helper functions
+
+// idempotencyRecordID is the same for the same user and key,
+// so a retry finds the record without querying.
+func idempotencyRecordID(usrID uuid.UUID, key string) uuid.UUID {
+	return uuid.NewSHA1(usrID, []byte(key))
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/idempotency"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyServiceSuite struct {
+	suite.Suite
+	service *idempotencyService
+}
+
+func TestIdempotencyServiceSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceSuite))
+}
+
+func (s *IdempotencyServiceSuite) SetupSuite() {
+	s.T().Logf("\n-------------- init ---------------")
+
+	s.service = &idempotencyService{
+		idemRepo: idempotency.NewMemoryIdempotencyRepository(),
+		window:   time.Hour,
+	}
+}
+
+//* Tests
+
+func (s *IdempotencyServiceSuite) TestIdempotencyService_Flow() {
+	usrID, key := utils.UserExp1.ID, "3f9a1c52-retry"
+
+	rec, err := s.service.Start(usrID, key, "fp-1")
+
+	s.Require().NoError(err, "should not be error")
+	s.Nil(rec, "the first request claims the key")
+
+	_, err = s.service.Start(usrID, key, "fp-1")
+
+	s.True(errors.Is(err, utils.ErrKeyInProgress), "the first request has not finished")
+
+	s.Require().NoError(s.service.Finish(usrID, key, 200, "application/json", `{"status":"success"}`))
+
+	rec, err = s.service.Start(usrID, key, "fp-1")
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().NotNil(rec, "should return the saved response")
+	s.Equal(200, rec.StatusCode)
+	s.Equal(`{"status":"success"}`, rec.Response)
+
+	_, err = s.service.Start(usrID, key, "fp-2")
+
+	s.True(errors.Is(err, utils.ErrKeyReused), "the key belongs to another request")
+
+	rec, err = s.service.Start(utils.UserExp2.ID, key, "fp-2")
+
+	s.NoError(err, "the keys are per user")
+	s.Nil(rec, "other user claims its own key")
+
+	s.Require().NoError(s.service.Abort(utils.UserExp2.ID, key))
+
+	rec, err = s.service.Start(utils.UserExp2.ID, key, "fp-3")
+
+	s.NoError(err, "aborted keys can be used again")
+	s.Nil(rec)
+}
+
+func (s *IdempotencyServiceSuite) TestIdempotencyService_Expired() {
+	usrID, key := utils.UserAdmin.ID, "expired-key"
+
+	_, err := s.service.Start(usrID, key, "fp-1")
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().NoError(s.service.Finish(usrID, key, 201, "application/json", "{}"))
+	s.Require().NoError(s.service.idemRepo.Update(idempotencyRecordID(usrID, key), domain.UpdateFields{
+		"ExpiresAt": time.Now().Add(-time.Minute).Unix(),
+	}))
+
+	rec, err := s.service.Start(usrID, key, "fp-2")
+
+	s.NoError(err, "expired keys can be used again")
+	s.Nil(rec, "the old response is not replayed")
+}
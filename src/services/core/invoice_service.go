@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+var invoiceNS = uuid.MustParse("6c0e243c-6e8d-4bcb-a771-c7dab2751779")
+
+type invoiceService struct {
+	invRepo domain.InvoiceRepository
+	ordRepo domain.OrderRepository
+}
+
+func NewInvoiceService(
+	invRepo domain.InvoiceRepository,
+	ordRepo domain.OrderRepository,
+) domain.InvoiceService {
+	return &invoiceService{
+		invRepo: invRepo,
+		ordRepo: ordRepo,
+	}
+}
+
+// GetOrIssue only invoices paid orders, so unpaid ones do not use up numbers.
+func (s *invoiceService) GetOrIssue(ordID uuid.UUID) (*domain.Invoice, error) {
+	ID := invoiceID(ordID)
+
+	inv, err := s.findInvoice(ID, ordID)
+
+	if err != nil || inv != nil {
+		return inv, err
+	}
+
+	ord, err := s.ordRepo.FindByID(ordID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ord == nil {
+		return nil, fmt.Errorf("%w: order not found", utils.ErrNotFound)
+	}
+
+	if !ord.Paid {
+		return nil, fmt.Errorf("%w: the order has not been paid", utils.ErrNotInvoiceable)
+	}
+
+	inv = &domain.Invoice{
+		Model: domain.Model{
+			ID:        ID,
+			CreatedAt: time.Now().Unix(),
+			UpdatedAt: time.Now().Unix(),
+		},
+		OrderID: ord.ID,
+		UserID:  ord.UserID,
+	}
+
+	if err := s.invRepo.SaveNext(inv); err != nil {
+		//* Issued at the same time by another request
+		if issued, _ := s.invRepo.FindByID(ID); issued != nil {
+			return issued, nil
+		}
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// Helper functions
+
+func (s *invoiceService) findInvoice(ID, ordID uuid.UUID) (*domain.Invoice, error) {
+	inv, err := s.invRepo.FindByID(ID)
+
+	if err != nil || inv != nil {
+		return inv, err
+	}
+
+	//* Invoices issued before the ids came from the orders
+	return s.invRepo.FindByField("OrderID", ordID)
+}
+
+// invoiceID is the same for the same order, so saving a second
+// invoice of an order fails.
+func invoiceID(ordID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(invoiceNS, ordID[:])
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/repositories/invoice"
+	"github.com/ZaphCode/clean-arch/src/repositories/order"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvoiceServiceSuite struct {
+	suite.Suite
+	service *invoiceService
+}
+
+func TestInvoiceServiceSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceServiceSuite))
+}
+
+func (s *InvoiceServiceSuite) SetupTest() {
+	s.service = &invoiceService{
+		invRepo: invoice.NewMemoryInvoiceRepository(),
+		ordRepo: order.NewMemoryOrderRepository(utils.OrderExp2, utils.OrderExp4, utils.OrderExp5),
+	}
+}
+
+//* Tests
+
+func (s *InvoiceServiceSuite) TestInvoiceService_GetOrIssue() {
+	first, err := s.service.GetOrIssue(utils.OrderExp4.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(1), first.Number, "wrong number")
+	s.Equal(utils.OrderExp4.UserID, first.UserID, "wrong user")
+
+	second, err := s.service.GetOrIssue(utils.OrderExp5.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(2), second.Number, "numbers should be sequential")
+
+	again, err := s.service.GetOrIssue(utils.OrderExp4.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(first.ID, again.ID, "the order keeps its invoice")
+	s.Equal(int64(1), again.Number, "the order keeps its number")
+}
+
+func (s *InvoiceServiceSuite) TestInvoiceService_GetOrIssue_Concurrent() {
+	var wg sync.WaitGroup
+
+	IDs := make([]uuid.UUID, 8)
+
+	for i := range IDs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			inv, err := s.service.GetOrIssue(utils.OrderExp4.ID)
+
+			if s.NoError(err, "should not be error") {
+				IDs[i] = inv.ID
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, ID := range IDs {
+		s.Equal(IDs[0], ID, "every request should get the same invoice")
+	}
+
+	invs, err := s.service.invRepo.Find()
+
+	s.Require().NoError(err, "should not be error")
+	s.Len(invs, 1, "the order should be invoiced once")
+
+	next, err := s.service.GetOrIssue(utils.OrderExp5.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(2), next.Number, "no number should be skipped")
+}
+
+func (s *InvoiceServiceSuite) TestInvoiceService_GetOrIssueFail() {
+	_, err := s.service.GetOrIssue(utils.OrderExp2.ID)
+
+	s.True(errors.Is(err, utils.ErrNotInvoiceable), "unpaid orders cannot be invoiced")
+
+	_, err = s.service.GetOrIssue(uuid.New())
+
+	s.True(errors.Is(err, utils.ErrNotFound), "the order does not exist")
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,18 +10,52 @@ import (
 	"github.com/google/uuid"
 )
 
+// Allowed moves between order statuses. Once an order is
+// paid the only way out is a refund, refunded is final. Cancelled
+// orders only get refunded when their payment came after the cancel.
+var orderTransitions = map[string][]string{
+	utils.StatusPending:    {utils.StatusPaid, utils.StatusCancelled},
+	utils.StatusCancelled:  {utils.StatusRefunded},
+	utils.StatusPaid:       {utils.StatusProcessing, utils.StatusRefunded},
+	utils.StatusProcessing: {utils.StatusShipped, utils.StatusRefunded},
+	utils.StatusShipped:    {utils.StatusDelivered},
+	utils.StatusDelivered:  {utils.StatusRefunded},
+}
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+var (
+	errNotReserved    = errors.New("the order stock is not reserved")
+	errNotRedeemed    = errors.New("the order coupon is not redeemed")
+	errRefundRecorded = errors.New("the refund is already recorded")
+	errEventHandled   = errors.New("the payment event is already handled")
+)
+
 type orderService struct {
 	ordRepo  domain.OrderRepository
 	addrRepo domain.AddressRepository
+	prodRepo domain.ProductRepository
+	cpnSvc   domain.CouponService
+	notifier domain.OrderNotifier
 }
 
+// NewOrderService works without notifier, the customers are just not told.
 func NewOrderService(
 	ordRepo domain.OrderRepository,
 	addrRepo domain.AddressRepository,
+	prodRepo domain.ProductRepository,
+	cpnSvc domain.CouponService,
+	notifier domain.OrderNotifier,
 ) domain.OrderService {
 	return &orderService{
 		ordRepo:  ordRepo,
 		addrRepo: addrRepo,
+		prodRepo: prodRepo,
+		cpnSvc:   cpnSvc,
+		notifier: notifier,
 	}
 }
 
@@ -35,12 +70,26 @@ func (s *orderService) Create(ord *domain.Order) error {
 		return fmt.Errorf("invalid address id")
 	}
 
+	if err := s.prodRepo.AdjustStock(stockDeltas(ord.Products, -1)); err != nil {
+		return err
+	}
+
 	ord.ID = ID
 	ord.Status = utils.StatusPending
+	ord.StockReserved = true
+	ord.RefundedAmount = domain.NewMoney(0, ord.Amount.Currency)
 	ord.CreatedAt = time.Now().Unix()
 	ord.UpdatedAt = time.Now().Unix()
+	ord.StatusHistory = []domain.StatusChange{
+		{Status: utils.StatusPending, ChangedAt: ord.CreatedAt},
+	}
 
-	s.ordRepo.Save(ord)
+	if err := s.ordRepo.Save(ord); err != nil {
+		if err := s.prodRepo.AdjustStock(stockDeltas(ord.Products, 1)); err != nil {
+			utils.PrintColor("red", "Error releasing stock: ", err)
+		}
+		return err
+	}
 
 	return nil
 }
@@ -49,18 +98,454 @@ func (s *orderService) GetAll() ([]domain.Order, error) {
 	return s.ordRepo.Find()
 }
 
+func (s *orderService) GetByID(ID uuid.UUID) (*domain.Order, error) {
+	return s.ordRepo.FindByID(ID)
+}
+
+// UpdateStatus is the manual way to move an order. Paid and refunded
+// only come from the payment provider, see HandlePaymentEvent and
+// RegisterRefund.
 func (s *orderService) UpdateStatus(ID uuid.UUID, status string) error {
-	return s.ordRepo.UpdateField(ID, "Status", status)
+	if status == utils.StatusPaid || status == utils.StatusRefunded {
+		return fmt.Errorf("%w: the %q status is set by the payment provider", utils.ErrInvalidTransition, status)
+	}
+
+	return s.setStatus(ID, status)
+}
+
+func (s *orderService) setStatus(ID uuid.UUID, status string) error {
+	var (
+		ord  domain.Order
+		from string
+	)
+
+	err := s.ordRepo.UpdateWith(ID, func(o *domain.Order) error {
+		from = o.Status
+
+		if err := moveTo(o, status); err != nil {
+			return err
+		}
+
+		ord = *o
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return s.afterMove(ord, from)
 }
 
 func (s *orderService) SetPaidStatus(ID uuid.UUID, paid bool) error {
 	return s.ordRepo.UpdateField(ID, "Paid", paid)
 }
 
+func (s *orderService) SetPaymentIntentID(ID uuid.UUID, piID string) error {
+	return s.ordRepo.UpdateField(ID, "PaymentIntentID", piID)
+}
+
+func (s *orderService) Cancel(ordID, usrID uuid.UUID) error {
+	ord, err := s.ordRepo.FindByID(ordID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord == nil || ord.UserID != usrID {
+		return fmt.Errorf("cannot cancel that order")
+	}
+
+	if ord.Status != utils.StatusPending || ord.Paid {
+		return fmt.Errorf("%w: only pending orders can be cancelled", utils.ErrInvalidTransition)
+	}
+
+	return s.UpdateStatus(ordID, utils.StatusCancelled)
+}
+
+func (s *orderService) RefundableAmount(ID uuid.UUID) (domain.Money, error) {
+	ord, err := s.ordRepo.FindByID(ID)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	if ord == nil {
+		return domain.Money{}, fmt.Errorf("order not found")
+	}
+
+	if !ord.Paid || ord.PaymentIntentID == "" {
+		return domain.Money{}, fmt.Errorf("%w: the order has not been paid", utils.ErrInvalidTransition)
+	}
+
+	if !utils.ItemInSlice(utils.StatusRefunded, orderTransitions[ord.Status]) {
+		return domain.Money{}, fmt.Errorf("%w: %q orders cannot be refunded", utils.ErrInvalidTransition, ord.Status)
+	}
+
+	return ord.Amount.Sub(ord.RefundedAmount)
+}
+
+// RegisterRefund records that the payment provider has given back
+// refunded in total for the order. The webhook may record a refund
+// before the call that made it, so a total already recorded is a no-op.
+func (s *orderService) RegisterRefund(ID uuid.UUID, refunded domain.Money) error {
+	var (
+		ord    domain.Order
+		from   string
+		amount domain.Money
+	)
+
+	err := s.ordRepo.UpdateWith(ID, func(o *domain.Order) error {
+		if refunded.Currency != o.Amount.Currency || refunded.Amount > o.Amount.Amount {
+			return fmt.Errorf("invalid refund amount. the order amount is %s", o.Amount)
+		}
+
+		if refunded.Amount <= o.RefundedAmount.Amount {
+			return errRefundRecorded
+		}
+
+		if !o.Paid || !utils.ItemInSlice(utils.StatusRefunded, orderTransitions[o.Status]) {
+			return fmt.Errorf("%w: %q orders cannot be refunded", utils.ErrInvalidTransition, o.Status)
+		}
+
+		delta, err := refunded.Sub(o.RefundedAmount)
+
+		if err != nil {
+			return err
+		}
+
+		from, amount, o.RefundedAmount = o.Status, delta, refunded
+
+		//* Fully refunded
+		if refunded.Amount == o.Amount.Amount {
+			if err := moveTo(o, utils.StatusRefunded); err != nil {
+				return err
+			}
+			o.Paid = false
+		}
+
+		ord = *o
+
+		return nil
+	})
+
+	if errors.Is(err, errRefundRecorded) {
+		return nil
+	}
+
+	if errors.Is(err, utils.ErrNotFound) {
+		return fmt.Errorf("order not found")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ord.Status == utils.StatusRefunded {
+		if err := s.afterMove(ord, from); err != nil {
+			return err
+		}
+	}
+
+	s.notifyRefund(ord, amount)
+
+	return nil
+}
+
+func (s *orderService) HandlePaymentEvent(evt *domain.PaymentEvent) error {
+	if !utils.ItemInSlice(evt.Type, utils.GetPaymentEvents()) {
+		return nil
+	}
+
+	ords, err := s.ordRepo.FindWhere("PaymentIntentID", "==", evt.PaymentIntentID)
+
+	if err != nil {
+		return err
+	}
+
+	if len(ords) == 0 {
+		return fmt.Errorf("%w: no order for payment intent %q", utils.ErrNotFound, evt.PaymentIntentID)
+	}
+
+	//* Refunds made through the api may be already registered
+	if evt.Type == utils.EventChargeRefunded {
+		return s.RegisterRefund(ords[0].ID, evt.AmountRefunded)
+	}
+
+	var (
+		ord  domain.Order
+		from string
+	)
+
+	//* The provider may deliver the same event more than once, so the
+	//* event is recorded in the same update that applies it
+	err = s.ordRepo.UpdateWith(ords[0].ID, func(o *domain.Order) error {
+		if utils.ItemInSlice(evt.ID, o.PaymentEvents) {
+			return errEventHandled
+		}
+
+		from = o.Status
+		o.PaymentEvents = append(o.PaymentEvents, evt.ID)
+
+		switch evt.Type {
+		case utils.EventPaymentSucceeded:
+			o.Paid = true
+
+			if o.Status == utils.StatusPending {
+				if err := moveTo(o, utils.StatusPaid); err != nil {
+					return err
+				}
+			}
+		case utils.EventPaymentFailed:
+			if o.Status == utils.StatusPending && !o.Paid {
+				if err := moveTo(o, utils.StatusCancelled); err != nil {
+					return err
+				}
+			}
+		}
+
+		ord = *o
+
+		return nil
+	})
+
+	if errors.Is(err, errEventHandled) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	//* Paid and cancelled, the admins have to refund it
+	if ord.Paid && ord.Status == utils.StatusCancelled {
+		utils.PrintColor("red", "Payment captured for the cancelled order ", ord.ID, ", it has to be refunded")
+	}
+
+	if ord.Status == from {
+		return nil
+	}
+
+	if err := s.afterMove(ord, from); err != nil {
+		return err
+	}
+
+	if s.notifier == nil {
+		return nil
+	}
+
+	//* The customer is told once the payment is accepted
+	switch ord.Status {
+	case utils.StatusPaid:
+		s.notifier.OrderPlaced(ord)
+	case utils.StatusCancelled:
+		s.notifier.PaymentFailed(ord)
+	}
+
+	return nil
+}
+
+// ReleaseStock puts back the units of the order once. The flag is
+// cleared atomically first, so only one caller gets to restock.
+func (s *orderService) ReleaseStock(ID uuid.UUID) error {
+	var ord domain.Order
+
+	err := s.ordRepo.UpdateWith(ID, func(o *domain.Order) error {
+		if !o.StockReserved {
+			return errNotReserved
+		}
+
+		ord = *o
+		o.StockReserved = false
+
+		return nil
+	})
+
+	if errors.Is(err, errNotReserved) {
+		return nil
+	}
+
+	if errors.Is(err, utils.ErrNotFound) {
+		return fmt.Errorf("order not found")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.prodRepo.RestoreStock(stockDeltas(ord.Products, 1)); err != nil {
+		//* The units are still out, so it can be tried again
+		if err := s.ordRepo.UpdateField(ID, "StockReserved", true); err != nil {
+			utils.PrintColor("red", "Error keeping the stock reservation: ", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// releaseCoupon gives back the coupon use of the order once, the
+// same way ReleaseStock does with the units.
+func (s *orderService) releaseCoupon(ID uuid.UUID) error {
+	var ord domain.Order
+
+	err := s.ordRepo.UpdateWith(ID, func(o *domain.Order) error {
+		if !o.CouponRedeemed {
+			return errNotRedeemed
+		}
+
+		ord = *o
+		o.CouponRedeemed = false
+
+		return nil
+	})
+
+	if errors.Is(err, errNotRedeemed) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.cpnSvc.Release(ord.CouponCode, ord.UserID); err != nil {
+		if err := s.ordRepo.UpdateField(ID, "CouponRedeemed", true); err != nil {
+			utils.PrintColor("red", "Error keeping the coupon redeemed: ", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (s *orderService) GetAllByUserID(ID uuid.UUID) ([]domain.Order, error) {
 	return s.ordRepo.FindWhere("UserID", "==", ID)
 }
 
+// GetPage lists the newest orders first.
+func (s *orderService) GetPage(f domain.OrderFilter) (*domain.Page[domain.Order], error) {
+	pq := domain.PageQuery{
+		OrderBy: "CreatedAt",
+		Desc:    true,
+		Limit:   f.Limit,
+		Cursor:  f.Cursor,
+	}
+
+	if pq.Limit <= 0 || pq.Limit > maxPageLimit {
+		pq.Limit = defaultPageLimit
+	}
+
+	if f.Status != "" {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Status", Cond: "==", Value: f.Status})
+	}
+
+	if f.Paid != nil {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Paid", Cond: "==", Value: *f.Paid})
+	}
+
+	if f.UserID != uuid.Nil {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "UserID", Cond: "==", Value: f.UserID})
+	}
+
+	if f.From > 0 {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "CreatedAt", Cond: ">=", Value: f.From})
+	}
+
+	if f.To > 0 {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "CreatedAt", Cond: "<", Value: f.To})
+	}
+
+	return s.ordRepo.FindPage(pq)
+}
+
+// Delete keeps the paid orders, they are the record of a payment.
+// The stock reserved by a pending order goes back first.
 func (s *orderService) Delete(ID uuid.UUID) error {
+	ord, err := s.ordRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord == nil {
+		return fmt.Errorf("%w: order not found", utils.ErrNotFound)
+	}
+
+	if ord.Paid && ord.Status != utils.StatusRefunded {
+		return fmt.Errorf("%w: paid orders cannot be deleted", utils.ErrInvalidTransition)
+	}
+
+	if ord.Status == utils.StatusPending {
+		if err := s.ReleaseStock(ID); err != nil {
+			return err
+		}
+	}
+
 	return s.ordRepo.Remove(ID)
 }
+
+// Helper functions
+
+// moveTo changes the status of the order if the move is allowed. It
+// runs inside UpdateWith, so the check and the write go together.
+func moveTo(o *domain.Order, status string) error {
+	if !utils.ItemInSlice(status, orderTransitions[o.Status]) {
+		return fmt.Errorf("%w: from %q to %q", utils.ErrInvalidTransition, o.Status, status)
+	}
+
+	o.Status = status
+	o.StatusHistory = append(o.StatusHistory, domain.StatusChange{
+		Status:    status,
+		ChangedAt: time.Now().Unix(),
+	})
+
+	return nil
+}
+
+// afterMove does what comes with a status change once it is saved.
+func (s *orderService) afterMove(ord domain.Order, from string) error {
+	//* Items that never left the warehouse go back to the stock
+	if ord.Status == utils.StatusCancelled || (ord.Status == utils.StatusRefunded && from != utils.StatusDelivered) {
+		if err := s.ReleaseStock(ord.ID); err != nil {
+			return err
+		}
+	}
+
+	//* An undone order does not count against the coupon limits
+	if s.cpnSvc != nil && (ord.Status == utils.StatusCancelled || ord.Status == utils.StatusRefunded) {
+		if err := s.releaseCoupon(ord.ID); err != nil {
+			return err
+		}
+	}
+
+	if s.notifier == nil {
+		return nil
+	}
+
+	//* Refunds are told by RegisterRefund, which knows the amount
+	switch ord.Status {
+	case utils.StatusShipped:
+		s.notifier.OrderShipped(ord)
+	case utils.StatusDelivered:
+		s.notifier.OrderDelivered(ord)
+	}
+
+	return nil
+}
+
+func (s *orderService) notifyRefund(ord domain.Order, amount domain.Money) {
+	if s.notifier != nil {
+		s.notifier.OrderRefunded(ord, amount)
+	}
+}
+
+func stockDeltas(ops []domain.OrderProduct, sign int64) map[domain.ProductRef]int64 {
+	deltas := make(map[domain.ProductRef]int64, len(ops))
+
+	for _, op := range ops {
+		deltas[op.Ref()] += sign * int64(op.Quantity)
+	}
+
+	return deltas
+}
@@ -1,11 +1,17 @@
 package core
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/coupon"
 	"github.com/ZaphCode/clean-arch/src/repositories/order"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,17 +28,809 @@ func (s *OrderServiceSuite) SetupSuite() {
 	s.T().Logf("\n-------------- init ---------------")
 
 	ordRepo := order.NewMemoryOrderRepository()
-	addrRepo := address.NewMemoryAddressRepository()
+	addrRepo := address.NewMemoryAddressRepository(utils.AddrExp1)
+	prodRepo := product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExp2)
 
 	s.service = &orderService{
 		ordRepo:  ordRepo,
 		addrRepo: addrRepo,
+		prodRepo: prodRepo,
 	}
 }
 
 //* Tests
 
-func (s *OrderServiceSuite) TestCreateOrder() {
-	ord := domain.Order{}
-	s.service.Create(&ord)
+func (s *OrderServiceSuite) TestOrderService_Create() {
+	testCases := []struct {
+		desc       string
+		input      domain.Order
+		wantErr    bool
+		wantNoStck bool
+		wantStock  int64
+	}{
+		{
+			desc: "invalid address",
+			input: domain.Order{
+				AddressID: uuid.New(),
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			},
+			wantErr:   true,
+			wantStock: utils.ProductExp1.Stock,
+		},
+		{
+			desc: "product that does not exist",
+			input: domain.Order{
+				AddressID: utils.AddrExp1.ID,
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+					{ID: uuid.New(), Quantity: 1},
+				},
+			},
+			wantErr:   true,
+			wantStock: utils.ProductExp1.Stock,
+		},
+		{
+			desc: "not enough stock",
+			input: domain.Order{
+				AddressID: utils.AddrExp1.ID,
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: uint(utils.ProductExp1.Stock)},
+					{ID: utils.ProductExp1.ID, Quantity: 1},
+				},
+			},
+			wantErr:    true,
+			wantNoStck: true,
+			wantStock:  utils.ProductExp1.Stock,
+		},
+		{
+			desc: "proper work",
+			input: domain.Order{
+				AddressID: utils.AddrExp1.ID,
+				Products: []domain.OrderProduct{
+					{ID: utils.ProductExp1.ID, Quantity: 2},
+					{ID: utils.ProductExp2.ID, Quantity: 1},
+				},
+			},
+			wantErr:   false,
+			wantStock: utils.ProductExp1.Stock - 2,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail")
+
+			s.Equal(tC.wantNoStck, errors.Is(err, utils.ErrOutOfStock), "expect out of stock fail")
+
+			p, _ := s.service.prodRepo.FindByID(utils.ProductExp1.ID)
+
+			s.Require().NotNil(p, "should exists")
+
+			s.Equal(tC.wantStock, p.Stock, "wrong stock")
+		})
+	}
+}
+
+func (s *OrderServiceSuite) TestOrderService_ReleaseStock() {
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 3},
+		},
+	}
+
+	before, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+
+	s.True(ord.StockReserved, "stock should be reserved")
+
+	// Released twice to check that the stock is only restored once
+	s.NoError(s.service.ReleaseStock(ord.ID), "should not be error")
+	s.NoError(s.service.ReleaseStock(ord.ID), "should not be error")
+
+	after, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Equal(before.Stock, after.Stock, "stock should be restored")
+
+	s.Error(s.service.ReleaseStock(uuid.New()), "order does not exist")
+}
+
+func (s *OrderServiceSuite) TestOrderService_ReleaseStock_Concurrent() {
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 2},
+		},
+	}
+
+	before, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.NoError(s.service.ReleaseStock(ord.ID), "should not be error")
+		}()
+	}
+
+	wg.Wait()
+
+	after, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Equal(before.Stock, after.Stock, "the stock should be restored once")
+}
+
+func (s *OrderServiceSuite) TestOrderService_ReleaseStock_DeletedProduct() {
+	prod := utils.ProductExp1
+	prod.ID = uuid.New()
+
+	s.Require().NoError(s.service.prodRepo.Save(&prod), "should not be error")
+
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: prod.ID, Quantity: 1},
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	before, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.prodRepo.Remove(prod.ID), "should not be error")
+
+	s.NoError(s.service.ReleaseStock(ord.ID), "the deleted product should be skipped")
+
+	after, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Equal(before.Stock, after.Stock, "the other products should be restocked")
+	s.False(got.StockReserved, "the stock should not be reserved")
+}
+
+func (s *OrderServiceSuite) TestOrderService_UpdateStatus() {
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+
+	testCases := []struct {
+		desc     string
+		id       uuid.UUID
+		status   string
+		provider bool
+		wantErr  bool
+	}{
+		{
+			desc:    "order not found",
+			id:      uuid.New(),
+			status:  utils.StatusPaid,
+			wantErr: true,
+		},
+		{
+			desc:    "skipping states",
+			id:      ord.ID,
+			status:  utils.StatusShipped,
+			wantErr: true,
+		},
+		{
+			desc:    "unknown status",
+			id:      ord.ID,
+			status:  "lost",
+			wantErr: true,
+		},
+		{
+			desc:    "paid is set by the provider",
+			id:      ord.ID,
+			status:  utils.StatusPaid,
+			wantErr: true,
+		},
+		{
+			desc:     "pending to paid by the provider",
+			id:       ord.ID,
+			status:   utils.StatusPaid,
+			provider: true,
+			wantErr:  false,
+		},
+		{
+			desc:    "paid cannot be cancelled",
+			id:      ord.ID,
+			status:  utils.StatusCancelled,
+			wantErr: true,
+		},
+		{
+			desc:    "paid to processing",
+			id:      ord.ID,
+			status:  utils.StatusProcessing,
+			wantErr: false,
+		},
+		{
+			desc:    "processing to shipped",
+			id:      ord.ID,
+			status:  utils.StatusShipped,
+			wantErr: false,
+		},
+		{
+			desc:    "shipped to delivered",
+			id:      ord.ID,
+			status:  utils.StatusDelivered,
+			wantErr: false,
+		},
+		{
+			desc:    "delivered back to pending",
+			id:      ord.ID,
+			status:  utils.StatusPending,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			update := s.service.UpdateStatus
+
+			if tC.provider {
+				update = s.service.setStatus
+			}
+
+			err := update(tC.id, tC.status)
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail")
+
+			if err != nil {
+				s.T().Logf("\n\n Error >>> %s \n\n", err.Error())
+			}
+		})
+	}
+
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Require().NotNil(got, "should exists")
+
+	s.Equal(utils.StatusDelivered, got.Status, "wrong final status")
+
+	s.Len(got.StatusHistory, 5, "every change should be recorded")
+}
+
+func (s *OrderServiceSuite) TestOrderService_Cancel() {
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 2},
+		},
+	}
+
+	before, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+
+	s.Error(s.service.Cancel(ord.ID, uuid.New()), "only the owner can cancel")
+
+	s.Require().NoError(s.service.Cancel(ord.ID, ord.UserID), "should not be error")
+
+	after, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Equal(before.Stock, after.Stock, "cancelling should release the stock")
+
+	s.Error(s.service.UpdateStatus(ord.ID, utils.StatusPaid), "cancelled is final")
+
+	s.Error(s.service.Cancel(ord.ID, ord.UserID), "cannot cancel twice")
+}
+
+func (s *OrderServiceSuite) TestOrderService_ReleaseCoupon() {
+	cpnSvc := NewCouponService(coupon.NewMemoryCouponRepository(utils.CouponExp1), s.service.prodRepo)
+
+	s.service.cpnSvc = cpnSvc
+	defer func() { s.service.cpnSvc = nil }()
+
+	uses := func() int64 {
+		cpn, err := cpnSvc.GetByCode(utils.CouponExp1.Code)
+		s.Require().NoError(err, "should not be error")
+		return cpn.UsesByUser[utils.UserExp1.ID.String()]
+	}
+
+	s.Require().NoError(cpnSvc.Redeem(utils.CouponExp1.Code, utils.UserExp1.ID))
+
+	ord := domain.Order{
+		UserID:         utils.UserExp1.ID,
+		AddressID:      utils.AddrExp1.ID,
+		CouponCode:     utils.CouponExp1.Code,
+		CouponRedeemed: true,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().Equal(int64(1), uses(), "the coupon should be used")
+
+	//* The user, the payment webhook and the order handler may all cancel it
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.service.UpdateStatus(ord.ID, utils.StatusCancelled)
+		}()
+	}
+
+	wg.Wait()
+
+	s.Equal(int64(0), uses(), "the coupon use should be given back once")
+
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Require().NotNil(got, "should exists")
+	s.False(got.CouponRedeemed, "the coupon should not be redeemed")
+}
+
+func (s *OrderServiceSuite) TestOrderService_GetPage() {
+	usrID := uuid.New()
+
+	for i := 0; i < 3; i++ {
+		ord := domain.Order{
+			UserID:    usrID,
+			AddressID: utils.AddrExp1.ID,
+			Products: []domain.OrderProduct{
+				{ID: utils.ProductExp2.ID, Quantity: 1},
+			},
+		}
+
+		s.Require().NoError(s.service.Create(&ord), "should not be error")
+	}
+
+	first, err := s.service.GetPage(domain.OrderFilter{UserID: usrID, Limit: 2})
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(3, first.Total, "wrong total")
+	s.Len(first.Items, 2, "wrong page size")
+	s.NotEmpty(first.Next, "there is another page")
+
+	second, err := s.service.GetPage(domain.OrderFilter{UserID: usrID, Limit: 2, Cursor: first.Next})
+
+	s.Require().NoError(err, "should not be error")
+	s.Len(second.Items, 1, "wrong page size")
+	s.Empty(second.Next, "should be the last page")
+
+	paid := true
+
+	page, err := s.service.GetPage(domain.OrderFilter{UserID: usrID, Paid: &paid})
+
+	s.Require().NoError(err, "should not be error")
+	s.Zero(page.Total, "the orders are not paid")
+
+	page, err = s.service.GetPage(domain.OrderFilter{UserID: usrID, To: second.Items[0].CreatedAt})
+
+	s.Require().NoError(err, "should not be error")
+	s.Zero(page.Total, "the orders were created later")
+}
+
+func (s *OrderServiceSuite) TestOrderService_Delete() {
+	ord := domain.Order{
+		AddressID: utils.AddrExp1.ID,
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 2},
+		},
+	}
+
+	before, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.SetPaidStatus(ord.ID, true), "should not be error")
+
+	s.True(errors.Is(s.service.Delete(ord.ID), utils.ErrInvalidTransition), "paid orders are kept")
+
+	s.Require().NoError(s.service.SetPaidStatus(ord.ID, false), "should not be error")
+	s.Require().NoError(s.service.Delete(ord.ID), "should not be error")
+
+	after, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
+
+	s.Equal(before.Stock, after.Stock, "the reserved stock should be back")
+
+	s.True(errors.Is(s.service.Delete(ord.ID), utils.ErrNotFound), "already deleted")
+}
+
+func (s *OrderServiceSuite) TestOrderService_Refund() {
+	ord := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(5000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+
+	_, err := s.service.RefundableAmount(ord.ID)
+
+	s.ErrorIs(err, utils.ErrInvalidTransition, "unpaid orders cannot be refunded")
+
+	s.Require().NoError(s.service.SetPaidStatus(ord.ID, true))
+	s.Require().NoError(s.service.SetPaymentIntentID(ord.ID, "pi_test"))
+	s.Require().NoError(s.service.setStatus(ord.ID, utils.StatusPaid))
+
+	s.Error(s.service.Cancel(ord.ID, ord.UserID), "paid orders cannot be cancelled")
+
+	testCases := []struct {
+		desc       string
+		amount     int64
+		wantErr    bool
+		wantLeft   int64
+		wantStatus string
+	}{
+		{
+			desc:       "bigger than the order amount",
+			amount:     5001,
+			wantErr:    true,
+			wantLeft:   5000,
+			wantStatus: utils.StatusPaid,
+		},
+		{
+			desc:       "partial refund",
+			amount:     1500,
+			wantErr:    false,
+			wantLeft:   3500,
+			wantStatus: utils.StatusPaid,
+		},
+		{
+			desc:       "already recorded",
+			amount:     1500,
+			wantErr:    false,
+			wantLeft:   3500,
+			wantStatus: utils.StatusPaid,
+		},
+		{
+			desc:       "older total",
+			amount:     1000,
+			wantErr:    false,
+			wantLeft:   3500,
+			wantStatus: utils.StatusPaid,
+		},
+		{
+			desc:       "refund what is left",
+			amount:     5000,
+			wantErr:    false,
+			wantStatus: utils.StatusRefunded,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.RegisterRefund(ord.ID, domain.NewMoney(tC.amount, utils.DefaultCurrency))
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail")
+
+			got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+			s.Require().NotNil(got, "should exists")
+
+			s.Equal(tC.wantStatus, got.Status, "wrong status")
+
+			s.Equal(tC.wantStatus != utils.StatusRefunded, got.Paid, "wrong paid flag")
+
+			if got.Paid {
+				left, err := s.service.RefundableAmount(ord.ID)
+				s.NoError(err, "should not be error")
+				s.Equal(tC.wantLeft, left.Amount, "wrong amount left")
+			}
+		})
+	}
+
+	_, err = s.service.RefundableAmount(ord.ID)
+
+	s.Error(err, "nothing left to refund")
+
+	s.NoError(s.service.RegisterRefund(ord.ID, ord.Amount), "the full refund is already recorded")
+}
+
+func (s *OrderServiceSuite) TestOrderService_Refund_Concurrent() {
+	ord := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(5000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.SetPaidStatus(ord.ID, true))
+	s.Require().NoError(s.service.setStatus(ord.ID, utils.StatusPaid))
+
+	//* The api and the webhook record the same totals
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			total := domain.NewMoney(int64(i%5+1)*1000, utils.DefaultCurrency)
+			s.NoError(s.service.RegisterRefund(ord.ID, total), "should not be error")
+		}(i)
+	}
+
+	wg.Wait()
+
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Require().NotNil(got, "should exists")
+	s.Equal(int64(5000), got.RefundedAmount.Amount, "wrong refunded amount")
+	s.Equal(utils.StatusRefunded, got.Status, "wrong status")
+	s.Len(got.StatusHistory, 3, "refunded once")
+}
+
+func (s *OrderServiceSuite) TestOrderService_HandlePaymentEvent() {
+	paid := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(4000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+	failed := paid
+
+	s.Require().NoError(s.service.Create(&paid), "should not be error")
+	s.Require().NoError(s.service.SetPaymentIntentID(paid.ID, "pi_evt_paid"))
+	s.Require().NoError(s.service.Create(&failed), "should not be error")
+	s.Require().NoError(s.service.SetPaymentIntentID(failed.ID, "pi_evt_failed"))
+
+	testCases := []struct {
+		desc         string
+		evt          domain.PaymentEvent
+		ordID        uuid.UUID
+		wantErr      bool
+		wantStatus   string
+		wantPaid     bool
+		wantRefunded int64
+	}{
+		{
+			desc:       "unknown payment intent",
+			evt:        domain.PaymentEvent{ID: "evt_0", Type: utils.EventPaymentSucceeded, PaymentIntentID: "pi_unknown"},
+			ordID:      paid.ID,
+			wantErr:    true,
+			wantStatus: utils.StatusPending,
+		},
+		{
+			desc:       "unsupported event is ignored",
+			evt:        domain.PaymentEvent{ID: "evt_1", Type: "customer.created", PaymentIntentID: "pi_evt_paid"},
+			ordID:      paid.ID,
+			wantStatus: utils.StatusPending,
+		},
+		{
+			desc:       "payment succeeded",
+			evt:        domain.PaymentEvent{ID: "evt_2", Type: utils.EventPaymentSucceeded, PaymentIntentID: "pi_evt_paid"},
+			ordID:      paid.ID,
+			wantStatus: utils.StatusPaid,
+			wantPaid:   true,
+		},
+		{
+			desc:       "same event twice",
+			evt:        domain.PaymentEvent{ID: "evt_2", Type: utils.EventPaymentSucceeded, PaymentIntentID: "pi_evt_paid"},
+			ordID:      paid.ID,
+			wantStatus: utils.StatusPaid,
+			wantPaid:   true,
+		},
+		{
+			desc:         "partial refund",
+			evt:          domain.PaymentEvent{ID: "evt_3", Type: utils.EventChargeRefunded, PaymentIntentID: "pi_evt_paid", AmountRefunded: domain.NewMoney(1000, utils.DefaultCurrency)},
+			ordID:        paid.ID,
+			wantStatus:   utils.StatusPaid,
+			wantPaid:     true,
+			wantRefunded: 1000,
+		},
+		{
+			desc:         "same refund twice",
+			evt:          domain.PaymentEvent{ID: "evt_3", Type: utils.EventChargeRefunded, PaymentIntentID: "pi_evt_paid", AmountRefunded: domain.NewMoney(1000, utils.DefaultCurrency)},
+			ordID:        paid.ID,
+			wantStatus:   utils.StatusPaid,
+			wantPaid:     true,
+			wantRefunded: 1000,
+		},
+		{
+			desc:         "full refund",
+			evt:          domain.PaymentEvent{ID: "evt_4", Type: utils.EventChargeRefunded, PaymentIntentID: "pi_evt_paid", AmountRefunded: domain.NewMoney(4000, utils.DefaultCurrency)},
+			ordID:        paid.ID,
+			wantStatus:   utils.StatusRefunded,
+			wantRefunded: 4000,
+		},
+		{
+			desc:       "payment failed",
+			evt:        domain.PaymentEvent{ID: "evt_5", Type: utils.EventPaymentFailed, PaymentIntentID: "pi_evt_failed"},
+			ordID:      failed.ID,
+			wantStatus: utils.StatusCancelled,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			evt := tC.evt
+
+			err := s.service.HandlePaymentEvent(&evt)
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail: %v", err)
+
+			got, _ := s.service.ordRepo.FindByID(tC.ordID)
+
+			s.Require().NotNil(got, "should exists")
+
+			s.Equal(tC.wantStatus, got.Status, "wrong status")
+			s.Equal(tC.wantPaid, got.Paid, "wrong paid flag")
+			s.Equal(tC.wantRefunded, got.RefundedAmount.Amount, "wrong refunded amount")
+		})
+	}
+
+	got, _ := s.service.ordRepo.FindByID(paid.ID)
+
+	s.Len(got.StatusHistory, 3, "repeated events should not change the history")
+}
+
+func (s *OrderServiceSuite) TestOrderService_HandlePaymentEvent_Concurrent() {
+	ord := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(4000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.SetPaymentIntentID(ord.ID, "pi_evt_concurrent"))
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.NoError(s.service.HandlePaymentEvent(&domain.PaymentEvent{
+				ID:              "evt_concurrent",
+				Type:            utils.EventPaymentSucceeded,
+				PaymentIntentID: "pi_evt_concurrent",
+			}), "should not be error")
+		}()
+	}
+
+	wg.Wait()
+
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Require().NotNil(got, "should exists")
+	s.Equal(utils.StatusPaid, got.Status, "wrong status")
+	s.Len(got.StatusHistory, 2, "the event should be applied once")
+	s.Equal([]string{"evt_concurrent"}, got.PaymentEvents, "the event should be recorded once")
+}
+
+func (s *OrderServiceSuite) TestOrderService_HandlePaymentEvent_Cancelled() {
+	ord := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(4000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.SetPaymentIntentID(ord.ID, "pi_evt_cancelled"))
+	s.Require().NoError(s.service.Cancel(ord.ID, ord.UserID))
+
+	s.Require().NoError(s.service.HandlePaymentEvent(&domain.PaymentEvent{
+		ID:              "evt_late_paid",
+		Type:            utils.EventPaymentSucceeded,
+		PaymentIntentID: "pi_evt_cancelled",
+	}))
+
+	got, _ := s.service.ordRepo.FindByID(ord.ID)
+
+	s.Require().NotNil(got, "should exists")
+	s.Equal(utils.StatusCancelled, got.Status, "the order stays cancelled")
+	s.True(got.Paid, "the money was captured")
+
+	//* So the admins can give it back
+	left, err := s.service.RefundableAmount(ord.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(4000), left.Amount, "wrong amount left")
+
+	s.Require().NoError(s.service.RegisterRefund(ord.ID, left))
+
+	got, _ = s.service.ordRepo.FindByID(ord.ID)
+
+	s.Equal(utils.StatusRefunded, got.Status, "wrong status")
+	s.False(got.Paid, "wrong paid flag")
+}
+
+func (s *OrderServiceSuite) TestOrderService_Notify() {
+	notifier := &recordNotifier{}
+
+	s.service.notifier = notifier
+	defer func() { s.service.notifier = nil }()
+
+	ord := domain.Order{
+		UserID:    utils.UserExp1.ID,
+		AddressID: utils.AddrExp1.ID,
+		Amount:    domain.NewMoney(5000, utils.DefaultCurrency),
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&ord), "should not be error")
+	s.Require().NoError(s.service.SetPaymentIntentID(ord.ID, "pi_notify"))
+
+	s.Empty(notifier.events, "the order is not placed until it is paid")
+
+	s.Require().NoError(s.service.HandlePaymentEvent(&domain.PaymentEvent{
+		ID:              "evt_notify_paid",
+		Type:            utils.EventPaymentSucceeded,
+		PaymentIntentID: "pi_notify",
+	}))
+
+	for _, status := range []string{
+		utils.StatusProcessing,
+		utils.StatusShipped,
+		utils.StatusDelivered,
+	} {
+		s.Require().NoError(s.service.UpdateStatus(ord.ID, status))
+	}
+
+	s.Require().NoError(s.service.RegisterRefund(ord.ID, domain.NewMoney(2000, utils.DefaultCurrency)))
+	s.Require().NoError(s.service.RegisterRefund(ord.ID, domain.NewMoney(5000, utils.DefaultCurrency)))
+
+	s.Equal([]string{
+		"placed",
+		"shipped",
+		"delivered",
+		"refunded 20.00 USD",
+		"refunded 30.00 USD",
+	}, notifier.events, "wrong notifications")
+
+	failed := domain.Order{
+		UserID:          utils.UserExp1.ID,
+		AddressID:       utils.AddrExp1.ID,
+		PaymentIntentID: "pi_notify_failed",
+		Products: []domain.OrderProduct{
+			{ID: utils.ProductExp2.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&failed), "should not be error")
+
+	evt := domain.PaymentEvent{
+		ID:              "evt_notify_failed",
+		Type:            utils.EventPaymentFailed,
+		PaymentIntentID: failed.PaymentIntentID,
+	}
+
+	s.Require().NoError(s.service.HandlePaymentEvent(&evt))
+	s.Require().NoError(s.service.HandlePaymentEvent(&evt), "repeated events are ignored")
+
+	s.Equal([]string{"payment failed"}, notifier.events[5:], "wrong notifications")
+}
+
+// Helpers
+
+type recordNotifier struct {
+	events []string
+}
+
+func (n *recordNotifier) OrderPlaced(domain.Order)    { n.events = append(n.events, "placed") }
+func (n *recordNotifier) PaymentFailed(domain.Order)  { n.events = append(n.events, "payment failed") }
+func (n *recordNotifier) OrderShipped(domain.Order)   { n.events = append(n.events, "shipped") }
+func (n *recordNotifier) OrderDelivered(domain.Order) { n.events = append(n.events, "delivered") }
+
+func (n *recordNotifier) OrderRefunded(_ domain.Order, amount domain.Money) {
+	n.events = append(n.events, "refunded "+amount.String())
 }
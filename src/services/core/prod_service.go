@@ -5,21 +5,29 @@ import (
 	"time"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/google/uuid"
 )
 
 type prodService struct {
 	prodRepo domain.ProductRepository
 	catRepo  domain.CategoryRepository
+	revRepo  domain.ReviewRepository
+	indexer  domain.ProductIndexer
 }
 
+// NewProductService works without indexer, the products are just not searchable.
 func NewProductService(
 	prodRepo domain.ProductRepository,
 	catRepo domain.CategoryRepository,
+	revRepo domain.ReviewRepository,
+	indexer domain.ProductIndexer,
 ) domain.ProductService {
 	return &prodService{
 		prodRepo: prodRepo,
 		catRepo:  catRepo,
+		revRepo:  revRepo,
+		indexer:  indexer,
 	}
 }
 
@@ -40,11 +48,23 @@ func (s *prodService) Create(prod *domain.Product) error {
 		return fmt.Errorf("category %q does not exist", prod.Category)
 	}
 
+	if err := checkOptions(prod.Options); err != nil {
+		return err
+	}
+
 	prod.ID = ID
+	prod.Variants = nil
+	prod.SKUs = []string{}
 	prod.CreatedAt = time.Now().Unix()
 	prod.UpdatedAt = time.Now().Unix()
 
-	s.prodRepo.Save(prod)
+	if err := s.prodRepo.Save(prod); err != nil {
+		return err
+	}
+
+	if s.indexer != nil {
+		s.indexer.Index(*prod)
+	}
 
 	return nil
 }
@@ -63,7 +83,7 @@ func (s *prodService) GetLatestProds(lim ...int) ([]domain.Product, error) {
 		return nil, err
 	}
 
-	if len(lim) <= 0 {
+	if len(lim) <= 0 || lim[0] < 0 || lim[0] >= len(prods) {
 		return prods, nil
 	}
 	return prods[:lim[0]], nil
@@ -77,6 +97,70 @@ func (s *prodService) GetByCategory(c string) ([]domain.Product, error) {
 	return s.prodRepo.FindWhere("Category", "==", c)
 }
 
+// GetPage lists the newest products first unless other sort is asked.
+// A price range can only be sorted by price, firestore needs the
+// range field to be the first one of the order.
+func (s *prodService) GetPage(f domain.ProductFilter) (*domain.Page[domain.Product], error) {
+	ranged := f.MinPrice > 0 || f.MaxPrice > 0
+
+	if ranged && f.SortBy == "" {
+		f.SortBy = "price"
+	}
+
+	pq := domain.PageQuery{
+		Desc:   f.Desc,
+		Limit:  f.Limit,
+		Cursor: f.Cursor,
+	}
+
+	switch f.SortBy {
+	case "price":
+		pq.OrderBy = "Price.Amount"
+	case "name":
+		pq.OrderBy = "Name"
+	case "created_at":
+		pq.OrderBy = "CreatedAt"
+	case "":
+		pq.OrderBy, pq.Desc = "CreatedAt", true
+	default:
+		return nil, fmt.Errorf("%w: cannot sort by %q", utils.ErrInvalidFilter, f.SortBy)
+	}
+
+	if ranged && pq.OrderBy != "Price.Amount" {
+		return nil, fmt.Errorf("%w: a price range can only be sorted by price", utils.ErrInvalidFilter)
+	}
+
+	if f.MinPrice > 0 && f.MaxPrice > 0 && f.MinPrice > f.MaxPrice {
+		return nil, fmt.Errorf("%w: the min price is bigger than the max price", utils.ErrInvalidFilter)
+	}
+
+	if pq.Limit <= 0 || pq.Limit > maxPageLimit {
+		pq.Limit = defaultPageLimit
+	}
+
+	if f.Category != "" {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Category", Cond: "==", Value: f.Category})
+	}
+
+	if len(f.Tags) > 0 {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Tags", Cond: "array-contains-any", Value: f.Tags})
+	}
+
+	if f.Available != nil {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Available", Cond: "==", Value: *f.Available})
+	}
+
+	if f.MinPrice > 0 {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Price.Amount", Cond: ">=", Value: f.MinPrice})
+	}
+
+	if f.MaxPrice > 0 {
+		pq.Filters = append(pq.Filters, domain.Filter{Field: "Price.Amount", Cond: "<=", Value: f.MaxPrice})
+	}
+
+	return s.prodRepo.FindPage(pq)
+}
+
 func (s *prodService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
 	p, err := s.prodRepo.FindByID(ID)
 
@@ -96,7 +180,63 @@ func (s *prodService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
 		}
 	}
 
-	return s.prodRepo.Update(ID, uf)
+	if v, ok := uf["Options"]; ok {
+		opts, ok := v.([]domain.ProductOption)
+
+		if !ok {
+			return fmt.Errorf("invalid options field")
+		}
+
+		if err := checkOptions(opts); err != nil {
+			return err
+		}
+
+		check := *p
+		check.Options = opts
+
+		for _, v := range p.Variants {
+			if err := checkVariant(&check, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok := uf["Stock"]; ok && p.HasVariants() {
+		return fmt.Errorf("%w: the stock of %q is set on its variants", utils.ErrInvalidVariant, p.Name)
+	}
+
+	if v, ok := uf["Price"].(domain.Money); ok {
+		for _, vr := range p.Variants {
+			if !vr.Price.IsZero() && vr.Price.Currency != v.Currency {
+				return fmt.Errorf("%w: variant %s is priced in %s", utils.ErrInvalidVariant, vr.SKU, vr.Price.Currency)
+			}
+		}
+	}
+
+	//* The images are added to the uploaded ones, not replacing them
+	if v, ok := uf["Images"]; ok {
+		imgs, ok := v.([]domain.Image)
+
+		if !ok {
+			return fmt.Errorf("invalid images field")
+		}
+
+		delete(uf, "Images")
+
+		if err := s.AddImages(ID, imgs...); err != nil {
+			return err
+		}
+
+		if len(uf) == 0 {
+			return nil
+		}
+	}
+
+	if err := s.prodRepo.Update(ID, uf); err != nil {
+		return err
+	}
+
+	return s.reindex(ID)
 }
 
 func (s *prodService) SetAvailable(ID uuid.UUID, avl bool) error {
@@ -110,7 +250,11 @@ func (s *prodService) SetAvailable(ID uuid.UUID, avl bool) error {
 		return fmt.Errorf("product not found")
 	}
 
-	return s.prodRepo.UpdateField(ID, "Available", avl)
+	if err := s.prodRepo.UpdateField(ID, "Available", avl); err != nil {
+		return err
+	}
+
+	return s.reindex(ID)
 }
 
 func (s *prodService) Delete(ID uuid.UUID) error {
@@ -124,31 +268,322 @@ func (s *prodService) Delete(ID uuid.UUID) error {
 		return fmt.Errorf("product not found")
 	}
 
-	return s.prodRepo.Remove(ID)
+	if err := s.prodRepo.Remove(ID); err != nil {
+		return err
+	}
+
+	if s.indexer != nil {
+		s.indexer.Remove(ID)
+	}
+
+	//* The reviews go with the product
+	revs, err := s.revRepo.FindWhere("ProductID", "==", ID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range revs {
+		if err := s.revRepo.Remove(r.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *prodService) AddVariant(prodID uuid.UUID, v *domain.ProductVariant) error {
+	if err := s.checkSKU(prodID, v.SKU); err != nil {
+		return err
+	}
+
+	ID, err := uuid.NewUUID()
+
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %s", err)
+	}
+
+	v.ID = ID
+
+	return s.updateVariants(prodID, func(p *domain.Product) error {
+		if err := checkVariant(p, *v); err != nil {
+			return err
+		}
+
+		p.Variants = append(p.Variants, *v)
+
+		return nil
+	})
+}
+
+func (s *prodService) UpdateVariant(prodID uuid.UUID, v domain.ProductVariant) error {
+	if err := s.checkSKU(prodID, v.SKU); err != nil {
+		return err
+	}
+
+	return s.updateVariants(prodID, func(p *domain.Product) error {
+		old := p.Variant(v.ID)
+
+		if old == nil {
+			return fmt.Errorf("%w: variant %s", utils.ErrNotFound, v.ID)
+		}
+
+		if err := checkVariant(p, v); err != nil {
+			return err
+		}
+
+		*old = v
+
+		return nil
+	})
+}
+
+// RemoveVariant leaves the product without stock when its last
+// variant goes, it has to be set again.
+func (s *prodService) RemoveVariant(prodID, varID uuid.UUID) error {
+	return s.updateVariants(prodID, func(p *domain.Product) error {
+		for i, v := range p.Variants {
+			if v.ID == varID {
+				p.Variants = append(p.Variants[:i], p.Variants[i+1:]...)
+
+				if !p.HasVariants() {
+					p.Stock = 0
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: variant %s", utils.ErrNotFound, varID)
+	})
+}
+
+// AddImages checks the limit and appends in the same update, so
+// uploads made at the same time do not drop each other images.
+func (s *prodService) AddImages(prodID uuid.UUID, imgs ...domain.Image) error {
+	err := s.prodRepo.UpdateWith(prodID, func(p *domain.Product) error {
+		if len(p.Images)+len(imgs) > utils.MaxProductImages {
+			return fmt.Errorf("%w: a product can have up to %d images", utils.ErrTooManyImages, utils.MaxProductImages)
+		}
+
+		p.Images = append(append([]domain.Image(nil), p.Images...), imgs...)
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return s.reindex(prodID)
 }
 
-func (s *prodService) CalculateTotalPrice(ops []domain.OrderProduct) (int64, error) {
+// RemoveImage takes the image out of the product and returns it, so
+// its stored files can be deleted.
+func (s *prodService) RemoveImage(prodID, imgID uuid.UUID) (*domain.Image, error) {
+	var removed *domain.Image
+
+	err := s.prodRepo.UpdateWith(prodID, func(p *domain.Product) error {
+		for i, img := range p.Images {
+			if img.ID == imgID {
+				removed = &img
+				p.Images = append(append([]domain.Image(nil), p.Images[:i]...), p.Images[i+1:]...)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: image %s", utils.ErrNotFound, imgID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, s.reindex(prodID)
+}
+
+// CalculateTotalPrice prices every line on its own (discount applied to
+// the line and rounded half up) and adds the lines. All the products
+// must share the same currency.
+func (s *prodService) CalculateTotalPrice(ops []domain.OrderProduct) (domain.Money, error) {
 	if len(ops) == 0 || ops == nil {
-		return 0, fmt.Errorf("missing products")
+		return domain.Money{}, fmt.Errorf("missing products")
 	}
 
-	var total float32 = 0
+	total := domain.Money{}
 
-	for _, op := range ops {
+	for i, op := range ops {
 		p, err := s.prodRepo.FindByID(op.ID)
 
 		if err != nil {
-			return 0, err
+			return domain.Money{}, err
 		}
 
 		if p == nil {
-			return 0, fmt.Errorf("product %s not found", op.ID.String())
+			return domain.Money{}, fmt.Errorf("product %s not found", op.ID.String())
+		}
+
+		if !p.Available {
+			return domain.Money{}, fmt.Errorf("product %q is not available", p.Name)
+		}
+
+		if op.Quantity == 0 {
+			return domain.Money{}, fmt.Errorf("invalid quantity for product %s", op.ID.String())
+		}
+
+		price := p.Price
+
+		if p.HasVariants() {
+			v := p.Variant(op.VariantID)
+
+			if v == nil {
+				return domain.Money{}, fmt.Errorf("choose a variant of %q", p.Name)
+			}
+
+			price = p.VariantPrice(*v)
+			ops[i].SKU = v.SKU
+			ops[i].Variant = p.VariantLabel(*v)
+		} else if op.VariantID != uuid.Nil {
+			return domain.Money{}, fmt.Errorf("product %q has no variants", p.Name)
+		}
+
+		lineTotal, err := price.Mul(int64(op.Quantity)).ApplyDiscount(p.DiscountRate)
+
+		if err != nil {
+			return domain.Money{}, fmt.Errorf("product %q: %w", p.Name, err)
+		}
+
+		if total, err = total.Add(lineTotal); err != nil {
+			return domain.Money{}, err
+		}
+
+		ops[i].Name = p.Name
+		ops[i].Category = p.Category
+		ops[i].UnitPrice = price
+		ops[i].DiscountRate = p.DiscountRate
+		ops[i].LineTotal = lineTotal
+	}
+
+	return total, nil
+}
+
+// Helper functions
+
+func (s *prodService) updateVariants(prodID uuid.UUID, fn func(p *domain.Product) error) error {
+	p, err := s.prodRepo.FindByID(prodID)
+
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		return fmt.Errorf("%w: product %s", utils.ErrNotFound, prodID)
+	}
+
+	if err := s.prodRepo.UpdateVariants(prodID, fn); err != nil {
+		return err
+	}
+
+	return s.reindex(prodID)
+}
+
+// checkSKU makes sure no other product uses the sku, the
+// product own variants are checked by checkVariant.
+func (s *prodService) checkSKU(prodID uuid.UUID, sku string) error {
+	prods, err := s.prodRepo.FindWhere("SKUs", "array-contains", sku)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range prods {
+		if p.ID != prodID {
+			return fmt.Errorf("%w: sku %q is used by %q", utils.ErrInvalidVariant, sku, p.Name)
+		}
+	}
+
+	return nil
+}
+
+func checkOptions(opts []domain.ProductOption) error {
+	names := make(map[string]bool, len(opts))
+
+	for _, o := range opts {
+		if o.Name == "" || names[o.Name] {
+			return fmt.Errorf("%w: option names must be unique", utils.ErrInvalidVariant)
+		}
+
+		if len(o.Values) == 0 {
+			return fmt.Errorf("%w: option %q has no values", utils.ErrInvalidVariant, o.Name)
+		}
+
+		names[o.Name] = true
+	}
+
+	return nil
+}
+
+// checkVariant makes sure the variant picks one value of every
+// option and does not repeat the options or the sku of another one.
+func checkVariant(p *domain.Product, v domain.ProductVariant) error {
+	if len(p.Options) == 0 {
+		return fmt.Errorf("%w: %q has no options", utils.ErrInvalidVariant, p.Name)
+	}
+
+	if v.SKU == "" {
+		return fmt.Errorf("%w: missing sku", utils.ErrInvalidVariant)
+	}
+
+	if v.Stock < 0 {
+		return fmt.Errorf("%w: negative stock", utils.ErrInvalidVariant)
+	}
+
+	if !v.Price.IsZero() && v.Price.Currency != p.Price.Currency {
+		return fmt.Errorf("%w: the price must be in %s", utils.ErrInvalidVariant, p.Price.Currency)
+	}
+
+	if len(v.Options) != len(p.Options) {
+		return fmt.Errorf("%w: pick one value of every option", utils.ErrInvalidVariant)
+	}
+
+	for _, o := range p.Options {
+		if !utils.ItemInSlice(v.Options[o.Name], o.Values) {
+			return fmt.Errorf("%w: %q is not a %s", utils.ErrInvalidVariant, v.Options[o.Name], o.Name)
 		}
+	}
 
-		var price float32 = float32(p.Price) * ((float32(100) - float32(p.DiscountRate)) / 100) * float32(op.Quantity)
+	for _, other := range p.Variants {
+		if other.ID == v.ID {
+			continue
+		}
 
-		total += price
+		if other.SKU == v.SKU {
+			return fmt.Errorf("%w: sku %q is repeated", utils.ErrInvalidVariant, v.SKU)
+		}
+
+		if p.VariantLabel(other) == p.VariantLabel(v) {
+			return fmt.Errorf("%w: %q already exists", utils.ErrInvalidVariant, p.VariantLabel(v))
+		}
 	}
 
-	return int64(total), nil
+	return nil
+}
+
+// reindex reads the product back, so the index gets what was stored.
+func (s *prodService) reindex(ID uuid.UUID) error {
+	if s.indexer == nil {
+		return nil
+	}
+
+	p, err := s.prodRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if p != nil {
+		s.indexer.Index(*p)
+	}
+
+	return nil
 }
@@ -1,11 +1,13 @@
 package core
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/category"
 	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/review"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
@@ -37,6 +39,12 @@ func (s *ProductServiceSuite) SetupSuite() {
 	s.service = &prodService{
 		prodRepo: prodRepo,
 		catRepo:  catRepo,
+		revRepo: review.NewMemoryReviewRepository(domain.Review{
+			Model:     domain.Model{ID: reviewID(utils.ProductExp1.ID, utils.UserExp1.ID)},
+			ProductID: utils.ProductExp1.ID,
+			UserID:    utils.UserExp1.ID,
+			Rating:    4,
+		}),
 	}
 }
 
@@ -53,11 +61,11 @@ func (s *ProductServiceSuite) TestProductService_Create() {
 				Category:     "clothes",
 				Name:         "Blue pants",
 				Description:  "incredible pants",
-				Price:        2424,
+				Price:        domain.NewMoney(2424, utils.DefaultCurrency),
 				DiscountRate: 13,
 				Tags:         []string{"blue", "pants", "levis"},
 				Available:    true,
-				ImagesUrl:    []string{"https://levis.com/bluepants.jpg"},
+				Images:       []domain.Image{domain.NewURLImage("https://levis.com/bluepants.jpg")},
 			},
 		},
 		{
@@ -67,11 +75,11 @@ func (s *ProductServiceSuite) TestProductService_Create() {
 				Category:     "ropa",
 				Name:         "pantalones azules",
 				Description:  "increibles pantalones",
-				Price:        43,
+				Price:        domain.NewMoney(43, utils.DefaultCurrency),
 				DiscountRate: 13,
 				Tags:         []string{"blue", "pants", "levis"},
 				Available:    true,
-				ImagesUrl:    []string{"https://levis.com/bluepants.jpg"},
+				Images:       []domain.Image{domain.NewURLImage("https://levis.com/bluepants.jpg")},
 			},
 		},
 	}
@@ -175,9 +183,157 @@ func (s *ProductServiceSuite) TestProductService_GetLatests() {
 
 	s.Equal("Corsair void pro", ps[0].Name, "should be the last prod created")
 
+	all, err := s.service.GetLatestProds(1000)
+
+	s.NoError(err, "a limit bigger than the catalog should not fail")
+
+	s.NotEmpty(all, "should return every product")
+
 	utils.PrettyPrintTesting(s.T(), ps)
 }
 
+func (s *ProductServiceSuite) TestProductService_GetByTags() {
+	svc := &prodService{
+		prodRepo: product.NewMemoryProductRepository(
+			utils.ProductExp1,
+			utils.ProductExp2,
+			utils.ProductExpToDev1,
+		),
+	}
+
+	testCases := []struct {
+		desc    string
+		tags    []string
+		wantIDs []uuid.UUID
+	}{
+		{
+			desc:    "one tag",
+			tags:    []string{"clothes"},
+			wantIDs: []uuid.UUID{utils.ProductExp1.ID, utils.ProductExpToDev1.ID},
+		},
+		{
+			desc:    "any of the tags",
+			tags:    []string{"black", "corsair"},
+			wantIDs: []uuid.UUID{utils.ProductExp1.ID, utils.ProductExp2.ID},
+		},
+		{
+			desc:    "unknown tag",
+			tags:    []string{"toys"},
+			wantIDs: []uuid.UUID{},
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			got, err := svc.GetByTags(tC.tags...)
+
+			s.Require().NoError(err, "should not be error")
+
+			ids := []uuid.UUID{}
+
+			for _, p := range got {
+				ids = append(ids, p.ID)
+			}
+
+			s.ElementsMatch(tC.wantIDs, ids, "wrong products")
+		})
+	}
+}
+
+func (s *ProductServiceSuite) TestProductService_GetPage() {
+	unavailable := utils.ProductExpToDev2
+	unavailable.Available = false
+
+	//* Own repo, the suite ones are changed by other tests
+	svc := &prodService{
+		prodRepo: product.NewMemoryProductRepository(
+			utils.ProductExp1,      // clothes 2400
+			utils.ProductExp2,      // headsets 6000
+			utils.ProductExpToDev1, // tenis 2599
+			unavailable,            // clothes 1549
+		),
+	}
+
+	avl := true
+
+	testCases := []struct {
+		desc      string
+		filter    domain.ProductFilter
+		wantErr   bool
+		wantTotal int
+		wantNames []string
+		wantNext  bool
+	}{
+		{
+			desc:      "price range sorted by price",
+			filter:    domain.ProductFilter{MinPrice: 2000, MaxPrice: 3000},
+			wantTotal: 2,
+			wantNames: []string{utils.ProductExp1.Name, utils.ProductExpToDev1.Name},
+		},
+		{
+			desc:      "category and availability",
+			filter:    domain.ProductFilter{Category: "clothes", Available: &avl},
+			wantTotal: 1,
+			wantNames: []string{utils.ProductExp1.Name},
+		},
+		{
+			desc:      "any of the tags by name desc",
+			filter:    domain.ProductFilter{Tags: []string{"cups", "corsair"}, SortBy: "name", Desc: true},
+			wantTotal: 2,
+			wantNames: []string{utils.ProductExpToDev2.Name, utils.ProductExp2.Name},
+		},
+		{
+			desc:      "first page by price",
+			filter:    domain.ProductFilter{SortBy: "price", Limit: 2},
+			wantTotal: 4,
+			wantNames: []string{utils.ProductExpToDev2.Name, utils.ProductExp1.Name},
+			wantNext:  true,
+		},
+		{
+			desc:      "second page by price",
+			filter:    domain.ProductFilter{SortBy: "price", Limit: 2, Cursor: utils.ProductExp1.ID.String()},
+			wantTotal: 4,
+			wantNames: []string{utils.ProductExpToDev1.Name, utils.ProductExp2.Name},
+		},
+		{
+			desc:    "price range sorted by name",
+			filter:  domain.ProductFilter{MinPrice: 2000, SortBy: "name"},
+			wantErr: true,
+		},
+		{
+			desc:    "min price bigger than max price",
+			filter:  domain.ProductFilter{MinPrice: 3000, MaxPrice: 2000},
+			wantErr: true,
+		},
+		{
+			desc:    "unknown sort",
+			filter:  domain.ProductFilter{SortBy: "stock"},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			page, err := svc.GetPage(tC.filter)
+
+			s.Equal(tC.wantErr, (err != nil), "expect error fail")
+
+			if err != nil {
+				s.ErrorIs(err, utils.ErrInvalidFilter, "wrong error")
+				return
+			}
+
+			names := []string{}
+
+			for _, p := range page.Items {
+				names = append(names, p.Name)
+			}
+
+			s.Equal(tC.wantTotal, page.Total, "wrong total")
+			s.Equal(tC.wantNames, names, "wrong products")
+			s.Equal(tC.wantNext, page.Next != "", "wrong next cursor")
+		})
+	}
+}
+
 func (s *ProductServiceSuite) TestProductService_Delete() {
 	testCases := []struct {
 		desc    string
@@ -202,6 +358,11 @@ func (s *ProductServiceSuite) TestProductService_Delete() {
 			s.Equal(tC.wantErr, (err != nil), "expert error fail")
 		})
 	}
+
+	rev, err := s.service.revRepo.FindByID(reviewID(utils.ProductExp1.ID, utils.UserExp1.ID))
+
+	s.NoError(err, "should not be error")
+	s.Nil(rev, "the reviews should go with the product")
 }
 
 func (s *ProductServiceSuite) TestProductService_Update() {
@@ -257,7 +418,7 @@ func (s *ProductServiceSuite) TestProductService_Update() {
 			id:      utils.ProductExp2.ID,
 			uf: domain.UpdateFields{
 				"Name":  "Corsair Void Pro masters",
-				"Price": int64(7777),
+				"Price": domain.NewMoney(7777, utils.DefaultCurrency),
 			},
 			validationFn: func() {
 				p, _ := s.service.prodRepo.FindByID(utils.ProductExp2.ID)
@@ -268,7 +429,7 @@ func (s *ProductServiceSuite) TestProductService_Update() {
 					s.Fail("Should be updated")
 				}
 
-				if p.Price != 7777 {
+				if p.Price.Amount != 7777 {
 					s.Fail("Should be updated")
 				}
 			},
@@ -388,11 +549,286 @@ func (s *ProductServiceSuite) TestProductService_CalculateTotalPrice() {
 
 			s.Equal(tC.wantErr, (err != nil), "bad :(")
 
-			s.Less(tC.wantOutput-got, int64(2), "diference cannot be bigger")
+			s.Equal(tC.wantOutput, got.Amount, "wrong total")
+
+			if !tC.wantErr {
+				s.Equal(utils.DefaultCurrency, got.Currency, "wrong currency")
+
+				var sum int64
+
+				for _, op := range tC.input {
+					s.NotEmpty(op.Name, "should snapshot the name")
+					s.NotZero(op.UnitPrice.Amount, "should snapshot the price")
+					sum += op.LineTotal.Amount
+				}
+
+				s.Equal(got.Amount, sum, "lines should add up to the total")
+			}
+
+			s.T().Logf("\n\n Total Price: %s\n\n", got)
+		})
+	}
+}
+
+func (s *ProductServiceSuite) TestProductService_Index() {
+	indexer := &recordIndexer{indexed: map[uuid.UUID]domain.Product{}}
+
+	svc := &prodService{
+		prodRepo: product.NewMemoryProductRepository(),
+		catRepo:  category.NewMemoryCategoryRepository(utils.CategoryExp1),
+		revRepo:  review.NewMemoryReviewRepository(),
+		indexer:  indexer,
+	}
+
+	prod := domain.Product{
+		Category: utils.CategoryExp1.Name,
+		Name:     "Logitech g435",
+		Price:    domain.NewMoney(1200, utils.DefaultCurrency),
+	}
+
+	s.Require().NoError(svc.Create(&prod), "should not be error")
+	s.Equal(prod.Name, indexer.indexed[prod.ID].Name, "created products should be indexed")
+
+	s.Require().NoError(svc.Update(prod.ID, domain.UpdateFields{"Name": "Logitech g435 lightspeed"}))
+	s.Equal("Logitech g435 lightspeed", indexer.indexed[prod.ID].Name, "the index should get the update")
+
+	s.Require().NoError(svc.SetAvailable(prod.ID, true))
+	s.True(indexer.indexed[prod.ID].Available, "the index should get the availability")
+
+	s.Require().NoError(svc.Delete(prod.ID))
+	s.NotContains(indexer.indexed, prod.ID, "deleted products should leave the index")
+}
+
+func (s *ProductServiceSuite) TestProductService_AddImages() {
+	var wg sync.WaitGroup
+
+	room := utils.MaxProductImages - len(utils.ProductExp1.Images)
+	errs := make([]error, room+3)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = s.service.AddImages(utils.ProductExp1.ID, domain.NewURLImage("https://example.com/tee.png"))
+		}(i)
+	}
+
+	wg.Wait()
+
+	added := 0
+
+	for _, err := range errs {
+		if err == nil {
+			added++
+			continue
+		}
+		s.ErrorIs(err, utils.ErrTooManyImages)
+	}
+
+	s.Equal(room, added, "only the images that fit should be added")
+
+	p, err := s.service.GetByID(utils.ProductExp1.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Len(p.Images, utils.MaxProductImages, "no image should be lost")
+
+	s.ErrorIs(s.service.AddImages(uuid.New(), domain.NewURLImage("https://example.com/tee.png")), utils.ErrNotFound)
+}
+
+func (s *ProductServiceSuite) TestProductService_Images() {
+	uploaded := domain.Image{ID: uuid.New(), Renditions: []domain.ImageRendition{{Name: "medium", Format: "jpeg"}}}
+
+	prod := utils.ProductExp2
+	prod.Images = []domain.Image{uploaded}
+
+	svc := &prodService{
+		prodRepo: product.NewMemoryProductRepository(prod),
+		catRepo:  category.NewMemoryCategoryRepository(),
+		revRepo:  review.NewMemoryReviewRepository(),
+	}
+
+	err := svc.Update(prod.ID, domain.UpdateFields{
+		"Images": []domain.Image{domain.NewURLImage("https://example.com/headset.png")},
+	})
+
+	s.Require().NoError(err, "should not be error")
+
+	p, err := svc.GetByID(prod.ID)
+
+	s.Require().NoError(err)
+	s.Require().Len(p.Images, 2, "the url images should be added to the uploaded one")
+	s.Equal(uploaded.ID, p.Images[0].ID)
+
+	full := make([]domain.Image, utils.MaxProductImages)
+
+	s.ErrorIs(svc.Update(prod.ID, domain.UpdateFields{"Images": full}), utils.ErrTooManyImages)
+
+	removed, err := svc.RemoveImage(prod.ID, uploaded.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(uploaded, *removed, "the removed image should be returned")
+
+	p, err = svc.GetByID(prod.ID)
+
+	s.Require().NoError(err)
+	s.Len(p.Images, 1, "the image should be taken out")
+
+	_, err = svc.RemoveImage(prod.ID, uploaded.ID)
+	s.ErrorIs(err, utils.ErrNotFound)
+}
+
+func (s *ProductServiceSuite) TestProductService_Variants() {
+	indexer := &recordIndexer{indexed: map[uuid.UUID]domain.Product{}}
+
+	svc := &prodService{
+		prodRepo: product.NewMemoryProductRepository(utils.ProductExp1),
+		catRepo:  category.NewMemoryCategoryRepository(utils.CategoryExp1),
+		indexer:  indexer,
+	}
+
+	prod := domain.Product{
+		Category:  utils.CategoryExp1.Name,
+		Name:      "Basic T-Shirt",
+		Price:     domain.NewMoney(1500, utils.DefaultCurrency),
+		Available: true,
+		Options: []domain.ProductOption{
+			{Name: "size", Values: []string{"M", "L"}},
+			{Name: "color", Values: []string{"black", "white"}},
+		},
+	}
+
+	s.Require().NoError(svc.Create(&prod), "should not be error")
 
-			s.Greater(tC.wantOutput-got, int64(-2), "diference cannot be smaller")
+	blackM := domain.ProductVariant{
+		SKU:     "TEE-BLK-M",
+		Options: map[string]string{"size": "M", "color": "black"},
+		Stock:   5,
+	}
+
+	whiteL := domain.ProductVariant{
+		SKU:     "TEE-WHT-L",
+		Options: map[string]string{"size": "L", "color": "white"},
+		Price:   domain.NewMoney(1800, utils.DefaultCurrency),
+		Stock:   2,
+	}
 
-			s.T().Logf("\n\n Total Price: %d\n\n", got)
+	s.Require().NoError(svc.AddVariant(prod.ID, &blackM))
+	s.Require().NoError(svc.AddVariant(prod.ID, &whiteL))
+	s.NotEqual(uuid.Nil, blackM.ID, "the variant should get an id")
+
+	invalid := []struct {
+		desc    string
+		variant domain.ProductVariant
+	}{
+		{desc: "missing option", variant: domain.ProductVariant{SKU: "TEE-M", Options: map[string]string{"size": "M"}}},
+		{desc: "unknown value", variant: domain.ProductVariant{SKU: "TEE-RED-M", Options: map[string]string{"size": "M", "color": "red"}}},
+		{desc: "repeated options", variant: domain.ProductVariant{SKU: "TEE-BLK-M2", Options: map[string]string{"size": "M", "color": "black"}}},
+		{desc: "repeated sku", variant: domain.ProductVariant{SKU: "TEE-BLK-M", Options: map[string]string{"size": "L", "color": "black"}}},
+		{desc: "missing sku", variant: domain.ProductVariant{Options: map[string]string{"size": "L", "color": "black"}}},
+		{desc: "other currency", variant: domain.ProductVariant{SKU: "TEE-BLK-L", Options: map[string]string{"size": "L", "color": "black"}, Price: domain.NewMoney(10, "eur")}},
+	}
+
+	for _, tC := range invalid {
+		s.Run(tC.desc, func() {
+			err := svc.AddVariant(prod.ID, &tC.variant)
+
+			s.ErrorIs(err, utils.ErrInvalidVariant)
 		})
 	}
+
+	s.Run("sku of other product", func() {
+		s.ErrorIs(svc.checkSKU(utils.ProductExp1.ID, "TEE-BLK-M"), utils.ErrInvalidVariant)
+	})
+
+	p, err := svc.GetByID(prod.ID)
+
+	s.Require().NoError(err)
+	s.Len(p.Variants, 2, "only the valid variants should be saved")
+	s.EqualValues(7, p.Stock, "the stock should be the variants stock")
+	s.ElementsMatch([]string{"TEE-BLK-M", "TEE-WHT-L"}, p.SKUs)
+	s.Len(indexer.indexed[prod.ID].Variants, 2, "the index should get the variants")
+
+	s.Run("price the variants", func() {
+		ops := []domain.OrderProduct{
+			{ID: prod.ID, VariantID: blackM.ID, Quantity: 2},
+			{ID: prod.ID, VariantID: whiteL.ID, Quantity: 1},
+		}
+
+		total, err := svc.CalculateTotalPrice(ops)
+
+		s.Require().NoError(err)
+		s.EqualValues(1500*2+1800, total.Amount)
+		s.Equal("M / black", ops[0].Variant)
+		s.Equal("TEE-WHT-L", ops[1].SKU)
+		s.EqualValues(1800, ops[1].UnitPrice.Amount, "should use the variant price")
+
+		_, err = svc.CalculateTotalPrice([]domain.OrderProduct{{ID: prod.ID, Quantity: 1}})
+
+		s.Error(err, "a product with variants needs one")
+	})
+
+	s.Run("adjust the variant stock", func() {
+		err := svc.prodRepo.AdjustStock(map[domain.ProductRef]int64{
+			{ProductID: prod.ID, VariantID: blackM.ID}: -3,
+		})
+
+		s.Require().NoError(err)
+
+		err = svc.prodRepo.AdjustStock(map[domain.ProductRef]int64{
+			{ProductID: prod.ID, VariantID: whiteL.ID}: -3,
+		})
+
+		s.ErrorIs(err, utils.ErrOutOfStock)
+
+		err = svc.prodRepo.AdjustStock(map[domain.ProductRef]int64{
+			{ProductID: prod.ID}: -1,
+		})
+
+		s.ErrorIs(err, utils.ErrOutOfStock, "should pick a variant")
+
+		p, _ := svc.GetByID(prod.ID)
+
+		s.EqualValues(2, p.Variant(blackM.ID).Stock)
+		s.EqualValues(2, p.Variant(whiteL.ID).Stock, "failed changes should not be saved")
+		s.EqualValues(4, p.Stock)
+	})
+
+	s.Run("update and remove", func() {
+		whiteL.Price = domain.Money{}
+		whiteL.Stock = 10
+
+		s.Require().NoError(svc.UpdateVariant(prod.ID, whiteL))
+
+		p, _ := svc.GetByID(prod.ID)
+
+		s.EqualValues(1500, p.VariantPrice(*p.Variant(whiteL.ID)).Amount, "should fall back to the product price")
+		s.EqualValues(12, p.Stock)
+
+		err := svc.Update(prod.ID, domain.UpdateFields{
+			"Options": []domain.ProductOption{{Name: "size", Values: []string{"M", "L"}}},
+		})
+
+		s.ErrorIs(err, utils.ErrInvalidVariant, "the variants should fit the new options")
+		s.ErrorIs(svc.Update(prod.ID, domain.UpdateFields{"Stock": int64(3)}), utils.ErrInvalidVariant)
+
+		s.Require().NoError(svc.RemoveVariant(prod.ID, blackM.ID))
+		s.ErrorIs(svc.RemoveVariant(prod.ID, blackM.ID), utils.ErrNotFound)
+
+		p, _ = svc.GetByID(prod.ID)
+
+		s.Len(p.Variants, 1)
+		s.EqualValues(10, p.Stock)
+		s.Equal([]string{"TEE-WHT-L"}, p.SKUs)
+	})
 }
+
+// Helpers
+
+type recordIndexer struct {
+	indexed map[uuid.UUID]domain.Product
+}
+
+func (i *recordIndexer) Index(prod domain.Product) { i.indexed[prod.ID] = prod }
+func (i *recordIndexer) Remove(ID uuid.UUID)       { delete(i.indexed, ID) }
@@ -0,0 +1,335 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type returnService struct {
+	retRepo  domain.ReturnRepository
+	ordSvc   domain.OrderService
+	prodRepo domain.ProductRepository
+}
+
+func NewReturnService(
+	retRepo domain.ReturnRepository,
+	ordSvc domain.OrderService,
+	prodRepo domain.ProductRepository,
+) domain.ReturnService {
+	return &returnService{
+		retRepo:  retRepo,
+		ordSvc:   ordSvc,
+		prodRepo: prodRepo,
+	}
+}
+
+// Create opens a return for delivered items of an order of the user.
+// Items already in other returns cannot be returned again.
+func (s *returnService) Create(ret *domain.ReturnRequest) error {
+	ord, err := s.ordSvc.GetByID(ret.OrderID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord == nil || ord.UserID != ret.UserID {
+		return fmt.Errorf("%w: order not found", utils.ErrInvalidReturn)
+	}
+
+	if ord.Status != utils.StatusDelivered {
+		return fmt.Errorf("%w: only delivered orders can be returned", utils.ErrInvalidReturn)
+	}
+
+	if strings.TrimSpace(ret.Reason) == "" {
+		return fmt.Errorf("%w: the reason is required", utils.ErrInvalidReturn)
+	}
+
+	if len(ret.Items) == 0 {
+		return fmt.Errorf("%w: missing items", utils.ErrInvalidReturn)
+	}
+
+	returnable, err := s.returnableItems(ord)
+
+	if err != nil {
+		return err
+	}
+
+	for _, it := range ret.Items {
+		if it.Quantity == 0 || it.Quantity > returnable[it.Ref()] {
+			return fmt.Errorf("%w: only %d of product %s can be returned", utils.ErrInvalidReturn, returnable[it.Ref()], it.ProductID)
+		}
+
+		returnable[it.Ref()] -= it.Quantity
+	}
+
+	ID, err := uuid.NewUUID()
+
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %s", err)
+	}
+
+	ret.ID = ID
+	ret.Status = utils.ReturnRequested
+	ret.ModeratorNote = ""
+	ret.RefundedAmount = domain.NewMoney(0, ord.Amount.Currency)
+	ret.Restocked = false
+	ret.CreatedAt = time.Now().Unix()
+	ret.UpdatedAt = time.Now().Unix()
+	ret.StatusHistory = []domain.StatusChange{
+		{Status: utils.ReturnRequested, ChangedAt: ret.CreatedAt},
+	}
+
+	return s.retRepo.Save(ret)
+}
+
+func (s *returnService) GetAll() ([]domain.ReturnRequest, error) {
+	return s.retRepo.Find()
+}
+
+func (s *returnService) GetByID(ID uuid.UUID) (*domain.ReturnRequest, error) {
+	return s.retRepo.FindByID(ID)
+}
+
+func (s *returnService) GetAllByUserID(usrID uuid.UUID) ([]domain.ReturnRequest, error) {
+	return s.retRepo.FindWhere("UserID", "==", usrID)
+}
+
+// StartApproval moves the return from requested to approving in one
+// step, so when it is approved twice at once only one gets to refund.
+func (s *returnService) StartApproval(ID uuid.UUID) error {
+	return s.moveStatus(ID, utils.ReturnRequested, utils.ReturnApproving)
+}
+
+func (s *returnService) CancelApproval(ID uuid.UUID) error {
+	return s.moveStatus(ID, utils.ReturnApproving, utils.ReturnRequested)
+}
+
+// RefundAmount splits what was paid for the products (shipping aside)
+// by the value of the returned lines.
+func (s *returnService) RefundAmount(ID uuid.UUID) (domain.Money, error) {
+	ret, ord, err := s.returnAndOrder(ID, utils.ReturnRequested, utils.ReturnApproving)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	subtotal, returned := domain.Money{}, domain.Money{}
+	pending := make(map[domain.ProductRef]uint)
+
+	for _, it := range ret.Items {
+		pending[it.Ref()] += it.Quantity
+	}
+
+	for _, op := range ord.Products {
+		if subtotal, err = subtotal.Add(op.LineTotal); err != nil {
+			return domain.Money{}, err
+		}
+
+		qty := pending[op.Ref()]
+
+		if qty == 0 || op.Quantity == 0 {
+			continue
+		}
+
+		if qty > op.Quantity {
+			qty = op.Quantity
+		}
+
+		pending[op.Ref()] -= qty
+
+		if returned, err = returned.Add(op.LineTotal.Scale(int64(qty), int64(op.Quantity))); err != nil {
+			return domain.Money{}, err
+		}
+	}
+
+	paid, err := ord.Amount.Sub(ord.ShippingCost)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	amount := paid
+
+	if subtotal.Amount > 0 {
+		amount = paid.Scale(returned.Amount, subtotal.Amount)
+	}
+
+	left, err := s.ordSvc.RefundableAmount(ord.ID)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	//* Never more than what is left to refund
+	if amount.Amount > left.Amount {
+		amount = left
+	}
+
+	return amount, nil
+}
+
+// Approve finishes the approval started by StartApproval.
+func (s *returnService) Approve(ID uuid.UUID, note string, refunded domain.Money) error {
+	if _, _, err := s.returnAndOrder(ID, utils.ReturnApproving); err != nil {
+		return err
+	}
+
+	return s.changeStatus(ID, utils.ReturnApproved, domain.UpdateFields{
+		"ModeratorNote":  note,
+		"RefundedAmount": refunded,
+	})
+}
+
+func (s *returnService) Reject(ID uuid.UUID, note string) error {
+	if _, _, err := s.returnAndOrder(ID, utils.ReturnRequested); err != nil {
+		return err
+	}
+
+	return s.changeStatus(ID, utils.ReturnRejected, domain.UpdateFields{
+		"ModeratorNote": note,
+	})
+}
+
+// Receive records the items arrived. The return is moved first, so
+// when it is received twice at once only one gets to restock. Damaged
+// items are not restocked, nor the products deleted since.
+func (s *returnService) Receive(ID uuid.UUID, restock bool) error {
+	var ret domain.ReturnRequest
+
+	err := s.retRepo.UpdateWith(ID, func(r *domain.ReturnRequest) error {
+		if r.Status != utils.ReturnApproved {
+			return fmt.Errorf("%w: the return is %s", utils.ErrInvalidTransition, r.Status)
+		}
+
+		r.Status = utils.ReturnReceived
+		r.Restocked = restock
+		r.StatusHistory = append(r.StatusHistory, domain.StatusChange{
+			Status:    utils.ReturnReceived,
+			ChangedAt: time.Now().Unix(),
+		})
+
+		ret = *r
+
+		return nil
+	})
+
+	if err != nil || !restock {
+		return err
+	}
+
+	deltas := make(map[domain.ProductRef]int64, len(ret.Items))
+
+	for _, it := range ret.Items {
+		deltas[it.Ref()] += int64(it.Quantity)
+	}
+
+	if err := s.prodRepo.RestoreStock(deltas); err != nil {
+		//* Received anyway, the units are left to be counted by hand
+		if err := s.retRepo.Update(ID, domain.UpdateFields{"Restocked": false}); err != nil {
+			utils.PrintColor("red", "Error clearing the return restock: ", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func (s *returnService) returnAndOrder(ID uuid.UUID, statuses ...string) (*domain.ReturnRequest, *domain.Order, error) {
+	ret, err := s.retRepo.FindByID(ID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ret == nil {
+		return nil, nil, fmt.Errorf("%w: return not found", utils.ErrNotFound)
+	}
+
+	if !utils.ItemInSlice(ret.Status, statuses) {
+		return nil, nil, fmt.Errorf("%w: the return is %s", utils.ErrInvalidTransition, ret.Status)
+	}
+
+	ord, err := s.ordSvc.GetByID(ret.OrderID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ord == nil {
+		return nil, nil, fmt.Errorf("%w: order not found", utils.ErrNotFound)
+	}
+
+	return ret, ord, nil
+}
+
+// returnableItems returns how many units of each product can still be returned.
+func (s *returnService) returnableItems(ord *domain.Order) (map[domain.ProductRef]uint, error) {
+	returnable := make(map[domain.ProductRef]uint)
+
+	for _, op := range ord.Products {
+		returnable[op.Ref()] += op.Quantity
+	}
+
+	rets, err := s.retRepo.FindWhere("OrderID", "==", ord.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rets {
+		if r.Status == utils.ReturnRejected {
+			continue
+		}
+
+		for _, it := range r.Items {
+			if it.Quantity > returnable[it.Ref()] {
+				returnable[it.Ref()] = 0
+				continue
+			}
+			returnable[it.Ref()] -= it.Quantity
+		}
+	}
+
+	return returnable, nil
+}
+
+// moveStatus checks and changes the status atomically. The approving
+// status is not kept in the history, it only lasts for the refund.
+func (s *returnService) moveStatus(ID uuid.UUID, from, to string) error {
+	return s.retRepo.UpdateWith(ID, func(ret *domain.ReturnRequest) error {
+		if ret.Status != from {
+			return fmt.Errorf("%w: the return is %s", utils.ErrInvalidTransition, ret.Status)
+		}
+
+		ret.Status = to
+
+		return nil
+	})
+}
+
+func (s *returnService) changeStatus(ID uuid.UUID, status string, uf domain.UpdateFields) error {
+	ret, err := s.retRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if ret == nil {
+		return fmt.Errorf("%w: return not found", utils.ErrNotFound)
+	}
+
+	uf["Status"] = status
+	uf["StatusHistory"] = append(ret.StatusHistory, domain.StatusChange{
+		Status:    status,
+		ChangedAt: time.Now().Unix(),
+	})
+
+	return s.retRepo.Update(ID, uf)
+}
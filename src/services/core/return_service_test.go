@@ -0,0 +1,244 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/order"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/returns"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReturnServiceSuite struct {
+	suite.Suite
+	service *returnService
+}
+
+func TestReturnServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReturnServiceSuite))
+}
+
+func (s *ReturnServiceSuite) SetupTest() {
+	prodRepo := product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExpToDev1)
+
+	s.service = &returnService{
+		retRepo: returns.NewMemoryReturnRepository(),
+		ordSvc: &orderService{
+			ordRepo:  order.NewMemoryOrderRepository(utils.OrderExp4, utils.OrderExp5),
+			addrRepo: address.NewMemoryAddressRepository(utils.AddrExp1),
+			prodRepo: prodRepo,
+		},
+		prodRepo: prodRepo,
+	}
+}
+
+//* Tests
+
+func (s *ReturnServiceSuite) TestReturnService_Create() {
+	testCases := []struct {
+		desc  string
+		input domain.ReturnRequest
+	}{
+		{
+			desc: "order not delivered",
+			input: domain.ReturnRequest{
+				OrderID: utils.OrderExp4.ID,
+				UserID:  utils.UserExp1.ID,
+				Reason:  "wrong size",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 1}},
+			},
+		},
+		{
+			desc: "order of other user",
+			input: domain.ReturnRequest{
+				OrderID: utils.OrderExp5.ID,
+				UserID:  utils.UserExp2.ID,
+				Reason:  "wrong size",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 1}},
+			},
+		},
+		{
+			desc: "without reason",
+			input: domain.ReturnRequest{
+				OrderID: utils.OrderExp5.ID,
+				UserID:  utils.UserExp1.ID,
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 1}},
+			},
+		},
+		{
+			desc: "more items than ordered",
+			input: domain.ReturnRequest{
+				OrderID: utils.OrderExp5.ID,
+				UserID:  utils.UserExp1.ID,
+				Reason:  "wrong size",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 3}},
+			},
+		},
+		{
+			desc: "product not in the order",
+			input: domain.ReturnRequest{
+				OrderID: utils.OrderExp5.ID,
+				UserID:  utils.UserExp1.ID,
+				Reason:  "wrong size",
+				Items:   []domain.ReturnItem{{ProductID: utils.ProductExp2.ID, Quantity: 1}},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			s.True(errors.Is(err, utils.ErrInvalidReturn), "should be an invalid return error")
+		})
+	}
+}
+
+func (s *ReturnServiceSuite) TestReturnService_Workflow() {
+	ret := s.newReturn(1)
+
+	s.Equal(utils.ReturnRequested, ret.Status, "wrong status")
+	s.Error(s.service.Create(&domain.ReturnRequest{
+		OrderID: utils.OrderExp5.ID,
+		UserID:  utils.UserExp1.ID,
+		Reason:  "wrong size",
+		Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: 2}},
+	}), "one item is already being returned")
+
+	s.Error(s.service.Receive(ret.ID, true), "the return has not been approved")
+	s.Error(s.service.Approve(ret.ID, "ok", domain.Money{}), "the approval has not been started")
+
+	s.Require().NoError(s.service.StartApproval(ret.ID), "should not be error")
+	s.ErrorIs(s.service.StartApproval(ret.ID), utils.ErrInvalidTransition, "only one approval at a time")
+	s.Error(s.service.Reject(ret.ID, "no"), "the return is being approved")
+
+	amount, err := s.service.RefundAmount(ret.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(2064), amount.Amount, "wrong refund amount")
+
+	s.Require().NoError(s.service.Approve(ret.ID, "ok", amount), "should not be error")
+	s.Error(s.service.Reject(ret.ID, "no"), "the return has been approved")
+
+	s.Require().NoError(s.service.Receive(ret.ID, true), "should not be error")
+
+	got, err := s.service.GetByID(ret.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(utils.ReturnReceived, got.Status, "wrong status")
+	s.Equal(amount, got.RefundedAmount, "wrong refunded amount")
+	s.True(got.Restocked, "should be restocked")
+	s.Len(got.StatusHistory, 3, "wrong history")
+
+	prod, err := s.service.prodRepo.FindByID(utils.ProductExp1.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(utils.ProductExp1.Stock+1, prod.Stock, "the item should be back in stock")
+}
+
+func (s *ReturnServiceSuite) TestReturnService_Receive_Concurrent() {
+	ret := s.approvedReturn(2)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 5)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.service.Receive(ret.ID, true)
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	received := 0
+
+	for err := range errs {
+		if err == nil {
+			received++
+			continue
+		}
+		s.ErrorIs(err, utils.ErrInvalidTransition, "wrong error")
+	}
+
+	s.Equal(1, received, "only one receive should pass")
+
+	prod, err := s.service.prodRepo.FindByID(utils.ProductExp1.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(utils.ProductExp1.Stock+2, prod.Stock, "the items should be restocked once")
+}
+
+func (s *ReturnServiceSuite) TestReturnService_Receive_DeletedProduct() {
+	ret := s.approvedReturn(2)
+
+	s.Require().NoError(s.service.prodRepo.Remove(utils.ProductExp1.ID))
+
+	s.Require().NoError(s.service.Receive(ret.ID, true), "deleted products are skipped")
+
+	got, err := s.service.GetByID(ret.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(utils.ReturnReceived, got.Status, "wrong status")
+}
+
+func (s *ReturnServiceSuite) TestReturnService_CancelApproval() {
+	ret := s.newReturn(2)
+
+	s.Error(s.service.CancelApproval(ret.ID), "the approval has not been started")
+	s.Require().NoError(s.service.StartApproval(ret.ID), "should not be error")
+	s.Require().NoError(s.service.CancelApproval(ret.ID), "should not be error")
+
+	got, err := s.service.GetByID(ret.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(utils.ReturnRequested, got.Status, "the return should be requested again")
+	s.Len(got.StatusHistory, 1, "the approval should not be in the history")
+
+	s.Require().NoError(s.service.Reject(ret.ID, "used item"), "should not be error")
+}
+
+func (s *ReturnServiceSuite) TestReturnService_Reject() {
+	ret := s.newReturn(2)
+
+	s.Require().NoError(s.service.Reject(ret.ID, "used item"), "should not be error")
+
+	//* Rejected items can be asked for again
+	again := s.newReturn(2)
+
+	rets, err := s.service.GetAllByUserID(utils.UserExp1.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Len(rets, 2, "wrong returns")
+	s.NotEqual(ret.ID, again.ID, "should be another return")
+}
+
+// Helper functions
+
+func (s *ReturnServiceSuite) approvedReturn(qty uint) domain.ReturnRequest {
+	ret := s.newReturn(qty)
+
+	s.Require().NoError(s.service.StartApproval(ret.ID), "should not be error")
+	s.Require().NoError(s.service.Approve(ret.ID, "ok", domain.Money{}), "should not be error")
+
+	return ret
+}
+
+func (s *ReturnServiceSuite) newReturn(qty uint) domain.ReturnRequest {
+	ret := domain.ReturnRequest{
+		OrderID: utils.OrderExp5.ID,
+		UserID:  utils.UserExp1.ID,
+		Reason:  "wrong size",
+		Items:   []domain.ReturnItem{{ProductID: utils.ProductExp1.ID, Quantity: qty}},
+	}
+
+	s.Require().NoError(s.service.Create(&ret), "should not be error")
+
+	return ret
+}
@@ -0,0 +1,222 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type reviewService struct {
+	revRepo  domain.ReviewRepository
+	ordRepo  domain.OrderRepository
+	prodRepo domain.ProductRepository
+	userRepo domain.UserRepository
+}
+
+func NewReviewService(
+	revRepo domain.ReviewRepository,
+	ordRepo domain.OrderRepository,
+	prodRepo domain.ProductRepository,
+	userRepo domain.UserRepository,
+) domain.ReviewService {
+	return &reviewService{
+		revRepo:  revRepo,
+		ordRepo:  ordRepo,
+		prodRepo: prodRepo,
+		userRepo: userRepo,
+	}
+}
+
+// Create only takes one review per user and product, and only
+// from users with a paid order that has the product.
+func (s *reviewService) Create(rev *domain.Review) error {
+	if rev.Rating < 1 || rev.Rating > 5 {
+		return fmt.Errorf("the rating must be between 1 and 5")
+	}
+
+	p, err := s.prodRepo.FindByID(rev.ProductID)
+
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		return fmt.Errorf("%w: product %s", utils.ErrNotFound, rev.ProductID)
+	}
+
+	usr, err := s.userRepo.FindByID(rev.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	if usr == nil {
+		return fmt.Errorf("%w: user %s", utils.ErrNotFound, rev.UserID)
+	}
+
+	if err := s.checkPurchase(rev.UserID, rev.ProductID); err != nil {
+		return err
+	}
+
+	ID := reviewID(rev.ProductID, rev.UserID)
+
+	if r, err := s.revRepo.FindByID(ID); err != nil {
+		return err
+	} else if r != nil {
+		return fmt.Errorf("%w: %q", utils.ErrAlreadyReviewed, p.Name)
+	}
+
+	rev.ID = ID
+	rev.Author = usr.Username
+	rev.Hidden = false
+	rev.CreatedAt = time.Now().Unix()
+	rev.UpdatedAt = time.Now().Unix()
+
+	if err := s.revRepo.Save(rev); err != nil {
+		//* Another request saved the same review first
+		if r, _ := s.revRepo.FindByID(ID); r != nil {
+			return fmt.Errorf("%w: %q", utils.ErrAlreadyReviewed, p.Name)
+		}
+		return err
+	}
+
+	return s.updateRating(rev.ProductID)
+}
+
+func (s *reviewService) GetByID(ID uuid.UUID) (*domain.Review, error) {
+	return s.revRepo.FindByID(ID)
+}
+
+// GetAllByProductID lists the newest reviews first.
+func (s *reviewService) GetAllByProductID(prodID uuid.UUID, withHidden bool) ([]domain.Review, error) {
+	revs, err := s.revRepo.FindWhere("ProductID", "==", prodID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]domain.Review, 0, len(revs))
+
+	for _, r := range revs {
+		if withHidden || !r.Hidden {
+			visible = append(visible, r)
+		}
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		return visible[i].CreatedAt > visible[j].CreatedAt
+	})
+
+	return visible, nil
+}
+
+func (s *reviewService) SetHidden(ID uuid.UUID, hidden bool) error {
+	rev, err := s.revRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if rev == nil {
+		return fmt.Errorf("%w: review %s", utils.ErrNotFound, ID)
+	}
+
+	if err := s.revRepo.Update(ID, domain.UpdateFields{"Hidden": hidden}); err != nil {
+		return err
+	}
+
+	return s.updateRating(rev.ProductID)
+}
+
+func (s *reviewService) Delete(ID uuid.UUID) error {
+	rev, err := s.revRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if rev == nil {
+		return fmt.Errorf("%w: review %s", utils.ErrNotFound, ID)
+	}
+
+	if err := s.revRepo.Remove(ID); err != nil {
+		return err
+	}
+
+	return s.updateRating(rev.ProductID)
+}
+
+// Helper functions
+
+// reviewID is the same for the same user and product, so saving a
+// second review of the product fails.
+func reviewID(prodID, usrID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(prodID, usrID[:])
+}
+
+// checkPurchase looks for a paid order of the user with the product.
+// The cancelled and refunded orders do not count.
+func (s *reviewService) checkPurchase(usrID, prodID uuid.UUID) error {
+	ords, err := s.ordRepo.FindWhere("UserID", "==", usrID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, ord := range ords {
+		if !ord.Paid || ord.Status == utils.StatusCancelled || ord.Status == utils.StatusRefunded {
+			continue
+		}
+
+		for _, op := range ord.Products {
+			if op.ID == prodID {
+				return nil
+			}
+		}
+	}
+
+	return utils.ErrNotPurchased
+}
+
+// updateRating recounts the visible reviews, so the product
+// rating can not drift from them. The recount runs inside the
+// update of the product, so concurrent recounts can not overwrite
+// a newer one.
+func (s *reviewService) updateRating(prodID uuid.UUID) error {
+	err := s.prodRepo.UpdateWith(prodID, func(p *domain.Product) error {
+		revs, err := s.GetAllByProductID(prodID, false)
+
+		if err != nil {
+			return err
+		}
+
+		var sum int64
+
+		for _, r := range revs {
+			sum += r.Rating
+		}
+
+		p.RatingAvg = 0
+
+		if len(revs) > 0 {
+			p.RatingAvg = math.Round(float64(sum)/float64(len(revs))*100) / 100
+		}
+
+		p.RatingCount = int64(len(revs))
+
+		return nil
+	})
+
+	//* The product may be deleted already
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
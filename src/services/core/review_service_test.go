@@ -0,0 +1,171 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/order"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/review"
+	"github.com/ZaphCode/clean-arch/src/repositories/user"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewServiceSuite struct {
+	suite.Suite
+	service *reviewService
+}
+
+func TestReviewServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceSuite))
+}
+
+func (s *ReviewServiceSuite) SetupTest() {
+	//* The UserExp2 bought the products too, but the order was cancelled
+	cancelled := utils.OrderExp4
+	cancelled.ID = uuid.New()
+	cancelled.UserID = utils.UserExp2.ID
+	cancelled.Status = utils.StatusCancelled
+
+	s.service = &reviewService{
+		revRepo:  review.NewMemoryReviewRepository(),
+		ordRepo:  order.NewMemoryOrderRepository(utils.OrderExp1, utils.OrderExp4, cancelled),
+		prodRepo: product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExp2, utils.ProductExpToDev1),
+		userRepo: user.NewMemoryUserRepository(utils.UserExp1, utils.UserExp2),
+	}
+}
+
+//* Tests
+
+func (s *ReviewServiceSuite) TestReviewService_Create() {
+	testCases := []struct {
+		desc    string
+		input   domain.Review
+		wantErr error
+	}{
+		{
+			desc:    "invalid rating",
+			input:   domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp1.ID, Rating: 6},
+			wantErr: nil,
+		},
+		{
+			desc:    "product not found",
+			input:   domain.Review{ProductID: uuid.New(), UserID: utils.UserExp1.ID, Rating: 4},
+			wantErr: utils.ErrNotFound,
+		},
+		{
+			desc:    "product not bought",
+			input:   domain.Review{ProductID: utils.ProductExp2.ID, UserID: utils.UserExp1.ID, Rating: 4},
+			wantErr: utils.ErrNotPurchased,
+		},
+		{
+			desc:    "order cancelled",
+			input:   domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp2.ID, Rating: 4},
+			wantErr: utils.ErrNotPurchased,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			s.Error(err, "should be error")
+
+			if tC.wantErr != nil {
+				s.ErrorIs(err, tC.wantErr)
+			}
+		})
+	}
+
+	rev := domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp1.ID, Rating: 4, Title: "Nice"}
+
+	s.Require().NoError(s.service.Create(&rev), "should not be error")
+	s.Equal(utils.UserExp1.Username, rev.Author, "should keep the author name")
+
+	again := domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp1.ID, Rating: 2}
+
+	s.ErrorIs(s.service.Create(&again), utils.ErrAlreadyReviewed)
+}
+
+func (s *ReviewServiceSuite) TestReviewService_Create_Concurrent() {
+	var wg sync.WaitGroup
+
+	errs := make([]error, 8)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = s.service.Create(&domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp1.ID, Rating: 4})
+		}(i)
+	}
+
+	wg.Wait()
+
+	saved := 0
+
+	for _, err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		s.ErrorIs(err, utils.ErrAlreadyReviewed)
+	}
+
+	s.Equal(1, saved, "only one review should be saved")
+
+	revs, err := s.service.GetAllByProductID(utils.ProductExp1.ID, true)
+
+	s.Require().NoError(err, "should not be error")
+	s.Len(revs, 1, "the user should have one review")
+	s.checkRating(4, 1)
+}
+
+func (s *ReviewServiceSuite) TestReviewService_Rating() {
+	s.Require().NoError(s.service.ordRepo.UpdateField(utils.OrderExp1.ID, "UserID", utils.UserExp2.ID))
+	s.Require().NoError(s.service.ordRepo.Update(utils.OrderExp1.ID, domain.UpdateFields{
+		"Paid": true, "Status": utils.StatusDelivered,
+	}))
+
+	first := domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp1.ID, Rating: 5}
+	second := domain.Review{ProductID: utils.ProductExp1.ID, UserID: utils.UserExp2.ID, Rating: 2}
+
+	s.Require().NoError(s.service.Create(&first))
+	s.Require().NoError(s.service.Create(&second))
+
+	s.checkRating(3.5, 2)
+
+	s.Require().NoError(s.service.SetHidden(second.ID, true))
+	s.checkRating(5, 1)
+
+	revs, err := s.service.GetAllByProductID(utils.ProductExp1.ID, false)
+
+	s.Require().NoError(err)
+	s.Len(revs, 1, "the hidden review should not be listed")
+
+	revs, err = s.service.GetAllByProductID(utils.ProductExp1.ID, true)
+
+	s.Require().NoError(err)
+	s.Len(revs, 2, "moderators should see the hidden review")
+
+	s.Require().NoError(s.service.SetHidden(second.ID, false))
+	s.Require().NoError(s.service.Delete(first.ID))
+	s.checkRating(2, 1)
+
+	s.ErrorIs(s.service.Delete(first.ID), utils.ErrNotFound)
+	s.ErrorIs(s.service.SetHidden(uuid.New(), true), utils.ErrNotFound)
+}
+
+// Helpers
+
+func (s *ReviewServiceSuite) checkRating(avg float64, count int64) {
+	p, err := s.service.prodRepo.FindByID(utils.ProductExp1.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(avg, p.RatingAvg, "wrong average")
+	s.Equal(count, p.RatingCount, "wrong count")
+}
@@ -0,0 +1,283 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type shipmentService struct {
+	shpRepo domain.ShipmentRepository
+	ordSvc  domain.OrderService
+}
+
+func NewShipmentService(
+	shpRepo domain.ShipmentRepository,
+	ordSvc domain.OrderService,
+) domain.ShipmentService {
+	return &shipmentService{
+		shpRepo: shpRepo,
+		ordSvc:  ordSvc,
+	}
+}
+
+// Create records a shipment of the order. Without items it
+// ships everything that has not been shipped yet.
+func (s *shipmentService) Create(shp *domain.Shipment) error {
+	ord, err := s.ordSvc.GetByID(shp.OrderID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord == nil {
+		return fmt.Errorf("order not found")
+	}
+
+	if ord.Status != utils.StatusPaid && ord.Status != utils.StatusProcessing {
+		return fmt.Errorf("%w: %s orders cannot be shipped", utils.ErrInvalidShipment, ord.Status)
+	}
+
+	pending, err := s.pendingItems(ord)
+
+	if err != nil {
+		return err
+	}
+
+	if len(shp.Items) == 0 {
+		added := make(map[domain.ProductRef]bool)
+
+		for _, op := range ord.Products {
+			if qty := pending[op.Ref()]; qty > 0 && !added[op.Ref()] {
+				shp.Items = append(shp.Items, domain.ShipmentItem{ProductID: op.ID, VariantID: op.VariantID, Quantity: qty})
+				added[op.Ref()] = true
+			}
+		}
+	}
+
+	if len(shp.Items) == 0 {
+		return fmt.Errorf("%w: every item has been shipped", utils.ErrInvalidShipment)
+	}
+
+	for _, it := range shp.Items {
+		if it.Quantity == 0 {
+			return fmt.Errorf("%w: invalid quantity for product %s", utils.ErrInvalidShipment, it.ProductID)
+		}
+
+		if it.Quantity > pending[it.Ref()] {
+			return fmt.Errorf("%w: only %d of product %s left to ship", utils.ErrInvalidShipment, pending[it.Ref()], it.ProductID)
+		}
+
+		pending[it.Ref()] -= it.Quantity
+	}
+
+	if shp.ShippedAt == 0 {
+		shp.ShippedAt = time.Now().Unix()
+	}
+
+	if err := validateShipment(shp); err != nil {
+		return err
+	}
+
+	ID, err := uuid.NewUUID()
+
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %s", err)
+	}
+
+	shp.ID = ID
+	shp.CreatedAt = time.Now().Unix()
+	shp.UpdatedAt = time.Now().Unix()
+
+	if err := s.shpRepo.Save(shp); err != nil {
+		return err
+	}
+
+	return s.syncOrderStatus(shp.OrderID)
+}
+
+func (s *shipmentService) GetAll() ([]domain.Shipment, error) {
+	return s.shpRepo.Find()
+}
+
+func (s *shipmentService) GetByID(ID uuid.UUID) (*domain.Shipment, error) {
+	return s.shpRepo.FindByID(ID)
+}
+
+func (s *shipmentService) GetAllByOrderID(ordID uuid.UUID) ([]domain.Shipment, error) {
+	return s.shpRepo.FindWhere("OrderID", "==", ordID)
+}
+
+func (s *shipmentService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
+	shp, err := s.shpRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if shp == nil {
+		return fmt.Errorf("shipment not found")
+	}
+
+	//* The order and the items cannot change
+	delete(uf, "OrderID")
+	delete(uf, "Items")
+	delete(uf, "Model")
+
+	updated := *shp
+
+	if err := utils.UpdateStructFields(&updated, uf); err != nil {
+		return err
+	}
+
+	if err := validateShipment(&updated); err != nil {
+		return err
+	}
+
+	if err := s.shpRepo.Update(ID, uf); err != nil {
+		return err
+	}
+
+	return s.syncOrderStatus(shp.OrderID)
+}
+
+// Delete removes a shipment recorded by mistake. It is only possible
+// while the order has items left to ship.
+func (s *shipmentService) Delete(ID uuid.UUID) error {
+	shp, err := s.shpRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if shp == nil {
+		return fmt.Errorf("shipment not found")
+	}
+
+	ord, err := s.ordSvc.GetByID(shp.OrderID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord != nil && ord.Status != utils.StatusProcessing {
+		return fmt.Errorf("%w: the order is already %s", utils.ErrInvalidShipment, ord.Status)
+	}
+
+	return s.shpRepo.Remove(ID)
+}
+
+// Helper functions
+
+// pendingItems returns how many units of each product, or variant,
+// are left to ship.
+func (s *shipmentService) pendingItems(ord *domain.Order) (map[domain.ProductRef]uint, error) {
+	pending := make(map[domain.ProductRef]uint)
+
+	for _, op := range ord.Products {
+		pending[op.Ref()] += op.Quantity
+	}
+
+	shps, err := s.GetAllByOrderID(ord.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, shp := range shps {
+		for _, it := range shp.Items {
+			if it.Quantity > pending[it.Ref()] {
+				pending[it.Ref()] = 0
+				continue
+			}
+			pending[it.Ref()] -= it.Quantity
+		}
+	}
+
+	return pending, nil
+}
+
+// syncOrderStatus walks the order through its lifecycle: processing
+// with the first shipment, shipped when nothing is left and delivered
+// when every shipment has arrived.
+func (s *shipmentService) syncOrderStatus(ordID uuid.UUID) error {
+	ord, err := s.ordSvc.GetByID(ordID)
+
+	if err != nil {
+		return err
+	}
+
+	if ord == nil {
+		return fmt.Errorf("order not found")
+	}
+
+	pending, err := s.pendingItems(ord)
+
+	if err != nil {
+		return err
+	}
+
+	allShipped := true
+
+	for _, qty := range pending {
+		if qty > 0 {
+			allShipped = false
+		}
+	}
+
+	shps, err := s.GetAllByOrderID(ordID)
+
+	if err != nil {
+		return err
+	}
+
+	allDelivered := len(shps) > 0
+
+	for _, shp := range shps {
+		if shp.DeliveredAt == 0 {
+			allDelivered = false
+		}
+	}
+
+	steps := []string{}
+
+	switch ord.Status {
+	case utils.StatusPaid:
+		steps = append(steps, utils.StatusProcessing)
+		fallthrough
+	case utils.StatusProcessing:
+		if !allShipped {
+			break
+		}
+		steps = append(steps, utils.StatusShipped)
+		fallthrough
+	case utils.StatusShipped:
+		if allShipped && allDelivered {
+			steps = append(steps, utils.StatusDelivered)
+		}
+	}
+
+	for _, status := range steps {
+		if err := s.ordSvc.UpdateStatus(ordID, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateShipment(shp *domain.Shipment) error {
+	if strings.TrimSpace(shp.Carrier) == "" || strings.TrimSpace(shp.TrackingNumber) == "" {
+		return fmt.Errorf("%w: it needs a carrier and a tracking number", utils.ErrInvalidShipment)
+	}
+
+	if shp.DeliveredAt != 0 && shp.DeliveredAt < shp.ShippedAt {
+		return fmt.Errorf("%w: it cannot be delivered before it was shipped", utils.ErrInvalidShipment)
+	}
+
+	return nil
+}
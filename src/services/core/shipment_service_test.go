@@ -0,0 +1,213 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/order"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipment"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShipmentServiceSuite struct {
+	suite.Suite
+	service *shipmentService
+}
+
+func TestShipmentServiceSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentServiceSuite))
+}
+
+func (s *ShipmentServiceSuite) SetupTest() {
+	s.service = &shipmentService{
+		shpRepo: shipment.NewMemoryShipmentRepository(),
+		ordSvc: &orderService{
+			ordRepo:  order.NewMemoryOrderRepository(utils.OrderExp1, utils.OrderExp4),
+			addrRepo: address.NewMemoryAddressRepository(utils.AddrExp1),
+			prodRepo: product.NewMemoryProductRepository(utils.ProductExp1, utils.ProductExpToDev1),
+		},
+	}
+}
+
+//* Tests
+
+func (s *ShipmentServiceSuite) TestShipmentService_Create() {
+	testCases := []struct {
+		desc    string
+		input   domain.Shipment
+		wantErr bool
+	}{
+		{
+			desc: "order not paid",
+			input: domain.Shipment{
+				OrderID:        utils.OrderExp1.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456784",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "without tracking number",
+			input: domain.Shipment{
+				OrderID: utils.OrderExp4.ID,
+				Carrier: "UPS",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "more items than ordered",
+			input: domain.Shipment{
+				OrderID:        utils.OrderExp4.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456784",
+				Items: []domain.ShipmentItem{
+					{ProductID: utils.ProductExp1.ID, Quantity: 3},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "product not in the order",
+			input: domain.Shipment{
+				OrderID:        utils.OrderExp4.ID,
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999AA10123456784",
+				Items: []domain.ShipmentItem{
+					{ProductID: utils.ProductExp2.ID, Quantity: 1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			s.True(errors.Is(err, utils.ErrInvalidShipment), "should be an invalid shipment error")
+		})
+	}
+}
+
+func (s *ShipmentServiceSuite) TestShipmentService_PartialShipments() {
+	first := domain.Shipment{
+		OrderID:        utils.OrderExp4.ID,
+		Carrier:        "UPS",
+		TrackingNumber: "1Z999AA10123456784",
+		Items: []domain.ShipmentItem{
+			{ProductID: utils.ProductExp1.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&first), "should not be error")
+	s.NotZero(first.ShippedAt, "should be shipped now")
+	s.orderStatus(utils.StatusProcessing, "some items are left")
+
+	rest := domain.Shipment{
+		OrderID:        utils.OrderExp4.ID,
+		Carrier:        "FedEx",
+		TrackingNumber: "449044304137821",
+	}
+
+	s.Require().NoError(s.service.Create(&rest), "should not be error")
+	s.Len(rest.Items, 2, "should ship everything left")
+	s.orderStatus(utils.StatusShipped, "every item has been shipped")
+
+	s.Error(s.service.Create(&domain.Shipment{
+		OrderID:        utils.OrderExp4.ID,
+		Carrier:        "FedEx",
+		TrackingNumber: "449044304137822",
+	}), "nothing left to ship")
+
+	s.Error(s.service.Delete(first.ID), "the order has been shipped")
+
+	now := time.Now().Unix()
+
+	s.Require().NoError(s.service.Update(first.ID, domain.UpdateFields{"DeliveredAt": now}), "should not be error")
+	s.orderStatus(utils.StatusShipped, "a shipment is on its way")
+
+	s.Error(s.service.Update(rest.ID, domain.UpdateFields{"DeliveredAt": rest.ShippedAt - 60}), "delivered before shipped")
+
+	s.Require().NoError(s.service.Update(rest.ID, domain.UpdateFields{"DeliveredAt": now}), "should not be error")
+	s.orderStatus(utils.StatusDelivered, "every shipment has arrived")
+
+	shps, err := s.service.GetAllByOrderID(utils.OrderExp4.ID)
+
+	s.NoError(err, "should not be error")
+	s.Len(shps, 2, "wrong shipments")
+}
+
+func (s *ShipmentServiceSuite) TestShipmentService_Variants() {
+	blackM, whiteL := uuid.New(), uuid.New()
+
+	ord := utils.OrderExp4
+	ord.ID = uuid.New()
+	ord.Products = []domain.OrderProduct{
+		{ID: utils.ProductExp1.ID, VariantID: blackM, Quantity: 2},
+		{ID: utils.ProductExp1.ID, VariantID: whiteL, Quantity: 1},
+	}
+
+	s.Require().NoError(s.service.ordSvc.(*orderService).ordRepo.Save(&ord), "should not be error")
+
+	s.Require().NoError(s.service.Create(&domain.Shipment{
+		OrderID:        ord.ID,
+		Carrier:        "UPS",
+		TrackingNumber: "1Z999AA10123456784",
+		Items: []domain.ShipmentItem{
+			{ProductID: utils.ProductExp1.ID, VariantID: blackM, Quantity: 2},
+		},
+	}), "should not be error")
+
+	err := s.service.Create(&domain.Shipment{
+		OrderID:        ord.ID,
+		Carrier:        "UPS",
+		TrackingNumber: "1Z999AA10123456785",
+		Items: []domain.ShipmentItem{
+			{ProductID: utils.ProductExp1.ID, VariantID: blackM, Quantity: 1},
+		},
+	})
+
+	s.True(errors.Is(err, utils.ErrInvalidShipment), "the other variant does not count for this one")
+
+	rest := domain.Shipment{
+		OrderID:        ord.ID,
+		Carrier:        "FedEx",
+		TrackingNumber: "449044304137821",
+	}
+
+	s.Require().NoError(s.service.Create(&rest), "should not be error")
+	s.Equal([]domain.ShipmentItem{
+		{ProductID: utils.ProductExp1.ID, VariantID: whiteL, Quantity: 1},
+	}, rest.Items, "should ship the variant left")
+}
+
+func (s *ShipmentServiceSuite) TestShipmentService_Delete() {
+	shp := domain.Shipment{
+		OrderID:        utils.OrderExp4.ID,
+		Carrier:        "UPS",
+		TrackingNumber: "1Z999AA10123456784",
+		Items: []domain.ShipmentItem{
+			{ProductID: utils.ProductExpToDev1.ID, Quantity: 1},
+		},
+	}
+
+	s.Require().NoError(s.service.Create(&shp), "should not be error")
+	s.Require().NoError(s.service.Delete(shp.ID), "should not be error")
+
+	s.Require().NoError(s.service.Create(&shp), "the items can be shipped again")
+	s.Equal(utils.ProductExpToDev1.ID, shp.Items[0].ProductID, "wrong item")
+}
+
+// Helper functions
+
+func (s *ShipmentServiceSuite) orderStatus(want, msg string) {
+	ord, err := s.service.ordSvc.GetByID(utils.OrderExp4.ID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(want, ord.Status, msg)
+}
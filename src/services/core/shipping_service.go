@@ -0,0 +1,292 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+type shippingService struct {
+	shipRepo domain.ShippingRepository
+	addrRepo domain.AddressRepository
+	prodRepo domain.ProductRepository
+}
+
+func NewShippingService(
+	shipRepo domain.ShippingRepository,
+	addrRepo domain.AddressRepository,
+	prodRepo domain.ProductRepository,
+) domain.ShippingService {
+	return &shippingService{
+		shipRepo: shipRepo,
+		addrRepo: addrRepo,
+		prodRepo: prodRepo,
+	}
+}
+
+func (s *shippingService) Create(sm *domain.ShippingMethod) error {
+	sm.Code = strings.ToLower(sm.Code)
+
+	if err := validateShippingMethod(sm); err != nil {
+		return err
+	}
+
+	m, err := s.GetByCode(sm.Code)
+
+	if err != nil {
+		return err
+	}
+
+	if m != nil {
+		return fmt.Errorf("the shipping method %q already exists", sm.Code)
+	}
+
+	ID, err := uuid.NewUUID()
+
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %s", err)
+	}
+
+	sm.ID = ID
+	sm.CreatedAt = time.Now().Unix()
+	sm.UpdatedAt = time.Now().Unix()
+
+	return s.shipRepo.Save(sm)
+}
+
+func (s *shippingService) GetAll() ([]domain.ShippingMethod, error) {
+	return s.shipRepo.Find()
+}
+
+func (s *shippingService) GetByID(ID uuid.UUID) (*domain.ShippingMethod, error) {
+	return s.shipRepo.FindByID(ID)
+}
+
+func (s *shippingService) GetByCode(code string) (*domain.ShippingMethod, error) {
+	return s.shipRepo.FindByField("Code", strings.ToLower(code))
+}
+
+func (s *shippingService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
+	sm, err := s.shipRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if sm == nil {
+		return fmt.Errorf("shipping method not found")
+	}
+
+	//* Check the method as it would be after the update
+	updated := *sm
+
+	if err := utils.UpdateStructFields(&updated, uf); err != nil {
+		return err
+	}
+
+	if err := validateShippingMethod(&updated); err != nil {
+		return err
+	}
+
+	return s.shipRepo.Update(ID, uf)
+}
+
+func (s *shippingService) Delete(ID uuid.UUID) error {
+	sm, err := s.shipRepo.FindByID(ID)
+
+	if err != nil {
+		return err
+	}
+
+	if sm == nil {
+		return fmt.Errorf("shipping method not found")
+	}
+
+	return s.shipRepo.Remove(ID)
+}
+
+func (s *shippingService) Quote(addrID uuid.UUID, ops []domain.OrderProduct) ([]domain.ShippingQuote, error) {
+	addr, weight, err := s.destinationAndWeight(addrID, ops)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sms, err := s.shipRepo.Find()
+
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := []domain.ShippingQuote{}
+
+	for _, sm := range sms {
+		if !sm.Active {
+			continue
+		}
+
+		rate := matchShippingRate(&sm, addr, weight)
+
+		if rate == nil {
+			continue
+		}
+
+		quotes = append(quotes, domain.ShippingQuote{
+			Method: sm.Code,
+			Name:   sm.Name,
+			Cost:   rate.Price,
+		})
+	}
+
+	//* Cheapest first
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Cost.Amount < quotes[j].Cost.Amount
+	})
+
+	return quotes, nil
+}
+
+func (s *shippingService) QuoteMethod(code string, addrID uuid.UUID, ops []domain.OrderProduct) (domain.Money, error) {
+	sm, err := s.GetByCode(code)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	if sm == nil || !sm.Active {
+		return domain.Money{}, fmt.Errorf("%w: unknown method %q", utils.ErrNoShipping, code)
+	}
+
+	addr, weight, err := s.destinationAndWeight(addrID, ops)
+
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	rate := matchShippingRate(sm, addr, weight)
+
+	if rate == nil {
+		return domain.Money{}, fmt.Errorf("%w: %q does not ship there", utils.ErrNoShipping, sm.Code)
+	}
+
+	return rate.Price, nil
+}
+
+// Helper functions
+
+// destinationAndWeight returns the address and the total weight in grams.
+func (s *shippingService) destinationAndWeight(addrID uuid.UUID, ops []domain.OrderProduct) (*domain.Address, int64, error) {
+	addr, err := s.addrRepo.FindByID(addrID)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if addr == nil {
+		return nil, 0, fmt.Errorf("%w: address not found", utils.ErrNoShipping)
+	}
+
+	var weight int64
+
+	for _, op := range ops {
+		p, err := s.prodRepo.FindByID(op.ID)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if p == nil {
+			return nil, 0, fmt.Errorf("%w: product %s not found", utils.ErrNoShipping, op.ID)
+		}
+
+		weight += p.Weight * int64(op.Quantity)
+	}
+
+	return addr, weight, nil
+}
+
+// matchShippingRate picks the rate of the most specific destination,
+// falling back to broader ones when the weight does not fit.
+func matchShippingRate(sm *domain.ShippingMethod, addr *domain.Address, weight int64) *domain.ShippingRate {
+	var best *domain.ShippingRate
+	bestScore := -1
+
+	for i := range sm.Rates {
+		r := &sm.Rates[i]
+
+		if r.Country != "" && !strings.EqualFold(r.Country, addr.Country) {
+			continue
+		}
+
+		if r.State != "" && !strings.EqualFold(r.State, addr.State) {
+			continue
+		}
+
+		if sm.Type == utils.ShippingWeight && r.MaxWeight != 0 && weight > r.MaxWeight {
+			continue
+		}
+
+		score := 0
+
+		if r.Country != "" {
+			score += 1
+		}
+
+		if r.State != "" {
+			score += 2
+		}
+
+		switch {
+		case score > bestScore:
+			best, bestScore = r, score
+		case score == bestScore && sm.Type == utils.ShippingWeight && smallerBracket(r, best):
+			best = r
+		}
+	}
+
+	return best
+}
+
+// smallerBracket tells if a is tighter than b. Zero means no limit.
+func smallerBracket(a, b *domain.ShippingRate) bool {
+	if a.MaxWeight == 0 {
+		return false
+	}
+
+	return b.MaxWeight == 0 || a.MaxWeight < b.MaxWeight
+}
+
+func validateShippingMethod(sm *domain.ShippingMethod) error {
+	if !utils.ItemInSlice(sm.Type, utils.GetShippingTypes()) {
+		return fmt.Errorf("invalid shipping type %q", sm.Type)
+	}
+
+	if sm.Code == "" || sm.Name == "" {
+		return fmt.Errorf("the shipping method needs a code and a name")
+	}
+
+	if len(sm.Rates) == 0 {
+		return fmt.Errorf("the shipping method needs at least one rate")
+	}
+
+	for _, r := range sm.Rates {
+		if r.Price.Amount < 0 || r.Price.Currency == "" {
+			return fmt.Errorf("every rate needs a price with a currency")
+		}
+
+		if r.MaxWeight < 0 {
+			return fmt.Errorf("the weight limits cannot be negative")
+		}
+
+		if r.State != "" && r.Country == "" {
+			return fmt.Errorf("a rate with a state needs a country")
+		}
+	}
+
+	return nil
+}
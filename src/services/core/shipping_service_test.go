@@ -0,0 +1,233 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/address"
+	"github.com/ZaphCode/clean-arch/src/repositories/product"
+	"github.com/ZaphCode/clean-arch/src/repositories/shipping"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShippingServiceSuite struct {
+	suite.Suite
+	service *shippingService
+}
+
+func TestShippingServiceSuite(t *testing.T) {
+	suite.Run(t, new(ShippingServiceSuite))
+}
+
+func (s *ShippingServiceSuite) SetupSuite() {
+	s.T().Logf("\n-------------- init ---------------")
+
+	disabled := domain.ShippingMethod{
+		Model: domain.Model{ID: uuid.New()},
+		Code:  "drone",
+		Name:  "Drone delivery",
+		Type:  utils.ShippingFlat,
+		Rates: []domain.ShippingRate{
+			{Price: domain.NewMoney(100, utils.DefaultCurrency)},
+		},
+	}
+
+	s.service = &shippingService{
+		shipRepo: shipping.NewMemoryShippingRepository(
+			utils.ShippingExp1, utils.ShippingExp2, disabled,
+		),
+		addrRepo: address.NewMemoryAddressRepository(utils.AddrExp1, utils.AddrExp2),
+		prodRepo: product.NewMemoryProductRepository(
+			utils.ProductExp1, utils.ProductExp2, utils.ProductExpToDev3,
+		),
+	}
+}
+
+//* Tests
+
+func (s *ShippingServiceSuite) TestShippingService_Create() {
+	testCases := []struct {
+		desc    string
+		input   domain.ShippingMethod
+		wantErr bool
+	}{
+		{
+			desc: "code already used",
+			input: domain.ShippingMethod{
+				Code:  "STANDARD",
+				Name:  "Standard again",
+				Type:  utils.ShippingFlat,
+				Rates: []domain.ShippingRate{{Price: domain.NewMoney(100, utils.DefaultCurrency)}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "invalid type",
+			input: domain.ShippingMethod{
+				Code:  "boat",
+				Name:  "By boat",
+				Type:  "volume",
+				Rates: []domain.ShippingRate{{Price: domain.NewMoney(100, utils.DefaultCurrency)}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "rate without currency",
+			input: domain.ShippingMethod{
+				Code:  "boat",
+				Name:  "By boat",
+				Type:  utils.ShippingFlat,
+				Rates: []domain.ShippingRate{{Price: domain.Money{Amount: 100}}},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "proper work",
+			input: domain.ShippingMethod{
+				Code: "Economy",
+				Name: "Economy shipping",
+				Type: utils.ShippingWeight,
+				Rates: []domain.ShippingRate{
+					{Country: "usa", MaxWeight: 2000, Price: domain.NewMoney(300, utils.DefaultCurrency)},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.service.Create(&tC.input)
+
+			if tC.wantErr {
+				s.Error(err, "should be error")
+				return
+			}
+
+			s.Require().NoError(err, "should not be error")
+
+			sm, err := s.service.GetByCode("economy")
+
+			s.NoError(err, "should not be error")
+			s.NotNil(sm, "the code should be saved in lower case")
+		})
+	}
+}
+
+func (s *ShippingServiceSuite) TestShippingService_QuoteMethod() {
+	light := []domain.OrderProduct{{ID: utils.ProductExp1.ID, Quantity: 2}}      // 400g
+	heavy := []domain.OrderProduct{{ID: utils.ProductExpToDev3.ID, Quantity: 3}} // 2400g
+	huge := []domain.OrderProduct{{ID: utils.ProductExp2.ID, Quantity: 20}}      // 7000g
+
+	other := utils.AddrExp1
+	other.ID = uuid.New()
+	other.State = "Texas"
+
+	s.Require().NoError(s.service.addrRepo.Save(&other), "should not be error")
+
+	testCases := []struct {
+		desc     string
+		code     string
+		addrID   uuid.UUID
+		input    []domain.OrderProduct
+		wantCost int64
+		wantErr  bool
+	}{
+		{
+			desc:    "unexisting method",
+			code:    "teleport",
+			addrID:  utils.AddrExp1.ID,
+			input:   light,
+			wantErr: true,
+		},
+		{
+			desc:    "inactive method",
+			code:    "drone",
+			addrID:  utils.AddrExp1.ID,
+			input:   light,
+			wantErr: true,
+		},
+		{
+			desc:    "unexisting address",
+			code:    "standard",
+			addrID:  uuid.New(),
+			input:   light,
+			wantErr: true,
+		},
+		{
+			desc:     "flat by country",
+			code:     "standard",
+			addrID:   utils.AddrExp2.ID,
+			input:    heavy,
+			wantCost: 800,
+		},
+		{
+			desc:     "state rate wins",
+			code:     "EXPRESS",
+			addrID:   utils.AddrExp1.ID,
+			input:    light,
+			wantCost: 900,
+		},
+		{
+			desc:     "too heavy for the state rate",
+			code:     "express",
+			addrID:   utils.AddrExp1.ID,
+			input:    heavy,
+			wantCost: 2000,
+		},
+		{
+			desc:     "smallest bracket",
+			code:     "express",
+			addrID:   other.ID,
+			input:    light,
+			wantCost: 1200,
+		},
+		{
+			desc:    "too heavy for every bracket",
+			code:    "express",
+			addrID:  utils.AddrExp1.ID,
+			input:   huge,
+			wantErr: true,
+		},
+		{
+			desc:    "country without rates",
+			code:    "express",
+			addrID:  utils.AddrExp2.ID,
+			input:   light,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			got, err := s.service.QuoteMethod(tC.code, tC.addrID, tC.input)
+
+			if tC.wantErr {
+				s.True(errors.Is(err, utils.ErrNoShipping), "should be a no shipping error")
+				return
+			}
+
+			s.Require().NoError(err, "should not be error")
+			s.Equal(tC.wantCost, got.Amount, "wrong cost")
+		})
+	}
+}
+
+func (s *ShippingServiceSuite) TestShippingService_Quote() {
+	quotes, err := s.service.Quote(utils.AddrExp1.ID, []domain.OrderProduct{
+		{ID: utils.ProductExp1.ID, Quantity: 1},
+	})
+
+	s.Require().NoError(err, "should not be error")
+
+	for i, q := range quotes {
+		s.NotEqual("drone", q.Method, "inactive methods are not quoted")
+
+		if i > 0 {
+			s.LessOrEqual(quotes[i-1].Cost.Amount, q.Cost.Amount, "cheapest first")
+		}
+	}
+
+	s.Equal("standard", quotes[0].Method, "wrong cheapest method")
+}
@@ -147,6 +147,25 @@ func (s *userService) Update(ID uuid.UUID, uf domain.UpdateFields) error {
 	return s.usrRepo.Update(ID, uf)
 }
 
+// SetAvatar swaps the avatar in one update, so the previous one
+// returned is not in use anymore and its files can be removed.
+func (s *userService) SetAvatar(ID uuid.UUID, img domain.Image) (*domain.Image, error) {
+	var prev *domain.Image
+
+	err := s.usrRepo.UpdateWith(ID, func(u *domain.User) error {
+		prev = u.Avatar
+		u.Avatar = &img
+		u.ImageUrl = img.URL("medium")
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return prev, nil
+}
+
 func (s *userService) Delete(ID uuid.UUID) error {
 	return s.usrRepo.Remove(ID)
 }
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/user"
 	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
-	"testing"
 )
 
 type UserServiceSuite struct {
@@ -33,3 +36,27 @@ func (s *UserServiceSuite) TestUserService_GetAll() {
 
 	s.T().Logf("%+v", users)
 }
+
+func (s *UserServiceSuite) TestUserService_SetAvatar() {
+	first := domain.Image{ID: uuid.New(), Renditions: []domain.ImageRendition{{Name: "medium", URL: "https://example.com/first.jpg"}}}
+	second := domain.Image{ID: uuid.New(), Renditions: []domain.ImageRendition{{Name: "medium", URL: "https://example.com/second.jpg"}}}
+
+	prev, err := s.service.SetAvatar(utils.UserExp2.ID, first)
+
+	s.Require().NoError(err)
+	s.Nil(prev, "there was no uploaded avatar")
+
+	prev, err = s.service.SetAvatar(utils.UserExp2.ID, second)
+
+	s.Require().NoError(err)
+	s.Require().NotNil(prev)
+	s.Equal(first.ID, prev.ID, "the previous avatar should be returned")
+
+	usr, err := s.service.GetByID(utils.UserExp2.ID)
+
+	s.Require().NoError(err)
+	s.Equal("https://example.com/second.jpg", usr.ImageUrl)
+
+	_, err = s.service.SetAvatar(uuid.New(), first)
+	s.ErrorIs(err, utils.ErrNotFound)
+}
@@ -0,0 +1,79 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+// orderEmailNotifier sends the order emails in the background,
+// so the request that changed the order is not kept waiting.
+type orderEmailNotifier struct {
+	emailSvc EmailService
+	usrRepo  domain.UserRepository
+}
+
+func NewOrderEmailNotifier(
+	emailSvc EmailService,
+	usrRepo domain.UserRepository,
+) domain.OrderNotifier {
+	return &orderEmailNotifier{
+		emailSvc: emailSvc,
+		usrRepo:  usrRepo,
+	}
+}
+
+func (n *orderEmailNotifier) OrderPlaced(ord domain.Order) {
+	n.send(ord, func(usr *domain.User) error {
+		return n.emailSvc.SendOrderPlacedEmail(usr.Email, usr.Username, ord)
+	})
+}
+
+func (n *orderEmailNotifier) PaymentFailed(ord domain.Order) {
+	n.send(ord, func(usr *domain.User) error {
+		return n.emailSvc.SendPaymentFailedEmail(usr.Email, usr.Username, ord)
+	})
+}
+
+func (n *orderEmailNotifier) OrderShipped(ord domain.Order) {
+	n.send(ord, func(usr *domain.User) error {
+		return n.emailSvc.SendOrderShippedEmail(usr.Email, usr.Username, ord)
+	})
+}
+
+func (n *orderEmailNotifier) OrderDelivered(ord domain.Order) {
+	n.send(ord, func(usr *domain.User) error {
+		return n.emailSvc.SendOrderDeliveredEmail(usr.Email, usr.Username, ord)
+	})
+}
+
+func (n *orderEmailNotifier) OrderRefunded(ord domain.Order, amount domain.Money) {
+	n.send(ord, func(usr *domain.User) error {
+		return n.emailSvc.SendOrderRefundedEmail(usr.Email, usr.Username, ord, amount)
+	})
+}
+
+// Helper functions
+
+func (n *orderEmailNotifier) send(ord domain.Order, fn func(usr *domain.User) error) {
+	go func() {
+		if err := n.deliver(ord, fn); err != nil {
+			utils.PrintColor("red", "Error sending order email: ", err)
+		}
+	}()
+}
+
+func (n *orderEmailNotifier) deliver(ord domain.Order, fn func(usr *domain.User) error) error {
+	usr, err := n.usrRepo.FindByID(ord.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	if usr == nil {
+		return fmt.Errorf("order %s: user %s not found", ord.ID, ord.UserID)
+	}
+
+	return fn(usr)
+}
@@ -0,0 +1,130 @@
+package email
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/user"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+func Test_orderEmailNotifier(t *testing.T) {
+	sent := make(chan string, 1)
+	n := NewOrderEmailNotifier(
+		&fakeEmailService{sent: sent},
+		user.NewMemoryUserRepository(utils.UserExp1),
+	)
+
+	ord := utils.OrderExp1
+	ord.UserID = utils.UserExp1.ID
+
+	tests := []struct {
+		name   string
+		notify func()
+		want   string
+	}{
+		{
+			name:   "order placed",
+			notify: func() { n.OrderPlaced(ord) },
+			want:   "placed " + utils.UserExp1.Email,
+		},
+		{
+			name:   "payment failed",
+			notify: func() { n.PaymentFailed(ord) },
+			want:   "payment failed " + utils.UserExp1.Email,
+		},
+		{
+			name:   "order shipped",
+			notify: func() { n.OrderShipped(ord) },
+			want:   "shipped " + utils.UserExp1.Email,
+		},
+		{
+			name:   "order delivered",
+			notify: func() { n.OrderDelivered(ord) },
+			want:   "delivered " + utils.UserExp1.Email,
+		},
+		{
+			name:   "order refunded",
+			notify: func() { n.OrderRefunded(ord, domain.NewMoney(500, utils.DefaultCurrency)) },
+			want:   "refunded 5.00 USD " + utils.UserExp1.Email,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.notify()
+
+			select {
+			case got := <-sent:
+				if got != tt.want {
+					t.Errorf("sent %q, want %q", got, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("the email was not sent")
+			}
+		})
+	}
+}
+
+func Test_orderEmailNotifier_deliver(t *testing.T) {
+	n := &orderEmailNotifier{
+		emailSvc: &fakeEmailService{},
+		usrRepo:  user.NewMemoryUserRepository(utils.UserExp1),
+	}
+
+	ord := utils.OrderExp1
+	ord.UserID = uuid.New()
+
+	errSend := errors.New("smtp down")
+
+	if err := n.deliver(ord, func(*domain.User) error { return nil }); err == nil {
+		t.Error("deliver() should fail when the user does not exist")
+	}
+
+	ord.UserID = utils.UserExp1.ID
+
+	if err := n.deliver(ord, func(*domain.User) error { return errSend }); !errors.Is(err, errSend) {
+		t.Errorf("deliver() error = %v, want %v", err, errSend)
+	}
+}
+
+// Helpers
+
+type fakeEmailService struct {
+	sent chan string
+}
+
+func (f *fakeEmailService) SendChangePasswordEmail(email, name, secretCode string) error {
+	return nil
+}
+
+func (f *fakeEmailService) SendVerifyEmail(email, name, secretCode string) error {
+	return nil
+}
+
+func (f *fakeEmailService) SendOrderPlacedEmail(email, name string, ord domain.Order) error {
+	f.sent <- "placed " + email
+	return nil
+}
+
+func (f *fakeEmailService) SendPaymentFailedEmail(email, name string, ord domain.Order) error {
+	f.sent <- "payment failed " + email
+	return nil
+}
+
+func (f *fakeEmailService) SendOrderShippedEmail(email, name string, ord domain.Order) error {
+	f.sent <- "shipped " + email
+	return nil
+}
+
+func (f *fakeEmailService) SendOrderDeliveredEmail(email, name string, ord domain.Order) error {
+	f.sent <- "delivered " + email
+	return nil
+}
+
+func (f *fakeEmailService) SendOrderRefundedEmail(email, name string, ord domain.Order, amount domain.Money) error {
+	f.sent <- "refunded " + amount.String() + " " + email
+	return nil
+}
@@ -1,8 +1,15 @@
 package email
 
+import "github.com/ZaphCode/clean-arch/src/domain"
+
 type EmailService interface {
 	SendChangePasswordEmail(email, name, secretCode string) error
 	SendVerifyEmail(email, name, secretCode string) error
+	SendOrderPlacedEmail(email, name string, ord domain.Order) error
+	SendPaymentFailedEmail(email, name string, ord domain.Order) error
+	SendOrderShippedEmail(email, name string, ord domain.Order) error
+	SendOrderDeliveredEmail(email, name string, ord domain.Order) error
+	SendOrderRefundedEmail(email, name string, ord domain.Order, amount domain.Money) error
 }
 
 type EmailData struct {
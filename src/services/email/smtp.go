@@ -2,14 +2,18 @@ package email
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
+	"html/template"
 	"net/smtp"
-	"text/template"
 
 	"github.com/ZaphCode/clean-arch/config"
-	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/ZaphCode/clean-arch/src/domain"
 )
 
+//go:embed templates/*.html
+var templatesFS embed.FS
+
 type smtpEmailServiceImpl struct{}
 
 func NewSmtpEmailService() EmailService {
@@ -19,27 +23,15 @@ func NewSmtpEmailService() EmailService {
 func (s *smtpEmailServiceImpl) sendEmail(data EmailData) error {
 	cfg := config.Get()
 
-	utils.PrintWD()
-
 	subject := fmt.Sprintf("Subject: %s!\n", data.Subject)
 	mime := "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";\n\n"
 
-	t, err := template.ParseFiles(
-		// "./src/services/email/templates/" + data.Template,
-		"./templates/" + data.Template,
-	)
+	body, err := render(data)
 
 	if err != nil {
 		return err
 	}
 
-	buf := new(bytes.Buffer)
-
-	if err = t.Execute(buf, data.Data); err != nil {
-		return err
-	}
-
-	body := buf.String()
 	msg := []byte(subject + mime + body)
 
 	sender := cfg.Smtp.Email
@@ -89,3 +81,79 @@ func (s *smtpEmailServiceImpl) SendVerifyEmail(email, name, secretCode string) e
 	}
 	return s.sendEmail(data)
 }
+
+func (s *smtpEmailServiceImpl) SendOrderPlacedEmail(email, name string, ord domain.Order) error {
+	return s.sendEmail(EmailData{
+		Email:    email,
+		Subject:  "Thanks for your order",
+		Template: "order_placed.html",
+		Data:     orderData(name, ord),
+	})
+}
+
+func (s *smtpEmailServiceImpl) SendPaymentFailedEmail(email, name string, ord domain.Order) error {
+	return s.sendEmail(EmailData{
+		Email:    email,
+		Subject:  "Your payment failed",
+		Template: "payment_failed.html",
+		Data:     orderData(name, ord),
+	})
+}
+
+func (s *smtpEmailServiceImpl) SendOrderShippedEmail(email, name string, ord domain.Order) error {
+	return s.sendEmail(EmailData{
+		Email:    email,
+		Subject:  "Your order is on its way",
+		Template: "order_shipped.html",
+		Data:     orderData(name, ord),
+	})
+}
+
+func (s *smtpEmailServiceImpl) SendOrderDeliveredEmail(email, name string, ord domain.Order) error {
+	return s.sendEmail(EmailData{
+		Email:    email,
+		Subject:  "Your order has been delivered",
+		Template: "order_delivered.html",
+		Data:     orderData(name, ord),
+	})
+}
+
+func (s *smtpEmailServiceImpl) SendOrderRefundedEmail(email, name string, ord domain.Order, amount domain.Money) error {
+	data := orderData(name, ord)
+	data["RefundAmount"] = amount.String()
+	data["RefundedAmount"] = ord.RefundedAmount.String()
+
+	return s.sendEmail(EmailData{
+		Email:    email,
+		Subject:  "Your refund is on its way",
+		Template: "order_refunded.html",
+		Data:     data,
+	})
+}
+
+// Helper functions
+
+func render(data EmailData) (string, error) {
+	t, err := template.ParseFS(templatesFS, "templates/"+data.Template)
+
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err = t.Execute(buf, data.Data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func orderData(name string, ord domain.Order) map[string]any {
+	return map[string]any{
+		"Name":     name,
+		"OrderID":  ord.ID.String(),
+		"Amount":   ord.Amount.String(),
+		"Products": ord.Products,
+	}
+}
@@ -1,9 +1,12 @@
 package email
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ZaphCode/clean-arch/config"
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
 )
 
 func TestMain(m *testing.M) {
@@ -83,3 +86,67 @@ func Test_smtpEmailServiceImpl_SendChangePasswordEmail(t *testing.T) {
 		})
 	}
 }
+
+func Test_render(t *testing.T) {
+	ord := utils.OrderExp1
+	ord.Products = append(ord.Products, domain.OrderProduct{
+		Name:      "<b>Sneakers</b>",
+		Quantity:  2,
+		LineTotal: domain.NewMoney(1999, utils.DefaultCurrency),
+	})
+
+	refund := orderData("Omar", ord)
+	refund["RefundAmount"] = "5.00 USD"
+	refund["RefundedAmount"] = "5.00 USD"
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]any
+		want     []string
+	}{
+		{
+			name:     "order placed",
+			template: "order_placed.html",
+			data:     orderData("Omar", ord),
+			want:     []string{"Hi Omar", ord.ID.String(), ord.Amount.String(), "2 x &lt;b&gt;Sneakers&lt;/b&gt;", "19.99 USD"},
+		},
+		{
+			name:     "payment failed",
+			template: "payment_failed.html",
+			data:     orderData("Omar", ord),
+			want:     []string{"has been cancelled", ord.ID.String()},
+		},
+		{
+			name:     "order shipped",
+			template: "order_shipped.html",
+			data:     orderData("Omar", ord),
+			want:     []string{"has been shipped", ord.ID.String()},
+		},
+		{
+			name:     "order delivered",
+			template: "order_delivered.html",
+			data:     orderData("Omar", ord),
+			want:     []string{"has been delivered", ord.ID.String()},
+		},
+		{
+			name:     "order refunded",
+			template: "order_refunded.html",
+			data:     refund,
+			want:     []string{"We refunded <b>5.00 USD</b>", ord.ID.String()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(EmailData{Template: tt.template, Data: tt.data})
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("render() does not contain %q", want)
+				}
+			}
+		})
+	}
+}
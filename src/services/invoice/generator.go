@@ -0,0 +1,146 @@
+package invoice
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/invoice.html
+var invoiceTemplate string
+
+type invoiceGeneratorImpl struct {
+	issuer Issuer
+	tmpl   *template.Template
+}
+
+func NewInvoiceGenerator(issuer Issuer) InvoiceGenerator {
+	return &invoiceGeneratorImpl{
+		issuer: issuer,
+		tmpl:   template.Must(template.New("invoice").Parse(invoiceTemplate)),
+	}
+}
+
+// templateData puts the issuer next to the document fields.
+type templateData struct {
+	Document
+	Issuer Issuer
+}
+
+func (g *invoiceGeneratorImpl) HTML(doc Document) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := g.tmpl.Execute(buf, templateData{Document: doc, Issuer: g.issuer}); err != nil {
+		return nil, fmt.Errorf("error rendering invoice: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *invoiceGeneratorImpl) PDF(doc Document) ([]byte, error) {
+	p := newPDFWriter()
+
+	p.text(pdfMargin, 20, true, "INVOICE")
+	p.newLine(28)
+	p.text(pdfMargin, 10, false, "Number: "+doc.Number)
+	p.newLine(14)
+	p.text(pdfMargin, 10, false, "Date: "+doc.IssuedAt)
+	p.newLine(14)
+	p.text(pdfMargin, 10, false, "Order: "+doc.OrderID)
+	p.newLine(30)
+
+	//* Issuer on the left, customer on the right
+	from := []string{}
+	if g.issuer.Address != "" {
+		from = append(from, g.issuer.Address)
+	}
+	if g.issuer.TaxID != "" {
+		from = append(from, "Tax ID: "+g.issuer.TaxID)
+	}
+	to := append([]string{doc.CustomerName, doc.CustomerEmail}, doc.Address...)
+
+	p.text(pdfMargin, 11, true, g.issuer.Name)
+	p.text(320, 11, true, "Bill to")
+	p.newLine(15)
+
+	for i := 0; i < len(from) || i < len(to); i++ {
+		if i < len(from) {
+			p.text(pdfMargin, 10, false, from[i])
+		}
+		if i < len(to) {
+			p.text(320, 10, false, to[i])
+		}
+		p.newLine(14)
+	}
+
+	p.newLine(16)
+
+	cols := []float64{pdfMargin, 300, 340, 420, 475}
+
+	for i, h := range []string{"Item", "Qty", "Unit price", "Discount", "Total"} {
+		p.text(cols[i], 10, true, h)
+	}
+
+	p.newLine(6)
+	p.rule()
+	p.newLine(14)
+
+	for _, l := range doc.Lines {
+		p.text(cols[0], 10, false, truncate(l.Name, 45))
+		p.text(cols[1], 10, false, fmt.Sprint(l.Quantity))
+		p.text(cols[2], 10, false, l.UnitPrice)
+		p.text(cols[3], 10, false, l.Discount)
+		p.text(cols[4], 10, false, l.Total)
+		p.newLine(16)
+	}
+
+	p.rule()
+	p.newLine(16)
+
+	total := func(name, amount string, bold bool) {
+		p.text(cols[2], 10, bold, name)
+		p.text(cols[4], 10, bold, amount)
+		p.newLine(16)
+	}
+
+	total("Subtotal", doc.Subtotal, false)
+
+	if doc.Discount != "" {
+		total("Coupon "+doc.CouponCode, "-"+doc.Discount, false)
+	}
+
+	total("Shipping ("+doc.ShippingMethod+")", doc.Shipping, false)
+
+	if doc.TaxName != "" {
+		name := doc.TaxName + " " + doc.TaxRate
+
+		if doc.TaxInclusive {
+			name += " (included)"
+		}
+
+		total(truncate(name, 24), doc.Tax, false)
+	}
+
+	total("Total", doc.Total, true)
+
+	if doc.Refunded != "" {
+		total("Refunded", "-"+doc.Refunded, false)
+	}
+
+	return p.bytes(), nil
+}
+
+// Helper functions
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	r := []rune(s)
+
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,81 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvoiceGeneratorSuite struct {
+	suite.Suite
+	gen InvoiceGenerator
+	doc Document
+}
+
+func TestInvoiceGeneratorSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceGeneratorSuite))
+}
+
+func (s *InvoiceGeneratorSuite) SetupTest() {
+	s.gen = NewInvoiceGenerator(Issuer{Name: "Clean Arch Store", TaxID: "US-1234567"})
+
+	ord := utils.OrderExp4
+	ord.Tax = domain.OrderTax{
+		Name:   "Washintong sales tax",
+		Rate:   650,
+		Amount: domain.NewMoney(419, utils.DefaultCurrency),
+	}
+
+	usr := utils.UserExp1
+	usr.Username = "<script>alert(1)</script>"
+
+	s.doc = NewDocument(domain.Invoice{Number: 42}, ord, usr, utils.AddrExp1)
+}
+
+//* Tests
+
+func (s *InvoiceGeneratorSuite) TestNewDocument() {
+	s.Equal("INV-000042", s.doc.Number, "wrong number")
+	s.Equal("59.47 USD", s.doc.Subtotal, "wrong subtotal")
+	s.Equal("6.50%", s.doc.TaxRate, "wrong tax rate")
+	s.Equal("", s.doc.Discount, "the order has no coupon")
+	s.Len(s.doc.Lines, 2, "wrong lines")
+	s.Contains(s.doc.Address, utils.AddrExp1.Line1, "should contain the address")
+}
+
+func (s *InvoiceGeneratorSuite) TestHTML() {
+	html, err := s.gen.HTML(s.doc)
+
+	s.Require().NoError(err, "should not be error")
+	s.Contains(string(html), "INV-000042", "should contain the number")
+	s.Contains(string(html), "Clean Arch Store", "should contain the issuer")
+	s.Contains(string(html), "64.47 USD", "should contain the total")
+	s.NotContains(string(html), "<script>", "user data should be escaped")
+}
+
+func (s *InvoiceGeneratorSuite) TestPDF() {
+	pdf, err := s.gen.PDF(s.doc)
+
+	s.Require().NoError(err, "should not be error")
+	s.True(bytes.HasPrefix(pdf, []byte("%PDF-1.4")), "should be a pdf")
+	s.True(bytes.HasSuffix(pdf, []byte("%%EOF\n")), "should end the pdf")
+	s.Contains(string(pdf), "(Number: INV-000042)", "should contain the number")
+	s.Contains(string(pdf), "/Count 1", "should fit in one page")
+
+	for i := 0; i < 60; i++ {
+		s.doc.Lines = append(s.doc.Lines, Line{Name: fmt.Sprint("Product ", i), Quantity: 1})
+	}
+
+	pdf, err = s.gen.PDF(s.doc)
+
+	s.Require().NoError(err, "should not be error")
+	s.Contains(string(pdf), "/Count 2", "should need another page")
+}
+
+func (s *InvoiceGeneratorSuite) TestPDFEscape() {
+	s.Equal("Caf\xe9 \\(1\\) ?", pdfEscape("Café (1) 日"), "wrong escaping")
+}
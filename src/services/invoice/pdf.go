@@ -0,0 +1,130 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//* A4 in points
+
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 50.0
+)
+
+// pdfWriter lays out text lines with the standard Helvetica fonts.
+// It is just enough for invoices without a third party library.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPDFWriter() *pdfWriter {
+	p := &pdfWriter{}
+	p.addPage()
+	return p
+}
+
+func (p *pdfWriter) addPage() {
+	p.pages = append(p.pages, new(bytes.Buffer))
+	p.y = pdfPageHeight - pdfMargin
+}
+
+func (p *pdfWriter) page() *bytes.Buffer {
+	return p.pages[len(p.pages)-1]
+}
+
+// text writes s at the x position of the current line.
+func (p *pdfWriter) text(x, size float64, bold bool, s string) {
+	font := "F1"
+
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(p.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, p.y, pdfEscape(s))
+}
+
+// newLine moves down h points, starting a new page at the bottom margin.
+func (p *pdfWriter) newLine(h float64) {
+	p.y -= h
+
+	if p.y < pdfMargin {
+		p.addPage()
+	}
+}
+
+// rule draws a horizontal line across the page on the current line.
+func (p *pdfWriter) rule() {
+	fmt.Fprintf(p.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, p.y, pdfPageWidth-pdfMargin, p.y)
+}
+
+func (p *pdfWriter) bytes() []byte {
+	//* 1 catalog, 2 pages, 3 and 4 fonts, then a page and its content per page
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	kids := new(bytes.Buffer)
+
+	for _, pg := range p.pages {
+		pageNum := len(objs) + 1
+
+		fmt.Fprintf(kids, "%d 0 R ", pageNum)
+
+		objs = append(objs,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, pageNum+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", pg.Len(), pg.String()),
+		)
+	}
+
+	objs[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(p.pages))
+
+	out := new(bytes.Buffer)
+	offsets := make([]int, len(objs))
+
+	out.WriteString("%PDF-1.4\n")
+
+	for i, obj := range objs {
+		offsets[i] = out.Len()
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(objs)+1)
+
+	for _, off := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", off)
+	}
+
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objs)+1, xref)
+
+	return out.Bytes()
+}
+
+// pdfEscape turns s into the bytes of a pdf literal string. Latin-1
+// runes keep their code, which matches WinAnsi for accented letters.
+func pdfEscape(s string) string {
+	buf := new(bytes.Buffer)
+
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r < 0x20:
+			buf.WriteByte(' ')
+		case r < 0x7f || (r >= 0xa0 && r < 0x100):
+			buf.WriteByte(byte(r))
+		default:
+			buf.WriteByte('?')
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,132 @@
+package invoice
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+)
+
+// InvoiceGenerator renders invoices into documents the customer can download.
+type InvoiceGenerator interface {
+	HTML(doc Document) ([]byte, error)
+	PDF(doc Document) ([]byte, error)
+}
+
+// Issuer is the business that issues the invoices.
+type Issuer struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+// Document is an invoice ready to be rendered. Everything
+// is already formatted, so every format shows the same values.
+type Document struct {
+	Number         string
+	IssuedAt       string
+	OrderID        string
+	CustomerName   string
+	CustomerEmail  string
+	Address        []string
+	Lines          []Line
+	Subtotal       string
+	CouponCode     string
+	Discount       string
+	ShippingMethod string
+	Shipping       string
+	TaxName        string
+	TaxRate        string
+	Tax            string
+	TaxInclusive   bool
+	Total          string
+	Refunded       string
+}
+
+type Line struct {
+	Name      string
+	Quantity  uint
+	UnitPrice string
+	Discount  string
+	Total     string
+}
+
+func NewDocument(
+	inv domain.Invoice,
+	ord domain.Order,
+	usr domain.User,
+	addr domain.Address,
+) Document {
+	doc := Document{
+		Number:         FormatNumber(inv.Number),
+		IssuedAt:       time.Unix(inv.CreatedAt, 0).UTC().Format("2006-01-02"),
+		OrderID:        ord.ID.String(),
+		CustomerName:   usr.Username,
+		CustomerEmail:  usr.Email,
+		Address:        addressLines(addr),
+		CouponCode:     ord.CouponCode,
+		ShippingMethod: ord.ShippingMethod,
+		Shipping:       ord.ShippingCost.String(),
+		TaxName:        ord.Tax.Name,
+		TaxRate:        fmt.Sprintf("%d.%02d%%", ord.Tax.Rate/100, ord.Tax.Rate%100),
+		Tax:            ord.Tax.Amount.String(),
+		TaxInclusive:   ord.Tax.Inclusive,
+		Total:          ord.Amount.String(),
+	}
+
+	subtotal := domain.NewMoney(0, ord.Amount.Currency)
+
+	for _, op := range ord.Products {
+		name := op.Name
+
+		if op.Variant != "" {
+			name = fmt.Sprintf("%s (%s)", op.Name, op.Variant)
+		}
+
+		doc.Lines = append(doc.Lines, Line{
+			Name:      name,
+			Quantity:  op.Quantity,
+			UnitPrice: op.UnitPrice.String(),
+			Discount:  fmt.Sprintf("%d%%", op.DiscountRate),
+			Total:     op.LineTotal.String(),
+		})
+
+		if sum, err := subtotal.Add(op.LineTotal); err == nil {
+			subtotal = sum
+		}
+	}
+
+	doc.Subtotal = subtotal.String()
+
+	if !ord.Discount.IsZero() {
+		doc.Discount = ord.Discount.String()
+	}
+
+	if !ord.RefundedAmount.IsZero() {
+		doc.Refunded = ord.RefundedAmount.String()
+	}
+
+	return doc
+}
+
+// FormatNumber turns 42 into INV-000042.
+func FormatNumber(n int64) string {
+	return fmt.Sprintf("INV-%06d", n)
+}
+
+func addressLines(addr domain.Address) []string {
+	lines := []string{addr.Name, addr.Line1, addr.Line2}
+	lines = append(lines, strings.TrimSpace(addr.PostalCode+" "+addr.City))
+	lines = append(lines, strings.Trim(addr.State+", "+addr.Country, ", "))
+
+	out := lines[:0]
+
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,352 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+//* Magic cards. They work as payment method ids without saving them first
+
+const (
+	TestCardVisa       = "pm_card_visa"
+	TestCardMastercard = "pm_card_mastercard"
+	TestCardDeclined   = "pm_card_chargeDeclined"
+	TestCardNoFunds    = "pm_card_chargeDeclinedInsufficientFunds"
+	TestCardExpired    = "pm_card_chargeDeclinedExpiredCard"
+)
+
+// The events are sent again while their order is not found, like
+// stripe does when the webhook answers with an error.
+const (
+	eventRetries    = 20
+	eventRetryDelay = 50 * time.Millisecond
+)
+
+type memoryCard struct {
+	Card
+	declineMsg string
+}
+
+type memoryIntent struct {
+	cusID    string
+	amount   domain.Money
+	refunded int64
+}
+
+//* Implementation
+
+type memoryPaymentServiceImpl struct {
+	mu        sync.Mutex
+	whSecret  string
+	usrRepo   domain.UserRepository
+	customers map[string]bool
+	cards     map[string]*memoryCard
+	intents   map[string]*memoryIntent
+	idemKeys  map[string]string // payments
+	rfndKeys  map[string]string // refunds
+	onEvent   func(evt *domain.PaymentEvent) error
+}
+
+//* Constructor
+
+// NewMemoryPaymentService works like the stripe one but keeps
+// everything in memory. Cards without customer can be attached.
+func NewMemoryPaymentService(
+	whSecret string,
+	usrRepo domain.UserRepository,
+	cards ...Card,
+) PaymentService {
+	s := &memoryPaymentServiceImpl{
+		whSecret:  whSecret,
+		usrRepo:   usrRepo,
+		customers: make(map[string]bool),
+		cards:     make(map[string]*memoryCard),
+		intents:   make(map[string]*memoryIntent),
+		idemKeys:  make(map[string]string),
+		rfndKeys:  make(map[string]string),
+	}
+
+	for _, c := range cards {
+		if c.CustomerID != "" {
+			s.customers[c.CustomerID] = true
+		}
+		s.cards[c.PaymentID] = &memoryCard{Card: c}
+	}
+
+	return s
+}
+
+func (s *memoryPaymentServiceImpl) GetOrCreateCustomerID(uid uuid.UUID) (string, error) {
+	usr, err := s.usrRepo.FindByID(uid)
+
+	if usr == nil || err != nil {
+		return "", fmt.Errorf("error getting user")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if usr.CustomerID != "" {
+		s.customers[usr.CustomerID] = true
+		return usr.CustomerID, nil
+	}
+
+	cusID := newFakeID("cus")
+
+	if err := s.usrRepo.UpdateField(uid, "CustomerID", cusID); err != nil {
+		return "", fmt.Errorf("error updating customer id")
+	}
+
+	s.customers[cusID] = true
+
+	return cusID, nil
+}
+
+func (s *memoryPaymentServiceImpl) DeleteCustomer(cusID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.customers[cusID] {
+		return fmt.Errorf("error deleting customer: no such customer %q", cusID)
+	}
+
+	delete(s.customers, cusID)
+
+	for ID, c := range s.cards {
+		if c.CustomerID == cusID {
+			delete(s.cards, ID)
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryPaymentServiceImpl) MakePayment(cusID, pmID string, amount domain.Money, idemKey string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if piID, ok := s.idemKeys[idemKey]; ok {
+		return piID, nil
+	}
+
+	pm, err := s.getCard(pmID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if pm.CustomerID != "" && pm.CustomerID != cusID {
+		return "", fmt.Errorf("this payment method is associated to another customer")
+	}
+
+	if !s.customers[cusID] {
+		return "", fmt.Errorf("error creating payment intent: no such customer %q", cusID)
+	}
+
+	if amount.Amount <= 0 || amount.Currency == "" {
+		return "", fmt.Errorf("error creating payment intent: invalid amount")
+	}
+
+	piID := newFakeID("pi")
+
+	s.intents[piID] = &memoryIntent{cusID: cusID, amount: amount}
+
+	evtType := utils.EventPaymentSucceeded
+
+	if pm.declineMsg != "" {
+		evtType = utils.EventPaymentFailed
+	} else if idemKey != "" {
+		s.idemKeys[idemKey] = piID
+	}
+
+	if s.onEvent != nil {
+		go s.sendEvent(s.onEvent, &domain.PaymentEvent{
+			ID:              newFakeID("evt"),
+			Type:            evtType,
+			PaymentIntentID: piID,
+		})
+	}
+
+	if pm.declineMsg != "" {
+		return piID, fmt.Errorf("error confirming the payment intent: %s", pm.declineMsg)
+	}
+
+	return piID, nil
+}
+
+func (s *memoryPaymentServiceImpl) Refund(piID string, amount domain.Money, idemKey string) (domain.Money, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if piID, ok := s.rfndKeys[idemKey]; ok {
+		pi := s.intents[piID]
+		return domain.NewMoney(pi.refunded, pi.amount.Currency), nil
+	}
+
+	pi, ok := s.intents[piID]
+
+	if !ok {
+		return domain.Money{}, fmt.Errorf("error refunding the payment intent: no such payment intent %q", piID)
+	}
+
+	left := pi.amount.Amount - pi.refunded
+
+	if amount.IsZero() {
+		amount = domain.NewMoney(left, pi.amount.Currency)
+	}
+
+	if amount.Currency != pi.amount.Currency || amount.Amount <= 0 || amount.Amount > left {
+		return domain.Money{}, fmt.Errorf("error refunding the payment intent: invalid amount %s", amount)
+	}
+
+	pi.refunded += amount.Amount
+
+	if idemKey != "" {
+		s.rfndKeys[idemKey] = piID
+	}
+
+	return domain.NewMoney(pi.refunded, pi.amount.Currency), nil
+}
+
+func (s *memoryPaymentServiceImpl) GetCustomerCards(custID string) ([]Card, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cards []Card
+
+	for _, c := range s.cards {
+		if c.CustomerID == custID {
+			cards = append(cards, c.Card)
+		}
+	}
+
+	return cards, nil
+}
+
+func (s *memoryPaymentServiceImpl) AttachCardToCustomer(cardID, cusID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pm, err := s.getCard(cardID)
+
+	if err != nil {
+		return err
+	}
+
+	if !s.customers[cusID] {
+		return fmt.Errorf("no such customer %q", cusID)
+	}
+
+	if pm.CustomerID != "" {
+		return fmt.Errorf("the payment method is already attached to a customer")
+	}
+
+	//* Like stripe, attaching a magic card saves a copy of it
+	if _, ok := s.cards[cardID]; !ok {
+		pm.PaymentID = newFakeID("pm")
+	}
+
+	pm.CustomerID = cusID
+	s.cards[pm.PaymentID] = pm
+
+	return nil
+}
+
+func (s *memoryPaymentServiceImpl) DetachCardFromCustomer(cardID, cusID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pm, ok := s.cards[cardID]
+
+	if !ok || pm.CustomerID == "" {
+		return fmt.Errorf("the payment method is not attached to a customer")
+	}
+
+	pm.CustomerID = ""
+
+	return nil
+}
+
+// OnEvent sets where the payment events go. There is no webhook in
+// memory, so the accepted payments are sent to fn in the background.
+func (s *memoryPaymentServiceImpl) OnEvent(fn func(evt *domain.PaymentEvent) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onEvent = fn
+}
+
+func (s *memoryPaymentServiceImpl) ParseWebhookEvent(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	return parseStripeEvent(payload, signature, s.whSecret)
+}
+
+// sendEvent waits for the order of the payment to be saved, the
+// payment intent id is known only after MakePayment returns.
+func (s *memoryPaymentServiceImpl) sendEvent(fn func(evt *domain.PaymentEvent) error, evt *domain.PaymentEvent) {
+	for i := 0; i < eventRetries; i++ {
+		err := fn(evt)
+
+		if err == nil {
+			return
+		}
+
+		if !errors.Is(err, utils.ErrNotFound) {
+			utils.PrintColor("red", "Error handling the payment event: ", err)
+			return
+		}
+
+		time.Sleep(eventRetryDelay)
+	}
+
+	utils.PrintColor("red", "No order for the payment intent ", evt.PaymentIntentID)
+}
+
+// getCard returns a copy of a saved card or a new magic one.
+func (s *memoryPaymentServiceImpl) getCard(pmID string) (*memoryCard, error) {
+	if pm, ok := s.cards[pmID]; ok {
+		c := *pm
+		return &c, nil
+	}
+
+	c := &memoryCard{
+		Card: Card{
+			Country:  "US",
+			Name:     "Test Card",
+			ExpMonth: 12,
+			ExpYear:  2034,
+			Brand:    "visa",
+		},
+	}
+
+	switch pmID {
+	case TestCardVisa:
+		c.Last4 = "4242"
+	case TestCardMastercard:
+		c.Brand, c.Last4 = "mastercard", "4444"
+	case TestCardDeclined:
+		c.Last4, c.declineMsg = "0002", "your card was declined"
+	case TestCardNoFunds:
+		c.Last4, c.declineMsg = "9995", "your card has insufficient funds"
+	case TestCardExpired:
+		c.Last4, c.declineMsg = "0069", "your card has expired"
+	default:
+		return nil, fmt.Errorf("no such payment method: %q", pmID)
+	}
+
+	c.PaymentID = pmID
+
+	return c, nil
+}
+
+// Helper functions
+
+func newFakeID(prefix string) string {
+	return prefix + "_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:24]
+}
@@ -0,0 +1,282 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/repositories/user"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+const savedCardID = "pm_1NKP27G8UXDxPRbaNZRE6Ajd"
+
+type MemoryPaymentServiceSuite struct {
+	suite.Suite
+	service PaymentService
+}
+
+func TestMemoryPaymentServiceSuite(t *testing.T) {
+	suite.Run(t, new(MemoryPaymentServiceSuite))
+}
+
+func (s *MemoryPaymentServiceSuite) SetupTest() {
+	userRepo := user.NewMemoryUserRepository(utils.UserAdmin, utils.UserExp1)
+	s.service = NewMemoryPaymentService("whsec_test", userRepo, Card{
+		CustomerID: utils.UserExp1.CustomerID,
+		PaymentID:  savedCardID,
+		Brand:      "visa",
+		Last4:      "4242",
+	})
+}
+
+//* Tests
+
+func (s *MemoryPaymentServiceSuite) TestCreateAndDeleteCustomerID() {
+	cusID, err := s.service.GetOrCreateCustomerID(utils.UserAdmin.ID)
+
+	s.Require().NoError(err, "should not be error")
+
+	again, err := s.service.GetOrCreateCustomerID(utils.UserAdmin.ID)
+
+	s.NoError(err, "should not be error")
+	s.Equal(cusID, again, "should reuse the customer")
+
+	s.NoError(s.service.DeleteCustomer(cusID), "should not be error")
+	s.Error(s.service.DeleteCustomer(cusID), "should not exist anymore")
+}
+
+func (s *MemoryPaymentServiceSuite) TestAttachAndDetachCard() {
+	cusID, err := s.service.GetOrCreateCustomerID(utils.UserAdmin.ID)
+
+	s.Require().NoError(err, "should not be error")
+
+	s.Error(s.service.AttachCardToCustomer("pm_asd", cusID), "unknown card")
+	s.Error(s.service.AttachCardToCustomer(savedCardID, cusID), "card of other customer")
+	s.Require().NoError(s.service.AttachCardToCustomer(TestCardMastercard, cusID))
+
+	cards, err := s.service.GetCustomerCards(cusID)
+
+	s.Require().NoError(err, "should not be error")
+	s.Require().Len(cards, 1, "should have the card")
+	s.NotEqual(TestCardMastercard, cards[0].PaymentID, "magic cards are copied")
+	s.Equal("4444", cards[0].Last4)
+
+	s.NoError(s.service.DetachCardFromCustomer(cards[0].PaymentID, cusID))
+	s.Error(s.service.DetachCardFromCustomer(cards[0].PaymentID, cusID), "already detached")
+
+	cards, _ = s.service.GetCustomerCards(cusID)
+
+	s.Empty(cards, "should not have cards")
+}
+
+func (s *MemoryPaymentServiceSuite) TestMakePayment() {
+	testCases := []struct {
+		desc    string
+		wantErr bool
+		cusID   string
+		pmID    string
+	}{
+		{
+			desc:    "invalid payment method",
+			cusID:   utils.UserExp1.CustomerID,
+			wantErr: true,
+			pmID:    "asd",
+		},
+		{
+			desc:    "invalid customer id",
+			cusID:   "asd",
+			wantErr: true,
+			pmID:    TestCardVisa,
+		},
+		{
+			desc:    "customer using card attached to other customer",
+			cusID:   "cus_NomqrSHuyzac8E",
+			wantErr: true,
+			pmID:    savedCardID,
+		},
+		{
+			desc:    "declined card",
+			cusID:   utils.UserExp1.CustomerID,
+			wantErr: true,
+			pmID:    TestCardDeclined,
+		},
+		{
+			desc:    "card without funds",
+			cusID:   utils.UserExp1.CustomerID,
+			wantErr: true,
+			pmID:    TestCardNoFunds,
+		},
+		{
+			desc:    "proper work with new card",
+			cusID:   utils.UserExp1.CustomerID,
+			wantErr: false,
+			pmID:    TestCardVisa,
+		},
+		{
+			desc:    "proper work with attached card",
+			cusID:   utils.UserExp1.CustomerID,
+			wantErr: false,
+			pmID:    savedCardID,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			piID, err := s.service.MakePayment(tC.cusID, tC.pmID, domain.NewMoney(4599, utils.DefaultCurrency), "")
+
+			s.Equal(tC.wantErr, (err != nil), "expect err fail: %v", err)
+
+			if !tC.wantErr {
+				s.NotEmpty(piID, "should return the payment intent")
+			}
+		})
+	}
+}
+
+func (s *MemoryPaymentServiceSuite) TestMakePaymentIdempotencyKey() {
+	amount := domain.NewMoney(1200, utils.DefaultCurrency)
+
+	first, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, amount, "order-retry-1")
+
+	s.Require().NoError(err, "should not be error")
+
+	again, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, amount, "order-retry-1")
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(first, again, "the same key should not charge twice")
+
+	other, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, amount, "order-retry-2")
+
+	s.Require().NoError(err, "should not be error")
+	s.NotEqual(first, other, "another key is another payment")
+}
+
+func (s *MemoryPaymentServiceSuite) TestMakePaymentEvents() {
+	evts := make(chan *domain.PaymentEvent, 1)
+	tries := 0
+
+	s.service.(EventSource).OnEvent(func(evt *domain.PaymentEvent) error {
+		//* The first try finds no order, like a webhook sent too early
+		if tries++; tries == 1 {
+			return utils.ErrNotFound
+		}
+		evts <- evt
+		return nil
+	})
+
+	declined, err := s.service.MakePayment(utils.UserExp1.CustomerID, TestCardDeclined, domain.NewMoney(1200, utils.DefaultCurrency), "")
+
+	s.Require().Error(err, "should be declined")
+	s.Require().NotEmpty(declined, "the declined payment intent should be returned")
+
+	select {
+	case evt := <-evts:
+		s.Equal(utils.EventPaymentFailed, evt.Type, "wrong event type")
+		s.Equal(declined, evt.PaymentIntentID, "wrong payment intent")
+	case <-time.After(time.Second):
+		s.Fail("the failed event was not sent")
+	}
+
+	piID, err := s.service.MakePayment(utils.UserExp1.CustomerID, TestCardVisa, domain.NewMoney(1200, utils.DefaultCurrency), "")
+
+	s.Require().NoError(err, "should not be error")
+
+	select {
+	case evt := <-evts:
+		s.Equal(utils.EventPaymentSucceeded, evt.Type, "wrong event type")
+		s.Equal(piID, evt.PaymentIntentID, "wrong payment intent")
+		s.NotEmpty(evt.ID, "the event should have an id")
+	case <-time.After(time.Second):
+		s.Fail("the succeeded event was not sent")
+	}
+}
+
+func (s *MemoryPaymentServiceSuite) TestRefundIdempotencyKey() {
+	piID, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, domain.NewMoney(3000, utils.DefaultCurrency), "")
+
+	s.Require().NoError(err, "should not be error")
+
+	amount := domain.NewMoney(2000, utils.DefaultCurrency)
+
+	refunded, err := s.service.Refund(piID, amount, "return-1")
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(2000), refunded.Amount, "wrong total refunded")
+
+	refunded, err = s.service.Refund(piID, amount, "return-1")
+
+	s.Require().NoError(err, "the same key should not refund twice")
+	s.Equal(int64(2000), refunded.Amount, "wrong total refunded")
+
+	_, err = s.service.Refund(piID, amount, "return-2")
+
+	s.Error(err, "only 10.00 USD are left")
+
+	refunded, err = s.service.Refund(piID, domain.NewMoney(1000, utils.DefaultCurrency), "return-2")
+
+	s.NoError(err, "should not be error")
+	s.Equal(int64(3000), refunded.Amount, "wrong total refunded")
+}
+
+func (s *MemoryPaymentServiceSuite) TestRefundIdempotencyKeyOfPayment() {
+	piID, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, domain.NewMoney(3000, utils.DefaultCurrency), "shared-key")
+
+	s.Require().NoError(err, "should not be error")
+
+	refunded, err := s.service.Refund(piID, domain.NewMoney(1000, utils.DefaultCurrency), "shared-key")
+
+	s.Require().NoError(err, "should not be error")
+	s.Equal(int64(1000), refunded.Amount, "the payment key should not replay the refund")
+}
+
+func (s *MemoryPaymentServiceSuite) TestRefund() {
+	piID, err := s.service.MakePayment(utils.UserExp1.CustomerID, savedCardID, domain.NewMoney(3000, utils.DefaultCurrency), "")
+
+	s.Require().NoError(err, "should not be error")
+
+	testCases := []struct {
+		desc    string
+		piID    string
+		amount  int64
+		wantErr bool
+	}{
+		{
+			desc:    "invalid payment intent",
+			piID:    "pi_asd",
+			amount:  1000,
+			wantErr: true,
+		},
+		{
+			desc:    "more than paid",
+			piID:    piID,
+			amount:  3001,
+			wantErr: true,
+		},
+		{
+			desc:    "partial refund",
+			piID:    piID,
+			amount:  1000,
+			wantErr: false,
+		},
+		{
+			desc:    "refund what is left",
+			piID:    piID,
+			amount:  0,
+			wantErr: false,
+		},
+		{
+			desc:    "nothing left to refund",
+			piID:    piID,
+			amount:  100,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			_, err := s.service.Refund(tC.piID, domain.NewMoney(tC.amount, utils.DefaultCurrency), "")
+
+			s.Equal(tC.wantErr, (err != nil), "expect err fail: %v", err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package payment
 
 import (
+	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/google/uuid"
 )
 
@@ -10,10 +11,23 @@ type PaymentService interface {
 	//CreatePaymentIntent(cusID string, amount int64) (string, error)
 	GetOrCreateCustomerID(uid uuid.UUID) (string, error)
 	DeleteCustomer(cusID string) error
-	MakePayment(cusID, pmID string, amount int64) error
+	// MakePayment charges the card. Calls with the same non empty
+	// idemKey return the first payment intent instead of charging again.
+	// When the charge is declined the payment intent comes with the error
+	MakePayment(cusID, pmID string, amount domain.Money, idemKey string) (string, error)
+	// Refund gives back the amount and returns everything refunded of
+	// the payment so far. Calls with the same non empty idemKey refund only once
+	Refund(piID string, amount domain.Money, idemKey string) (domain.Money, error)
 	GetCustomerCards(custID string) ([]Card, error)
 	AttachCardToCustomer(cardID, cusID string) error
 	DetachCardFromCustomer(cardID, cusID string) error
+	ParseWebhookEvent(payload []byte, signature string) (*domain.PaymentEvent, error)
+}
+
+// EventSource is a payment service that sends the payment events
+// itself instead of through the webhook.
+type EventSource interface {
+	OnEvent(fn func(evt *domain.PaymentEvent) error)
 }
 
 //* Models
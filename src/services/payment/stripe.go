@@ -1,15 +1,19 @@
 package payment
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
 	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/google/uuid"
 	"github.com/stripe/stripe-go/v74"
 	"github.com/stripe/stripe-go/v74/customer"
 	"github.com/stripe/stripe-go/v74/paymentintent"
 	"github.com/stripe/stripe-go/v74/paymentmethod"
+	"github.com/stripe/stripe-go/v74/refund"
+	"github.com/stripe/stripe-go/v74/webhook"
 )
 
 //! THIS TESTS DON'T WORK TOGETHER BECAUSE THE PM IS USED TWICE
@@ -17,47 +21,90 @@ import (
 //* Implementation
 
 type stripeServiceImpl struct {
-	usrRepo domain.UserRepository
+	whSecret string
+	usrRepo  domain.UserRepository
 }
 
 //* Constructor
 
-func NewStripePaymentService(sk string, usrRepo domain.UserRepository) PaymentService {
+func NewStripePaymentService(sk, whSecret string, usrRepo domain.UserRepository) PaymentService {
 	stripe.Key = sk
-	return &stripeServiceImpl{usrRepo: usrRepo}
+	return &stripeServiceImpl{whSecret: whSecret, usrRepo: usrRepo}
 }
 
-func (s *stripeServiceImpl) MakePayment(cusID, pmID string, amount int64) error {
+func (s *stripeServiceImpl) MakePayment(cusID, pmID string, amount domain.Money, idemKey string) (string, error) {
 	pm, err := paymentmethod.Get(
 		pmID,
 		nil,
 	)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if pm.Customer != nil {
 		if pm.Customer.ID != cusID {
-			return fmt.Errorf("this payment method is associated to another customer")
+			return "", fmt.Errorf("this payment method is associated to another customer")
 		}
 	}
 
-	piID, err := s.createPaymentIntent(cusID, amount)
+	piID, err := s.createPaymentIntent(cusID, amount, idemKey)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = paymentintent.Confirm(piID, &stripe.PaymentIntentConfirmParams{
+	confirmParams := &stripe.PaymentIntentConfirmParams{
 		PaymentMethod: stripe.String(pm.ID),
-	})
+	}
+
+	if idemKey != "" {
+		confirmParams.SetIdempotencyKey(idemKey + "-confirm")
+	}
 
+	_, err = paymentintent.Confirm(piID, confirmParams)
+
+	//* The intent exists even if it failed, its events come for it
 	if err != nil {
-		return fmt.Errorf("error confirming the payment intent: %w", err)
+		return piID, fmt.Errorf("error confirming the payment intent: %w", err)
 	}
 
-	return nil
+	return piID, nil
+}
+
+// Refund gives back the amount of a payment intent.
+// A zero amount refunds everything that is left.
+func (*stripeServiceImpl) Refund(piID string, amount domain.Money, idemKey string) (domain.Money, error) {
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(piID),
+	}
+
+	if amount.Amount > 0 {
+		params.Amount = stripe.Int64(amount.Amount)
+	}
+
+	if idemKey != "" {
+		params.SetIdempotencyKey(idemKey)
+	}
+
+	//* The charge has the total refunded
+	params.AddExpand("charge")
+
+	r, err := refund.New(params)
+
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("error refunding the payment intent: %w", err)
+	}
+
+	if r.Charge == nil {
+		return domain.Money{}, fmt.Errorf("the refund has no charge")
+	}
+
+	return domain.NewMoney(r.Charge.AmountRefunded, string(r.Charge.Currency)), nil
+}
+
+func (s *stripeServiceImpl) ParseWebhookEvent(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	return parseStripeEvent(payload, signature, s.whSecret)
 }
 
 func (s *stripeServiceImpl) GetOrCreateCustomerID(uid uuid.UUID) (string, error) {
@@ -158,16 +205,22 @@ func (*stripeServiceImpl) DetachCardFromCustomer(cardID, cusID string) error {
 	return err
 }
 
-func (s *stripeServiceImpl) createPaymentIntent(cusID string, amount int64) (string, error) {
+// createPaymentIntent sends the idempotency key to stripe, so a retried
+// request gets back the same payment intent.
+func (s *stripeServiceImpl) createPaymentIntent(cusID string, amount domain.Money, idemKey string) (string, error) {
 	params := &stripe.PaymentIntentParams{
-		Amount:   stripe.Int64(amount),
-		Currency: stripe.String(string(stripe.CurrencyUSD)),
+		Amount:   stripe.Int64(amount.Amount),
+		Currency: stripe.String(amount.Currency),
 		PaymentMethodTypes: []*string{
 			stripe.String("card"),
 		},
 		Customer: stripe.String(cusID),
 	}
 
+	if idemKey != "" {
+		params.SetIdempotencyKey(idemKey)
+	}
+
 	pi, err := paymentintent.New(params)
 
 	if err != nil {
@@ -176,3 +229,43 @@ func (s *stripeServiceImpl) createPaymentIntent(cusID string, amount int64) (str
 
 	return pi.ID, nil
 }
+
+// parseStripeEvent checks the signature of a stripe event and
+// takes from it what the orders need.
+func parseStripeEvent(payload []byte, signature, secret string) (*domain.PaymentEvent, error) {
+	evt, err := webhook.ConstructEventWithOptions(payload, signature, secret, webhook.ConstructEventOptions{
+		IgnoreAPIVersionMismatch: true,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidSignature, err)
+	}
+
+	pmEvt := &domain.PaymentEvent{ID: evt.ID, Type: string(evt.Type)}
+
+	switch pmEvt.Type {
+	case utils.EventPaymentSucceeded, utils.EventPaymentFailed:
+		var pi stripe.PaymentIntent
+
+		if err := json.Unmarshal(evt.Data.Raw, &pi); err != nil {
+			return nil, fmt.Errorf("error parsing the payment intent: %w", err)
+		}
+
+		pmEvt.PaymentIntentID = pi.ID
+	case utils.EventChargeRefunded:
+		var ch stripe.Charge
+
+		if err := json.Unmarshal(evt.Data.Raw, &ch); err != nil {
+			return nil, fmt.Errorf("error parsing the charge: %w", err)
+		}
+
+		if ch.PaymentIntent == nil {
+			return nil, fmt.Errorf("the charge has no payment intent")
+		}
+
+		pmEvt.PaymentIntentID = ch.PaymentIntent.ID
+		pmEvt.AmountRefunded = domain.NewMoney(ch.AmountRefunded, string(ch.Currency))
+	}
+
+	return pmEvt, nil
+}
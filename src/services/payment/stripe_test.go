@@ -1,13 +1,17 @@
 package payment
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/ZaphCode/clean-arch/config"
+	"github.com/ZaphCode/clean-arch/src/domain"
 	"github.com/ZaphCode/clean-arch/src/repositories/user"
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/stripe/stripe-go/v74"
 	"github.com/stripe/stripe-go/v74/paymentmethod"
+	"github.com/stripe/stripe-go/v74/webhook"
 
 	//"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/stretchr/testify/suite"
@@ -33,7 +37,7 @@ func (s *StripeServiceSuite) SetupSuite() {
 	cfg := config.Get()
 
 	userRepo := user.NewMemoryUserRepository(utils.UserAdmin, utils.UserExp1)
-	s.service = NewStripePaymentService(cfg.Stripe.SecretKey, userRepo)
+	s.service = NewStripePaymentService(cfg.Stripe.SecretKey, cfg.Stripe.WebhookSecret, userRepo)
 
 	utils.PrintBlueTesting(s.T(), "Init")
 }
@@ -42,6 +46,103 @@ func (s *StripeServiceSuite) TearDownSuite() {
 	utils.PrettyPrintTesting(s.T(), "Bye")
 }
 
+//* Webhook (works without network)
+
+func TestParseWebhookEvent(t *testing.T) {
+	secret := "whsec_test_secret"
+	svc := NewStripePaymentService("", secret, user.NewMemoryUserRepository())
+
+	sign := func(file, secret string, ts time.Time) ([]byte, string) {
+		payload, err := os.ReadFile("./testdata/" + file)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sp := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+			Payload:   payload,
+			Secret:    secret,
+			Timestamp: ts,
+		})
+
+		return sp.Payload, sp.Header
+	}
+
+	testCases := []struct {
+		desc       string
+		file       string
+		secret     string
+		ts         time.Time
+		wantErr    bool
+		wantType   string
+		wantRefund int64
+	}{
+		{
+			desc:    "wrong secret",
+			file:    "payment_succeeded.json",
+			secret:  "whsec_other",
+			ts:      time.Now(),
+			wantErr: true,
+		},
+		{
+			desc:    "too old",
+			file:    "payment_succeeded.json",
+			secret:  secret,
+			ts:      time.Now().Add(-time.Hour),
+			wantErr: true,
+		},
+		{
+			desc:     "payment succeeded",
+			file:     "payment_succeeded.json",
+			secret:   secret,
+			ts:       time.Now(),
+			wantType: utils.EventPaymentSucceeded,
+		},
+		{
+			desc:     "payment failed",
+			file:     "payment_failed.json",
+			secret:   secret,
+			ts:       time.Now(),
+			wantType: utils.EventPaymentFailed,
+		},
+		{
+			desc:       "charge refunded",
+			file:       "charge_refunded.json",
+			secret:     secret,
+			ts:         time.Now(),
+			wantType:   utils.EventChargeRefunded,
+			wantRefund: 1000,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			payload, header := sign(tC.file, tC.secret, tC.ts)
+
+			evt, err := svc.ParseWebhookEvent(payload, header)
+
+			if (err != nil) != tC.wantErr {
+				t.Fatalf("expect err fail: %v", err)
+			}
+
+			if tC.wantErr {
+				return
+			}
+
+			if evt.Type != tC.wantType {
+				t.Errorf("wrong event type: %s", evt.Type)
+			}
+
+			if evt.PaymentIntentID != "pi_3NKQexG8UXDxPRba0d7cVcUE" {
+				t.Errorf("wrong payment intent: %s", evt.PaymentIntentID)
+			}
+
+			if evt.AmountRefunded.Amount != tC.wantRefund {
+				t.Errorf("wrong refunded amount: %s", evt.AmountRefunded)
+			}
+		})
+	}
+}
+
 //* Tests
 
 func (s *StripeServiceSuite) TestCreateAndDeleteCustomerID() {
@@ -121,7 +222,7 @@ func (s *StripeServiceSuite) TestMakePayment() {
 	}
 	for i, tC := range testCases {
 		s.Run(tC.desc, func() {
-			err := s.service.MakePayment(tC.cusID, tC.pmID, 4599+int64(i+1*10))
+			_, err := s.service.MakePayment(tC.cusID, tC.pmID, domain.NewMoney(4599+int64(i+1*10), utils.DefaultCurrency), "")
 
 			s.Equal((err != nil), tC.wantErr, "expect err fail: %v", err)
 
@@ -130,6 +231,51 @@ func (s *StripeServiceSuite) TestMakePayment() {
 	}
 }
 
+func (s *StripeServiceSuite) TestRefund() {
+	piID, err := s.service.MakePayment(utils.UserExp1.CustomerID, "pm_1NKP27G8UXDxPRbaNZRE6Ajd", domain.NewMoney(3000, utils.DefaultCurrency), "")
+
+	s.Require().NoError(err, "should not be error")
+
+	testCases := []struct {
+		desc    string
+		piID    string
+		amount  int64
+		wantErr bool
+	}{
+		{
+			desc:    "invalid payment intent",
+			piID:    "pi_asd",
+			amount:  1000,
+			wantErr: true,
+		},
+		{
+			desc:    "partial refund",
+			piID:    piID,
+			amount:  1000,
+			wantErr: false,
+		},
+		{
+			desc:    "refund what is left",
+			piID:    piID,
+			amount:  0,
+			wantErr: false,
+		},
+		{
+			desc:    "nothing left to refund",
+			piID:    piID,
+			amount:  100,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			_, err := s.service.Refund(tC.piID, domain.NewMoney(tC.amount, utils.DefaultCurrency), "")
+
+			s.Equal(tC.wantErr, (err != nil), "expect err fail: %v", err)
+		})
+	}
+}
+
 func (s *StripeServiceSuite) TestDetachCard() {
 	err := s.service.DetachCardFromCustomer(
 		"pm_1NKPiEG8UXDxPRbaEDuh6BrU",
@@ -0,0 +1,136 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// folds takes the accents out, so "camión" finds "camion".
+var folds = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// token is a normalized word and where it is in the original text.
+type token struct {
+	term       string
+	start, end int // byte offsets
+}
+
+// tokenize splits the text in words of letters and digits.
+func tokenize(text string) []token {
+	tks := []token{}
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 {
+			tks = append(tks, token{term: normalize(text[start:end]), start: start, end: end})
+			start = -1
+		}
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+
+	flush(len(text))
+
+	return tks
+}
+
+func terms(text string) []string {
+	tks := tokenize(text)
+	ts := make([]string, len(tks))
+
+	for i, tk := range tks {
+		ts[i] = tk.term
+	}
+
+	return ts
+}
+
+func normalize(word string) string {
+	return folds.Replace(strings.ToLower(word))
+}
+
+// maxTypos is how many edits a query word of that length tolerates.
+func maxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance is the optimal string alignment distance, so a swap
+// of two letters ("tshirt", "thsirt") counts as one typo. It stops
+// early with max+1 when the words are further than max.
+func distance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+
+			best = minInt(best, cur[j])
+		}
+
+		if best > max {
+			return max + 1
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(vs ...int) int {
+	m := vs[0]
+
+	for _, v := range vs[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,482 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/whitespace"
+	"github.com/blevesearch/bleve/v2/index/scorch"
+	"github.com/blevesearch/bleve/v2/mapping"
+	bsearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	index "github.com/blevesearch/bleve_index_api"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 50
+	maxFacets    = 20
+
+	prefixWeight = 0.8 // the last word may be unfinished
+	typoWeight   = 0.6 // for every edit
+)
+
+// The indexed text fields and how much a match in them is worth.
+var boosts = map[string]float64{
+	"name":        3,
+	"tags":        2,
+	"category":    2,
+	"description": 1,
+}
+
+// Keyword fields, for the filters, the facets and the order.
+const (
+	fieldCategoryKey = "category_key"
+	fieldTagKeys     = "tag_keys"
+	fieldCategory    = "category_facet"
+	fieldTags        = "tag_facets"
+	fieldNameSort    = "name_sort"
+	fieldSource      = "source" // the product, stored only
+)
+
+// termsAnalyzer splits on spaces only, the texts are tokenized and
+// normalized by terms before they are indexed.
+const termsAnalyzer = "terms"
+
+//* Implementation
+
+type bleveSearchServiceImpl struct {
+	index bleve.Index
+}
+
+//* Constructor
+
+// NewBleveSearchService opens the index kept in dir, or creates it
+// when there is none yet, and syncs it with the products of seed, as
+// they may have changed while the index was closed. An empty dir
+// keeps the index in memory. Every instance has its own index, kept
+// in sync by the product service of that instance.
+func NewBleveSearchService(dir string, seed func() ([]domain.Product, error)) (SearchService, error) {
+	var (
+		idx bleve.Index
+		err error
+	)
+
+	if _, serr := os.Stat(dir); dir != "" && serr == nil {
+		if idx, err = bleve.Open(dir); err != nil {
+			return nil, fmt.Errorf("bleve.Open(): %w", err)
+		}
+	} else if idx, err = bleve.NewUsing(dir, indexMapping(), scorch.Name, scorch.Name, nil); err != nil {
+		return nil, fmt.Errorf("bleve.NewUsing(): %w", err)
+	}
+
+	s := &bleveSearchServiceImpl{index: idx}
+
+	prods, err := seed()
+
+	if err == nil {
+		err = s.sync(prods)
+	}
+
+	if err != nil {
+		idx.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NewMemorySearchService is an index in memory with the products,
+// handy for tests.
+func NewMemorySearchService(prods ...domain.Product) SearchService {
+	s, err := NewBleveSearchService("", func() ([]domain.Product, error) {
+		return prods, nil
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+func (s *bleveSearchServiceImpl) Index(prod domain.Product) {
+	if err := s.index.Index(prod.ID.String(), document(prod)); err != nil {
+		utils.PrintColor("red", "Error indexing the product: ", err)
+	}
+}
+
+func (s *bleveSearchServiceImpl) Remove(ID uuid.UUID) {
+	if err := s.index.Delete(ID.String()); err != nil {
+		utils.PrintColor("red", "Error removing the product from the index: ", err)
+	}
+}
+
+func (s *bleveSearchServiceImpl) Search(q Query) (Result, error) {
+	res := Result{Hits: []Hit{}, Facets: Facets{Categories: []FacetCount{}, Tags: []FacetCount{}}}
+
+	words, err := s.textQuery(unique(terms(q.Text)))
+
+	if err != nil {
+		return Result{}, fmt.Errorf("error building the query: %w", err)
+	}
+
+	if words == nil {
+		return res, nil
+	}
+
+	qry := query.Query(words)
+	filters := []query.Query{words}
+
+	if cat := normalize(q.Category); cat != "" {
+		filters = append(filters, termQuery(fieldCategoryKey, cat, 0))
+	}
+
+	if tag := normalize(q.Tag); tag != "" {
+		filters = append(filters, termQuery(fieldTagKeys, tag, 0))
+	}
+
+	if len(filters) > 1 {
+		qry = bleve.NewConjunctionQuery(filters...)
+	}
+
+	limit := q.Limit
+
+	if limit <= 0 || limit > maxLimit {
+		limit = defaultLimit
+	}
+
+	req := bleve.NewSearchRequestOptions(qry, limit, q.Offset, false)
+	req.Fields = []string{fieldSource}
+	req.IncludeLocations = true
+	req.SortBy([]string{"-_score", fieldNameSort, "_id"})
+	req.AddFacet("categories", bleve.NewFacetRequest(fieldCategory, maxFacets))
+	req.AddFacet("tags", bleve.NewFacetRequest(fieldTags, maxFacets))
+
+	sr, err := s.index.Search(req)
+
+	if err != nil {
+		return Result{}, fmt.Errorf("index.Search(): %w", err)
+	}
+
+	res.Total = int(sr.Total)
+	res.Facets = Facets{
+		Categories: facetCounts(sr.Facets["categories"]),
+		Tags:       facetCounts(sr.Facets["tags"]),
+	}
+
+	for _, h := range sr.Hits {
+		var prod domain.Product
+
+		src, _ := h.Fields[fieldSource].(string)
+
+		if err := json.Unmarshal([]byte(src), &prod); err != nil {
+			return Result{}, fmt.Errorf("error reading the indexed product %s: %w", h.ID, err)
+		}
+
+		matched := map[string]bool{}
+
+		for _, tls := range h.Locations {
+			for t := range tls {
+				matched[t] = true
+			}
+		}
+
+		res.Hits = append(res.Hits, Hit{
+			Product:    prod,
+			Score:      math.Round(h.Score*1000) / 1000,
+			Highlights: highlights(prod, matched),
+		})
+	}
+
+	return res, nil
+}
+
+// sync makes the index hold the products and only them.
+func (s *bleveSearchServiceImpl) sync(prods []domain.Product) error {
+	batch := s.index.NewBatch()
+	keep := make(map[string]bool, len(prods))
+
+	for _, p := range prods {
+		keep[p.ID.String()] = true
+
+		if err := batch.Index(p.ID.String(), document(p)); err != nil {
+			return fmt.Errorf("error indexing product %s: %w", p.ID, err)
+		}
+	}
+
+	n, err := s.index.DocCount()
+
+	if err != nil {
+		return fmt.Errorf("index.DocCount(): %w", err)
+	}
+
+	if n > 0 {
+		sr, err := s.index.Search(bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), int(n), 0, false))
+
+		if err != nil {
+			return fmt.Errorf("index.Search(): %w", err)
+		}
+
+		for _, h := range sr.Hits {
+			if !keep[h.ID] {
+				batch.Delete(h.ID)
+			}
+		}
+	}
+
+	if err := s.index.Batch(batch); err != nil {
+		return fmt.Errorf("error indexing the products: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func indexMapping() mapping.IndexMapping {
+	im := bleve.NewIndexMapping()
+
+	if err := im.AddCustomAnalyzer(termsAnalyzer, map[string]interface{}{
+		"type":      custom.Name,
+		"tokenizer": whitespace.Name,
+	}); err != nil {
+		panic(err)
+	}
+
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = termsAnalyzer
+	text.Store = false
+	text.IncludeInAll = false
+
+	keyword := bleve.NewKeywordFieldMapping()
+	keyword.Store = false
+	keyword.IncludeInAll = false
+	keyword.IncludeTermVectors = false
+
+	source := bleve.NewTextFieldMapping()
+	source.Index = false
+	source.IncludeInAll = false
+	source.IncludeTermVectors = false
+	source.DocValues = false
+
+	dm := bleve.NewDocumentStaticMapping()
+
+	for f := range boosts {
+		dm.AddFieldMappingsAt(f, text)
+	}
+
+	for _, f := range []string{fieldCategoryKey, fieldTagKeys, fieldCategory, fieldTags, fieldNameSort} {
+		dm.AddFieldMappingsAt(f, keyword)
+	}
+
+	dm.AddFieldMappingsAt(fieldSource, source)
+
+	im.DefaultMapping = dm
+
+	return im
+}
+
+// document is what is indexed of a product. The text fields go
+// already split in normalized words.
+func document(prod domain.Product) map[string]interface{} {
+	src, _ := json.Marshal(prod)
+
+	tagKeys := make([]string, len(prod.Tags))
+
+	for i, t := range prod.Tags {
+		tagKeys[i] = normalize(t)
+	}
+
+	return map[string]interface{}{
+		"name":           strings.Join(terms(prod.Name), " "),
+		"tags":           strings.Join(terms(strings.Join(prod.Tags, " ")), " "),
+		"category":       strings.Join(terms(prod.Category), " "),
+		"description":    strings.Join(terms(prod.Description), " "),
+		fieldCategoryKey: normalize(prod.Category),
+		fieldTagKeys:     tagKeys,
+		fieldCategory:    prod.Category,
+		fieldTags:        prod.Tags,
+		fieldNameSort:    normalize(prod.Name),
+		fieldSource:      string(src),
+	}
+}
+
+// textQuery matches any of the words, the products with more of
+// them score higher. It is nil when no word can match.
+func (s *bleveSearchServiceImpl) textQuery(qterms []string) (query.Query, error) {
+	if len(qterms) == 0 {
+		return nil, nil
+	}
+
+	words := []query.Query{}
+
+	for i, qt := range qterms {
+		exp, err := s.expand(qt, i == len(qterms)-1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fields := []query.Query{}
+
+		for t, w := range exp {
+			for f, boost := range boosts {
+				fields = append(fields, termQuery(f, t, w*boost))
+			}
+		}
+
+		if len(fields) > 0 {
+			words = append(words, bleve.NewDisjunctionQuery(fields...))
+		}
+	}
+
+	if len(words) == 0 {
+		return bleve.NewMatchNoneQuery(), nil
+	}
+
+	return bleve.NewDisjunctionQuery(words...), nil
+}
+
+// expand finds the indexed terms a query word stands for and how
+// much each is worth: the word itself, the words a few typos away
+// and, for the last word, the words it starts. The candidates come
+// from the term dictionaries, the vocabulary is not scanned.
+func (s *bleveSearchServiceImpl) expand(qt string, last bool) (map[string]float64, error) {
+	idx, err := s.index.Advanced()
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := idx.Reader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	fuzzy, ok := r.(index.IndexReaderFuzzy)
+
+	if !ok {
+		return nil, errors.New("the index cannot look up similar terms")
+	}
+
+	//* A term missing from the index just matches nothing
+	exp := map[string]float64{qt: 1}
+	typos := maxTypos(qt)
+
+	for f := range boosts {
+		if typos > 0 {
+			//* Levenshtein counts a swap as two edits, the distance below as one
+			fuzziness := typos + 1
+
+			if fuzziness > 2 {
+				fuzziness = 2
+			}
+
+			dict, err := fuzzy.FieldDictFuzzy(f, qt, fuzziness, "")
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = eachTerm(dict, func(t string) {
+				if d := distance(qt, t, typos); d <= typos {
+					exp[t] = math.Max(exp[t], math.Pow(typoWeight, float64(d)))
+				}
+			})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !last || len(qt) < 2 {
+			continue
+		}
+
+		dict, err := r.FieldDictPrefix(f, []byte(qt))
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = eachTerm(dict, func(t string) {
+			exp[t] = math.Max(exp[t], prefixWeight)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return exp, nil
+}
+
+func eachTerm(dict index.FieldDict, fn func(t string)) error {
+	defer dict.Close()
+
+	for {
+		de, err := dict.Next()
+
+		if err != nil {
+			return err
+		}
+
+		if de == nil {
+			return nil
+		}
+
+		fn(de.Term)
+	}
+}
+
+func termQuery(field, term string, boost float64) query.Query {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+
+	if boost > 0 {
+		q.SetBoost(boost)
+	}
+
+	return q
+}
+
+func facetCounts(fr *bsearch.FacetResult) []FacetCount {
+	fcs := []FacetCount{}
+
+	if fr == nil || fr.Terms == nil {
+		return fcs
+	}
+
+	for _, tf := range fr.Terms.Terms() {
+		fcs = append(fcs, FacetCount{Value: tf.Term, Count: tf.Count})
+	}
+
+	return fcs
+}
+
+func unique(ts []string) []string {
+	seen := map[string]bool{}
+	us := []string{}
+
+	for _, t := range ts {
+		if !seen[t] {
+			seen[t] = true
+			us = append(us, t)
+		}
+	}
+
+	return us
+}
@@ -0,0 +1,256 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+func newTestSearchService() SearchService {
+	return NewMemorySearchService(
+		utils.ProductExp1,      // Black T-shirt, clothes
+		utils.ProductExp2,      // Corsair void pro, headsets
+		utils.ProductExpToDev1, // Adidas Black T-Shirt Basketball, tenis
+		utils.ProductExpToDev2, // Nike Black Cup, clothes
+	)
+}
+
+func names(hits []Hit) []string {
+	ns := []string{}
+	for _, h := range hits {
+		ns = append(ns, h.Product.Name)
+	}
+	return ns
+}
+
+func Test_bleveSearchService_Search(t *testing.T) {
+	s := newTestSearchService()
+
+	tests := []struct {
+		name      string
+		query     Query
+		wantNames []string
+	}{
+		{
+			name:  "ranking",
+			query: Query{Text: "black t-shirt"},
+			wantNames: []string{
+				utils.ProductExp1.Name,
+				utils.ProductExpToDev1.Name,
+				utils.ProductExpToDev2.Name,
+			},
+		},
+		{
+			name:      "typo",
+			query:     Query{Text: "corsiar"},
+			wantNames: []string{utils.ProductExp2.Name},
+		},
+		{
+			name:      "two typos in a long word",
+			query:     Query{Text: "baskteboll"},
+			wantNames: []string{utils.ProductExpToDev1.Name},
+		},
+		{
+			name:      "short words need to be exact",
+			query:     Query{Text: "cap"},
+			wantNames: []string{},
+		},
+		{
+			name:      "unfinished last word",
+			query:     Query{Text: "head"},
+			wantNames: []string{utils.ProductExp2.Name},
+		},
+		{
+			name:      "case and accents",
+			query:     Query{Text: "NÏKE"},
+			wantNames: []string{utils.ProductExpToDev2.Name},
+		},
+		{
+			name:      "category filter",
+			query:     Query{Text: "black", Category: "Clothes"},
+			wantNames: []string{utils.ProductExp1.Name, utils.ProductExpToDev2.Name},
+		},
+		{
+			name:      "tag filter",
+			query:     Query{Text: "black", Tag: "adidas"},
+			wantNames: []string{utils.ProductExpToDev1.Name},
+		},
+		{
+			name:      "page",
+			query:     Query{Text: "black", Limit: 1, Offset: 1},
+			wantNames: []string{utils.ProductExpToDev2.Name},
+		},
+		{
+			name:      "nothing to search",
+			query:     Query{Text: " ¿? "},
+			wantNames: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(mustSearch(t, s, tt.query).Hits)
+
+			if len(got) != len(tt.wantNames) {
+				t.Fatalf("Search() = %v, want %v", got, tt.wantNames)
+			}
+
+			for i := range got {
+				if got[i] != tt.wantNames[i] {
+					t.Errorf("Search() = %v, want %v", got, tt.wantNames)
+					break
+				}
+			}
+		})
+	}
+}
+
+func Test_bleveSearchService_Facets(t *testing.T) {
+	res := mustSearch(t, newTestSearchService(), Query{Text: "black", Limit: 1})
+
+	if res.Total != 3 || len(res.Hits) != 1 {
+		t.Fatalf("Search() total = %d, hits = %d, want 3 and 1", res.Total, len(res.Hits))
+	}
+
+	want := FacetCount{Value: "clothes", Count: 2}
+
+	if len(res.Facets.Categories) != 2 || res.Facets.Categories[0] != want {
+		t.Errorf("category facets = %v, want %v first of 2", res.Facets.Categories, want)
+	}
+
+	want = FacetCount{Value: "clothes", Count: 3}
+
+	if len(res.Facets.Tags) == 0 || res.Facets.Tags[0] != want {
+		t.Errorf("tag facets = %v, want %v first", res.Facets.Tags, want)
+	}
+}
+
+func Test_bleveSearchService_Highlights(t *testing.T) {
+	prod := utils.ProductExp2
+	prod.Name = "Corsair <void> pro"
+	prod.Description = "one two three four five six seven eight nine ten eleven twelve " +
+		"corsair thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty " +
+		"twenty-one twenty-two twenty-three twenty-four twenty-five twenty-six"
+
+	res := mustSearch(t, NewMemorySearchService(prod), Query{Text: "corsiar"})
+
+	if len(res.Hits) != 1 {
+		t.Fatalf("Search() hits = %d, want 1", len(res.Hits))
+	}
+
+	hls := res.Hits[0].Highlights
+
+	if want := "<mark>Corsair</mark> &lt;void&gt; pro"; hls["name"] != want {
+		t.Errorf("name highlight = %q, want %q", hls["name"], want)
+	}
+
+	if want := "…seven eight nine ten eleven twelve <mark>corsair</mark> thirteen"; len(hls["description"]) < len(want) || hls["description"][:len(want)] != want {
+		t.Errorf("description highlight = %q, want it to start with %q", hls["description"], want)
+	}
+
+	if want := "<mark>corsair</mark>"; hls["tags"] != "headsets, "+want+", technology" {
+		t.Errorf("tags highlight = %q", hls["tags"])
+	}
+}
+
+func Test_bleveSearchService_Sync(t *testing.T) {
+	s := newTestSearchService()
+
+	prod := utils.ProductExp2
+	prod.Name = "Logitech g613"
+	prod.Tags = []string{"tech"}
+	prod.Category = "keyboards"
+	prod.Description = "wireless keyboard"
+
+	s.Index(prod)
+
+	if got := mustSearch(t, s, Query{Text: "corsair"}); got.Total != 0 {
+		t.Errorf("the old words should be gone, got %v", names(got.Hits))
+	}
+
+	if got := mustSearch(t, s, Query{Text: "logitech"}); got.Total != 1 {
+		t.Errorf("the new words should be found, got %v", names(got.Hits))
+	}
+
+	s.Remove(prod.ID)
+
+	if got := mustSearch(t, s, Query{Text: "logitech"}); got.Total != 0 {
+		t.Errorf("removed products should not be found, got %v", names(got.Hits))
+	}
+
+	s.Remove(prod.ID)
+	s.Index(domain.Product{Model: prod.Model})
+
+	if got := mustSearch(t, s, Query{Text: "black"}); got.Total != 3 {
+		t.Errorf("other products should stay, got %v", names(got.Hits))
+	}
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"shirt", "shirt", 1, 0},
+		{"shirt", "shirts", 1, 1},
+		{"tshirt", "thsirt", 1, 1},
+		{"camion", "camión", 1, 1},
+		{"black", "white", 2, 3},
+		{"a", "abcd", 1, 2},
+	}
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b, tt.max); got != tt.want {
+			t.Errorf("distance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+		}
+	}
+}
+
+func Test_bleveSearchService_Persist(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "search.bleve")
+	catalog := []domain.Product{utils.ProductExp1, utils.ProductExp2}
+
+	seed := func() ([]domain.Product, error) {
+		return catalog, nil
+	}
+
+	s, err := NewBleveSearchService(dir, seed)
+
+	if err != nil {
+		t.Fatalf("NewBleveSearchService() error = %v", err)
+	}
+
+	//* The index misses a product and the catalog changes while it is closed
+	s.Remove(utils.ProductExp2.ID)
+	s.(*bleveSearchServiceImpl).index.Close()
+	catalog = []domain.Product{utils.ProductExp2}
+
+	s, err = NewBleveSearchService(dir, seed)
+
+	if err != nil {
+		t.Fatalf("NewBleveSearchService() reopen error = %v", err)
+	}
+
+	defer s.(*bleveSearchServiceImpl).index.Close()
+
+	if got := mustSearch(t, s, Query{Text: "corsair"}); got.Total != 1 {
+		t.Errorf("the missing product should be indexed again, got %v", names(got.Hits))
+	}
+
+	if got := mustSearch(t, s, Query{Text: "black"}); got.Total != 0 {
+		t.Errorf("the product gone from the catalog should be dropped, got %v", names(got.Hits))
+	}
+}
+
+func mustSearch(t *testing.T, s SearchService, q Query) Result {
+	t.Helper()
+
+	res, err := s.Search(q)
+
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	return res
+}
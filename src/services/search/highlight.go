@@ -0,0 +1,100 @@
+package search
+
+import (
+	"html"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+)
+
+const snippetWords = 24 // words of description around the first match
+
+// highlights marks the matched words of the name, the description
+// and the tags. Only the fields with a match are returned.
+func highlights(prod domain.Product, matched map[string]bool) map[string]string {
+	hls := map[string]string{}
+
+	if hl, ok := highlight(prod.Name, matched, 0); ok {
+		hls["name"] = hl
+	}
+
+	if hl, ok := highlight(prod.Description, matched, snippetWords); ok {
+		hls["description"] = hl
+	}
+
+	if hl, ok := highlight(strings.Join(prod.Tags, ", "), matched, 0); ok {
+		hls["tags"] = hl
+	}
+
+	return hls
+}
+
+// highlight escapes the text and wraps the matched words in <mark>
+// tags. With a words limit, it only keeps that many words, starting
+// a little before the first match.
+func highlight(text string, matched map[string]bool, words int) (string, bool) {
+	tks := tokenize(text)
+	first := -1
+
+	for i, tk := range tks {
+		if matched[tk.term] {
+			first = i
+			break
+		}
+	}
+
+	if first < 0 {
+		return "", false
+	}
+
+	from, to := 0, len(tks)
+
+	if words > 0 && len(tks) > words {
+		from = first - words/4
+
+		if from < 0 {
+			from = 0
+		}
+
+		to = from + words
+
+		if to > len(tks) {
+			to, from = len(tks), len(tks)-words
+		}
+	}
+
+	var sb strings.Builder
+
+	start, end := 0, len(text)
+
+	if from > 0 {
+		start = tks[from].start
+		sb.WriteString("…")
+	}
+
+	if to < len(tks) {
+		end = tks[to-1].end
+	}
+
+	pos := start
+
+	for _, tk := range tks[from:to] {
+		if !matched[tk.term] {
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(text[pos:tk.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(text[tk.start:tk.end]))
+		sb.WriteString("</mark>")
+		pos = tk.end
+	}
+
+	sb.WriteString(html.EscapeString(text[pos:end]))
+
+	if to < len(tks) {
+		sb.WriteString("…")
+	}
+
+	return sb.String(), true
+}
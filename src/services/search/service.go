@@ -0,0 +1,50 @@
+package search
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+)
+
+//* Service
+
+// SearchService answers text queries over the catalog. It is fed
+// by the product service through the domain.ProductIndexer methods.
+type SearchService interface {
+	domain.ProductIndexer
+	Search(q Query) (Result, error)
+}
+
+//* Models
+
+// Query is a text search. Category and Tag narrow the results
+// to one facet value, the facet counts are of what is left.
+type Query struct {
+	Text     string
+	Category string
+	Tag      string
+	Limit    int
+	Offset   int
+}
+
+type Result struct {
+	Hits   []Hit  `json:"hits"`
+	Total  int    `json:"total"`
+	Facets Facets `json:"facets"`
+}
+
+// Hit is a matching product. Highlights has the matched
+// fields with the matched words wrapped in <mark> tags.
+type Hit struct {
+	Product    domain.Product    `json:"product"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type Facets struct {
+	Categories []FacetCount `json:"categories"`
+	Tags       []FacetCount `json:"tags"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
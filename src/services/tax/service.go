@@ -0,0 +1,28 @@
+package tax
+
+import (
+	"github.com/ZaphCode/clean-arch/src/domain"
+)
+
+//* Service
+
+type TaxCalculator interface {
+	// Calculate returns the tax line of the priced lines and the shipping
+	// sent to the address. The order discount is spread over the lines.
+	Calculate(addr *domain.Address, ops []domain.OrderProduct, discount, shipping domain.Money) (domain.OrderTax, error)
+}
+
+//* Models
+
+// Rate is a row of a tax table. An empty State or PostalPrefix
+// matches the whole country. The most specific row wins.
+type Rate struct {
+	Name             string   `json:"name"`
+	Country          string   `json:"country"`
+	State            string   `json:"state"`
+	PostalPrefix     string   `json:"postal_prefix"`
+	Rate             int64    `json:"rate"` // basis points, 825 is 8.25%
+	Inclusive        bool     `json:"inclusive"`
+	TaxShipping      bool     `json:"tax_shipping"`
+	ExemptCategories []string `json:"exempt_categories"`
+}
@@ -0,0 +1,142 @@
+package tax
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+//* Implementation
+
+type tableTaxCalculatorImpl struct {
+	rates []Rate
+}
+
+//* Constructors
+
+func NewTableTaxCalculator(rates ...Rate) TaxCalculator {
+	return &tableTaxCalculatorImpl{rates: rates}
+}
+
+// MustLoadTableTaxCalculator reads the rates from a json array.
+func MustLoadTableTaxCalculator(filename string) TaxCalculator {
+	file, err := os.ReadFile(filename)
+
+	if err != nil {
+		panic(err)
+	}
+
+	rates := []Rate{}
+
+	if err := json.Unmarshal(file, &rates); err != nil {
+		panic(err)
+	}
+
+	for _, r := range rates {
+		if r.Country == "" || r.Rate < 0 {
+			panic(fmt.Sprintf("invalid tax rate %q: it needs a country and a non-negative rate", r.Name))
+		}
+	}
+
+	return NewTableTaxCalculator(rates...)
+}
+
+func (t *tableTaxCalculatorImpl) Calculate(
+	addr *domain.Address,
+	ops []domain.OrderProduct,
+	discount, shipping domain.Money,
+) (domain.OrderTax, error) {
+	var err error
+
+	rate := t.match(addr)
+	subtotal, taxable := domain.Money{}, domain.Money{}
+
+	for _, op := range ops {
+		if subtotal, err = subtotal.Add(op.LineTotal); err != nil {
+			return domain.OrderTax{}, err
+		}
+
+		if rate == nil || utils.ItemInSlice(op.Category, rate.ExemptCategories) {
+			continue
+		}
+
+		if taxable, err = taxable.Add(op.LineTotal); err != nil {
+			return domain.OrderTax{}, err
+		}
+	}
+
+	if rate == nil {
+		return domain.OrderTax{Amount: domain.NewMoney(0, subtotal.Currency)}, nil
+	}
+
+	//* The exempt lines keep their share of the discount
+	if !discount.IsZero() && !subtotal.IsZero() {
+		if taxable, err = taxable.Sub(discount.Scale(taxable.Amount, subtotal.Amount)); err != nil {
+			return domain.OrderTax{}, err
+		}
+	}
+
+	if rate.TaxShipping {
+		if taxable, err = taxable.Add(shipping); err != nil {
+			return domain.OrderTax{}, err
+		}
+	}
+
+	amount := taxable.Scale(rate.Rate, 10000)
+
+	if rate.Inclusive {
+		amount = taxable.Scale(rate.Rate, 10000+rate.Rate)
+	}
+
+	amount.Currency = subtotal.Currency
+
+	return domain.OrderTax{
+		Name:      rate.Name,
+		Rate:      rate.Rate,
+		Amount:    amount,
+		Inclusive: rate.Inclusive,
+	}, nil
+}
+
+// Helper functions
+
+func (t *tableTaxCalculatorImpl) match(addr *domain.Address) *Rate {
+	var best *Rate
+	bestScore := -1
+
+	for i := range t.rates {
+		r := &t.rates[i]
+
+		if !strings.EqualFold(r.Country, addr.Country) {
+			continue
+		}
+
+		if r.State != "" && !strings.EqualFold(r.State, addr.State) {
+			continue
+		}
+
+		if r.PostalPrefix != "" && !strings.HasPrefix(addr.PostalCode, r.PostalPrefix) {
+			continue
+		}
+
+		score := 0
+
+		if r.State != "" {
+			score += 1
+		}
+
+		if r.PostalPrefix != "" {
+			score += 2
+		}
+
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,135 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/stretchr/testify/suite"
+)
+
+type TableTaxCalculatorSuite struct {
+	suite.Suite
+	calc TaxCalculator
+}
+
+func TestTableTaxCalculatorSuite(t *testing.T) {
+	suite.Run(t, new(TableTaxCalculatorSuite))
+}
+
+func (s *TableTaxCalculatorSuite) SetupSuite() {
+	s.calc = MustLoadTableTaxCalculator("./../../../config/tax_rates.json")
+}
+
+//* Tests
+
+func (s *TableTaxCalculatorSuite) TestCalculate() {
+	usd := func(n int64) domain.Money { return domain.NewMoney(n, utils.DefaultCurrency) }
+
+	shirt := domain.OrderProduct{Category: "clothes", LineTotal: usd(2000)}
+	book := domain.OrderProduct{Category: "books", LineTotal: usd(1000)}
+
+	addr := func(country, state, postal string) *domain.Address {
+		return &domain.Address{Country: country, State: state, PostalCode: postal}
+	}
+
+	testCases := []struct {
+		desc          string
+		addr          *domain.Address
+		ops           []domain.OrderProduct
+		discount      domain.Money
+		shipping      domain.Money
+		wantName      string
+		wantAmount    int64
+		wantInclusive bool
+	}{
+		{
+			desc:       "no rate for the country",
+			addr:       addr("Canada", "Ontario", "M5V"),
+			ops:        []domain.OrderProduct{shirt},
+			wantAmount: 0,
+		},
+		{
+			desc:       "state rate",
+			addr:       addr("usa", "washington", "99201"),
+			ops:        []domain.OrderProduct{shirt},
+			wantName:   "Washington sales tax",
+			wantAmount: 130,
+		},
+		{
+			desc:       "postal code rate wins",
+			addr:       addr("USA", "Washington", "98101"),
+			ops:        []domain.OrderProduct{shirt},
+			wantName:   "Seattle sales tax",
+			wantAmount: 207,
+		},
+		{
+			desc:       "exempt category",
+			addr:       addr("USA", "Washington", "99201"),
+			ops:        []domain.OrderProduct{shirt, book},
+			wantName:   "Washington sales tax",
+			wantAmount: 130,
+		},
+		{
+			desc:       "discount spread over the lines",
+			addr:       addr("USA", "Washington", "99201"),
+			ops:        []domain.OrderProduct{shirt, book},
+			discount:   usd(300),
+			wantName:   "Washington sales tax",
+			wantAmount: 117,
+		},
+		{
+			desc:       "taxed shipping",
+			addr:       addr("USA", "Washington", "99201"),
+			ops:        []domain.OrderProduct{shirt},
+			shipping:   usd(500),
+			wantName:   "Washington sales tax",
+			wantAmount: 163,
+		},
+		{
+			desc:       "untaxed shipping",
+			addr:       addr("USA", "California", "90001"),
+			ops:        []domain.OrderProduct{shirt},
+			shipping:   usd(500),
+			wantName:   "California sales tax",
+			wantAmount: 145,
+		},
+		{
+			desc:          "tax included",
+			addr:          addr("Mexico", "Jalisco", "44100"),
+			ops:           []domain.OrderProduct{shirt},
+			shipping:      usd(320),
+			wantName:      "IVA",
+			wantAmount:    320,
+			wantInclusive: true,
+		},
+		{
+			desc:          "country rate when the state has none",
+			addr:          addr("Mexico", "Baja California Sur", "23473"),
+			ops:           []domain.OrderProduct{book},
+			wantName:      "IVA",
+			wantAmount:    138,
+			wantInclusive: true,
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			got, err := s.calc.Calculate(tC.addr, tC.ops, tC.discount, tC.shipping)
+
+			s.Require().NoError(err, "should not be error")
+			s.Equal(tC.wantName, got.Name, "wrong rate")
+			s.Equal(tC.wantAmount, got.Amount.Amount, "wrong amount")
+			s.Equal(utils.DefaultCurrency, got.Amount.Currency, "wrong currency")
+			s.Equal(tC.wantInclusive, got.Inclusive, "wrong pricing")
+		})
+	}
+}
+
+func (s *TableTaxCalculatorSuite) TestCalculate_MixedCurrencies() {
+	_, err := s.calc.Calculate(&domain.Address{Country: "USA", State: "Washington"}, []domain.OrderProduct{
+		{Category: "clothes", LineTotal: domain.NewMoney(2000, "usd")},
+		{Category: "clothes", LineTotal: domain.NewMoney(2000, "mxn")},
+	}, domain.Money{}, domain.Money{})
+
+	s.Error(err, "should be error")
+}
@@ -0,0 +1,284 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+	"github.com/ZaphCode/clean-arch/src/utils"
+	"github.com/google/uuid"
+)
+
+// rendition is a size the images are stored in, by its longest side.
+type rendition struct {
+	name string
+	side int
+}
+
+var renditions = []rendition{
+	{name: "thumbnail", side: 150},
+	{name: "medium", side: 600},
+	{name: "large", side: 1200},
+}
+
+const jpegQuality = 85
+
+// ImageEncoder writes an image in one format.
+type ImageEncoder func(io.Writer, image.Image) error
+
+// renderedImage is a rendition ready to be stored.
+type renderedImage struct {
+	domain.ImageRendition
+	contentType string
+	ext         string
+	data        []byte
+}
+
+// putFunc stores the content under name and returns its url.
+type putFunc func(name, contentType string, r io.Reader) (string, error)
+
+// removeFunc deletes the content stored under name.
+type removeFunc func(name string) error
+
+// formatExts are the file extensions of the rendition formats.
+var formatExts = map[string]string{"jpeg": ".jpg", "png": ".png", "webp": ".webp"}
+
+// storeImage renders the image and stores every rendition with put.
+// When a rendition cannot be stored, the ones already stored are
+// removed.
+func storeImage(r io.Reader, put putFunc, remove removeFunc) (*domain.Image, error) {
+	id, err := uuid.NewUUID()
+
+	if err != nil {
+		return nil, fmt.Errorf("uuid.NewUUID() error : %w", err)
+	}
+
+	imgs, err := renderImage(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	img := &domain.Image{ID: id}
+
+	for _, ri := range imgs {
+		name := renditionName(id, ri.Name, ri.ext)
+
+		if ri.URL, err = put(name, ri.contentType, bytes.NewReader(ri.data)); err != nil {
+			if rerr := removeImage(*img, remove); rerr != nil {
+				return nil, fmt.Errorf("%w (and removing the stored renditions: %v)", err, rerr)
+			}
+			return nil, err
+		}
+
+		img.Renditions = append(img.Renditions, ri.ImageRendition)
+	}
+
+	return img, nil
+}
+
+// removeImage deletes every rendition of the image with remove, the
+// images set by url were not stored here and are left alone.
+func removeImage(img domain.Image, remove removeFunc) error {
+	errs := []string{}
+
+	for _, rd := range img.Renditions {
+		ext, ok := formatExts[rd.Format]
+
+		if !ok {
+			continue
+		}
+
+		if err := remove(renditionName(img.ID, rd.Name, ext)); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error removing the image %s: %s", img.ID, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func renditionName(id uuid.UUID, name, ext string) string {
+	return fmt.Sprintf("%s-%s%s", id, name, ext)
+}
+
+// renderImage decodes the image and encodes it again in every
+// rendition. Only the pixels are kept, so the metadata (like the
+// EXIF) is left behind once its orientation has been applied.
+func renderImage(r io.Reader) ([]renderedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(r, utils.MaxImageSize+1))
+
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	if len(data) > utils.MaxImageSize {
+		return nil, fmt.Errorf("%w: it is bigger than %d MB", utils.ErrInvalidImage, utils.MaxImageSize>>20)
+	}
+
+	//* The size is checked before decoding all the pixels
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidImage, err)
+	}
+
+	if cfg.Width < 1 || cfg.Height < 1 {
+		return nil, fmt.Errorf("%w: it has no pixels", utils.ErrInvalidImage)
+	}
+
+	if cfg.Width > utils.MaxImageWidth || cfg.Height > utils.MaxImageHeight {
+		return nil, fmt.Errorf(
+			"%w: it is bigger than %dx%d pixels",
+			utils.ErrInvalidImage, utils.MaxImageWidth, utils.MaxImageHeight,
+		)
+	}
+
+	if cfg.Width*cfg.Height > utils.MaxImagePixels {
+		return nil, fmt.Errorf("%w: it has more than %d megapixels", utils.ErrInvalidImage, utils.MaxImagePixels/1_000_000)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidImage, err)
+	}
+
+	rgba, ok := src.(*image.RGBA)
+
+	if !ok || rgba.Rect.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, cfg.Width, cfg.Height))
+		draw.Draw(rgba, rgba.Bounds(), src, src.Bounds().Min, draw.Src)
+	}
+
+	if format == "jpeg" {
+		rgba = orient(rgba, jpegOrientation(data))
+	}
+
+	//* Photos go as jpeg, the images with transparency as png
+	format, contentType, ext := "jpeg", "image/jpeg", ".jpg"
+	encode := func(w io.Writer, m image.Image) error {
+		return jpeg.Encode(w, m, &jpeg.Options{Quality: jpegQuality})
+	}
+
+	if !rgba.Opaque() {
+		format, contentType, ext, encode = "png", "image/png", ".png", png.Encode
+	}
+
+	imgs := []renderedImage{}
+
+	for _, rd := range renditions {
+		m := resize(rgba, rd.side)
+
+		ri, err := encodeRendition(m, rd.name, format, contentType, ext, encode)
+
+		if err != nil {
+			return nil, err
+		}
+
+		imgs = append(imgs, ri)
+
+		if !WebP {
+			continue
+		}
+
+		ri, err = encodeRendition(m, rd.name, "webp", "image/webp", ".webp", encodeWebP)
+
+		if err != nil {
+			return nil, err
+		}
+
+		imgs = append(imgs, ri)
+	}
+
+	return imgs, nil
+}
+
+func encodeRendition(
+	m image.Image, name, format, contentType, ext string, encode ImageEncoder,
+) (renderedImage, error) {
+	buf := &bytes.Buffer{}
+
+	if err := encode(buf, m); err != nil {
+		return renderedImage{}, fmt.Errorf("error encoding the %s %s: %v", name, format, err)
+	}
+
+	return renderedImage{
+		ImageRendition: domain.ImageRendition{
+			Name:   name,
+			Format: format,
+			Width:  m.Bounds().Dx(),
+			Height: m.Bounds().Dy(),
+			Size:   int64(buf.Len()),
+		},
+		contentType: contentType,
+		ext:         ext,
+		data:        buf.Bytes(),
+	}, nil
+}
+
+// resize scales src down so its longest side fits in side, averaging
+// the source pixels of every box. Images that already fit are kept.
+func resize(src *image.RGBA, side int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+
+	if sw <= side && sh <= side {
+		return src
+	}
+
+	dw, dh := side, sh*side/sw
+
+	if sh > sw {
+		dw, dh = sw*side/sh, side
+	}
+
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, (y+1)*sh/dh
+
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, (x+1)*sw/dw
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
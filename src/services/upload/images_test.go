@@ -0,0 +1,277 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/ZaphCode/clean-arch/src/utils"
+)
+
+func encodeTestImage(t *testing.T, m image.Image, format string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	var err error
+
+	if format == "png" {
+		err = png.Encode(buf, m)
+	} else {
+		err = jpeg.Encode(buf, m, nil)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// formats is how many formats every size is stored in, the webp
+// copies need cgo.
+func formats() int {
+	if WebP {
+		return 2
+	}
+	return 1
+}
+
+// withExif puts an exif segment right after the jpeg start marker.
+func withExif(data []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 19.4326 -99.1332")...)
+	size := len(payload) + 2
+
+	seg := append([]byte{0xFF, 0xE1, byte(size >> 8), byte(size)}, payload...)
+
+	return append(append(append([]byte{}, data[:2]...), seg...), data[2:]...)
+}
+
+func Test_renderImage(t *testing.T) {
+	photo := image.NewRGBA(image.Rect(0, 0, 2000, 1000))
+
+	for i := range photo.Pix {
+		photo.Pix[i] = 200
+	}
+
+	src := withExif(encodeTestImage(t, photo, "jpeg"))
+
+	if !bytes.Contains(src, []byte("Exif")) {
+		t.Fatal("the source should have exif")
+	}
+
+	imgs, err := renderImage(bytes.NewReader(src))
+
+	if err != nil {
+		t.Fatalf("renderImage() error = %v", err)
+	}
+
+	want := []struct {
+		name          string
+		width, height int
+	}{
+		{"thumbnail", 150, 75},
+		{"medium", 600, 300},
+		{"large", 1200, 600},
+	}
+
+	//* Every size goes as jpeg and as webp when there is cgo
+	if len(imgs) != formats()*len(want) {
+		t.Fatalf("renderImage() got %d renditions, want %d", len(imgs), formats()*len(want))
+	}
+
+	for i, w := range want {
+		ri := imgs[formats()*i]
+
+		if ri.Name != w.name || ri.Width != w.width || ri.Height != w.height {
+			t.Errorf("rendition %d = %s %dx%d, want %s %dx%d",
+				i, ri.Name, ri.Width, ri.Height, w.name, w.width, w.height)
+		}
+
+		if ri.Format != "jpeg" || ri.ext != ".jpg" {
+			t.Errorf("rendition %s format = %s, want jpeg", ri.Name, ri.Format)
+		}
+
+		if ri.Size != int64(len(ri.data)) {
+			t.Errorf("rendition %s size = %d, want %d", ri.Name, ri.Size, len(ri.data))
+		}
+
+		if bytes.Contains(ri.data, []byte("Exif")) {
+			t.Errorf("rendition %s should not have the exif", ri.Name)
+		}
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(ri.data))
+
+		if err != nil || cfg.Width != w.width || cfg.Height != w.height {
+			t.Errorf("rendition %s is not a %dx%d jpeg: %v", ri.Name, w.width, w.height, err)
+		}
+	}
+}
+
+func Test_renderImage_Transparent(t *testing.T) {
+	m := image.NewNRGBA(image.Rect(0, 0, 300, 600))
+	m.Set(0, 0, color.NRGBA{R: 255, A: 128})
+
+	imgs, err := renderImage(bytes.NewReader(encodeTestImage(t, m, "png")))
+
+	if err != nil {
+		t.Fatalf("renderImage() error = %v", err)
+	}
+
+	if imgs[0].Format != "png" || imgs[0].Width != 75 || imgs[0].Height != 150 {
+		t.Errorf("thumbnail = %s %dx%d, want png 75x150", imgs[0].Format, imgs[0].Width, imgs[0].Height)
+	}
+
+	//* Smaller images are not scaled up
+	large := imgs[2*formats()]
+
+	if large.Width != 300 || large.Height != 600 {
+		t.Errorf("large = %dx%d, want 300x600", large.Width, large.Height)
+	}
+}
+
+// withOrientation puts an exif segment with only the orientation
+// right after the jpeg start marker.
+func withOrientation(data []byte, o byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0, 42, 0, 0, 0, 8, // big endian, first IFD at 8
+		0, 1, // one entry
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, o, 0, 0, // orientation, SHORT
+		0, 0, 0, 0, // no next IFD
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+
+	seg := append([]byte{0xFF, 0xE1, byte(size >> 8), byte(size)}, payload...)
+
+	return append(append(append([]byte{}, data[:2]...), seg...), data[2:]...)
+}
+
+func Test_renderImage_Orientation(t *testing.T) {
+	//* A 400x200 photo with a red left half, as the camera stored it
+	m := image.NewRGBA(image.Rect(0, 0, 400, 200))
+
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			if x < 200 {
+				m.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				m.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	testCases := []struct {
+		orientation   byte
+		width, height int
+		red           image.Point // a point that ends up red
+	}{
+		{orientation: 1, width: 400, height: 200, red: image.Pt(50, 100)},
+		{orientation: 3, width: 400, height: 200, red: image.Pt(350, 100)},
+		{orientation: 6, width: 200, height: 400, red: image.Pt(100, 50)},
+		{orientation: 8, width: 200, height: 400, red: image.Pt(100, 350)},
+	}
+
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("orientation %d", tC.orientation), func(t *testing.T) {
+			src := withOrientation(encodeTestImage(t, m, "jpeg"), tC.orientation)
+
+			imgs, err := renderImage(bytes.NewReader(src))
+
+			if err != nil {
+				t.Fatalf("renderImage() error = %v", err)
+			}
+
+			large := imgs[2*formats()]
+
+			if large.Width != tC.width || large.Height != tC.height {
+				t.Fatalf("large = %dx%d, want %dx%d", large.Width, large.Height, tC.width, tC.height)
+			}
+
+			dec, err := jpeg.Decode(bytes.NewReader(large.data))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if r, _, b, _ := dec.At(tC.red.X, tC.red.Y).RGBA(); r < b {
+				t.Errorf("pixel %v should be red", tC.red)
+			}
+		})
+	}
+}
+
+func Test_renderImage_Limits(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "not an image",
+			data: []byte("just some text"),
+		},
+		{
+			desc: "too wide",
+			data: encodeTestImage(t, image.NewGray(image.Rect(0, 0, utils.MaxImageWidth+1, 1)), "png"),
+		},
+		{
+			desc: "too tall",
+			data: encodeTestImage(t, image.NewGray(image.Rect(0, 0, 1, utils.MaxImageHeight+1)), "png"),
+		},
+		{
+			desc: "too many pixels",
+			data: encodeTestImage(t, image.NewGray(image.Rect(0, 0, utils.MaxImageWidth, utils.MaxImagePixels/utils.MaxImageWidth+1)), "png"),
+		},
+		{
+			desc: "too big",
+			data: append(encodeTestImage(t, image.NewGray(image.Rect(0, 0, 1, 1)), "png"), make([]byte, utils.MaxImageSize)...),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := renderImage(bytes.NewReader(tC.data))
+
+			if !errors.Is(err, utils.ErrInvalidImage) {
+				t.Errorf("renderImage() error = %v, want %v", err, utils.ErrInvalidImage)
+			}
+		})
+	}
+}
+
+func Test_storeImage_PutFails(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 800, 400))); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := map[string]bool{}
+
+	put := func(name, _ string, r io.Reader) (string, error) {
+		if len(stored) == 2 {
+			return "", errors.New("storage down")
+		}
+		stored[name] = true
+		return "https://cdn.example.com/" + name, nil
+	}
+
+	remove := func(name string) error {
+		delete(stored, name)
+		return nil
+	}
+
+	if _, err := storeImage(buf, put, remove); err == nil {
+		t.Fatal("storeImage() error = nil, want the put error")
+	}
+
+	if len(stored) != 0 {
+		t.Errorf("storeImage() left %v stored", stored)
+	}
+}
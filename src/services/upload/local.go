@@ -0,0 +1,72 @@
+package upload
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
+)
+
+//* Implementation
+
+// localUploadServiceImpl keeps the files in a folder of the disk,
+// the server has to serve that folder on baseURL.
+type localUploadServiceImpl struct {
+	dir     string
+	baseURL string
+}
+
+//* Constructor
+
+func NewLocalUploadService(dir, baseURL string) UploadService {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Fatal("Error creating the upload folder: ", err)
+	}
+
+	return &localUploadServiceImpl{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+//* Methods
+
+func (s *localUploadServiceImpl) UploadImage(r io.Reader) (*domain.Image, error) {
+	return storeImage(r, s.put, s.remove)
+}
+
+func (s *localUploadServiceImpl) RemoveImage(img domain.Image) error {
+	return removeImage(img, s.remove)
+}
+
+// put writes the file, the static server sends the content type
+// by the extension.
+func (s *localUploadServiceImpl) put(name, _ string, r io.Reader) (string, error) {
+	dst, err := os.Create(filepath.Join(s.dir, name))
+
+	if err != nil {
+		return "", fmt.Errorf("os.Create: %v", err)
+	}
+
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("io.Copy: %v", err)
+	}
+
+	return s.baseURL + "/" + name, nil
+}
+
+// remove deletes the file, a file that is already gone is fine.
+func (s *localUploadServiceImpl) remove(name string) error {
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("os.Remove: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package upload
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_localUploadService_UploadImage(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewLocalUploadService(dir, "http://localhost:9000/uploads")
+
+	buf := &bytes.Buffer{}
+
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 800, 400))); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := svc.UploadImage(buf)
+
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v", err)
+	}
+
+	if len(img.Renditions) != formats()*len(renditions) {
+		t.Fatalf("UploadImage() got %d renditions, want %d", len(img.Renditions), formats()*len(renditions))
+	}
+
+	for _, r := range img.Renditions {
+		name := filepath.Base(r.URL)
+
+		if !strings.HasPrefix(name, img.ID.String()+"-"+r.Name) {
+			t.Errorf("rendition %s url = %q, want it under the image id", r.Name, r.URL)
+		}
+
+		info, err := os.Stat(filepath.Join(dir, name))
+
+		if err != nil {
+			t.Errorf("rendition %s should be on disk: %v", r.Name, err)
+			continue
+		}
+
+		if info.Size() != r.Size {
+			t.Errorf("rendition %s size = %d, want %d", r.Name, r.Size, info.Size())
+		}
+	}
+
+	if got := img.URL("medium"); !strings.HasSuffix(got, "-medium.jpg") {
+		t.Errorf("URL(medium) = %q, want the medium jpeg", got)
+	}
+}
+
+func Test_localUploadService_RemoveImage(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewLocalUploadService(dir, "http://localhost:9000/uploads")
+
+	buf := &bytes.Buffer{}
+
+	if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 800, 400))); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := svc.UploadImage(buf)
+
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v", err)
+	}
+
+	if err := svc.RemoveImage(*img); err != nil {
+		t.Fatalf("RemoveImage() error = %v", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("RemoveImage() left %d files", len(entries))
+	}
+
+	if err := svc.RemoveImage(*img); err != nil {
+		t.Errorf("RemoveImage() twice error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,139 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const orientationTag = 0x0112
+
+// jpegOrientation reads the EXIF orientation (1 to 8) of a jpeg from
+// its APP1 segment. It is 1, as stored, when there is none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+
+		marker := data[i+1]
+
+		//* The image data starts, no more metadata
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+
+		seg := data[i+4 : i+2+size]
+
+		if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return exifOrientation(seg[6:])
+		}
+
+		i += 2 + size
+	}
+
+	return 1
+}
+
+// exifOrientation looks for the orientation in the first IFD of
+// the TIFF structure the EXIF is stored in.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+
+	for e := 0; e < entries; e++ {
+		at := ifd + 2 + e*12
+
+		if at+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[at:]) != orientationTag {
+			continue
+		}
+
+		//* A SHORT, kept in the first bytes of the value
+		if o := int(order.Uint16(tiff[at+8:])); o >= 1 && o <= 8 {
+			return o
+		}
+
+		return 1
+	}
+
+	return 1
+}
+
+// orient turns the pixels as the EXIF orientation says, so the
+// image shows right without it.
+func orient(src *image.RGBA, o int) *image.RGBA {
+	if o < 2 || o > 8 {
+		return src
+	}
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := sw, sh
+
+	//* 5 to 8 are turned a quarter, so the sides swap
+	if o >= 5 {
+		dw, dh = sh, sw
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+
+			switch o {
+			case 2: // mirrored
+				sx, sy = sw-1-x, y
+			case 3: // upside down
+				sx, sy = sw-1-x, sh-1-y
+			case 4: // upside down and mirrored
+				sx, sy = x, sh-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // turned a quarter counterclockwise
+				sx, sy = y, sh-1-x
+			case 7: // transverse
+				sx, sy = sw-1-y, sh-1-x
+			case 8: // turned a quarter clockwise
+				sx, sy = sw-1-y, x
+			}
+
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
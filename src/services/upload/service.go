@@ -1,11 +1,16 @@
 package upload
 
 import (
-	"mime/multipart"
+	"io"
+
+	"github.com/ZaphCode/clean-arch/src/domain"
 )
 
 //* Service
 
 type UploadService interface {
-	Upload(multipart.File) (string, error)
+	// UploadImage stores the image re-encoded in every rendition.
+	UploadImage(io.Reader) (*domain.Image, error)
+	// RemoveImage deletes the stored renditions of the image.
+	RemoveImage(domain.Image) error
 }
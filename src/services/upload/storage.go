@@ -2,12 +2,13 @@ package upload
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 
+	gcs "cloud.google.com/go/storage"
 	"firebase.google.com/go/v4/storage"
-	"github.com/google/uuid"
+	"github.com/ZaphCode/clean-arch/src/domain"
 )
 
 //* Implementation
@@ -32,22 +33,25 @@ func NewFirebaseUploadService(
 
 //* Methods
 
-func (s *firebaseUploadServiceImpl) Upload(file multipart.File) (string, error) {
-	bucket, err := s.client.Bucket(s.bucketName)
+func (s *firebaseUploadServiceImpl) UploadImage(r io.Reader) (*domain.Image, error) {
+	return storeImage(r, s.put, s.remove)
+}
 
-	if err != nil {
-		return "", fmt.Errorf("client.Bucket() error : %w", err)
-	}
+func (s *firebaseUploadServiceImpl) RemoveImage(img domain.Image) error {
+	return removeImage(img, s.remove)
+}
 
-	id, err := uuid.NewUUID()
+func (s *firebaseUploadServiceImpl) put(name, contentType string, r io.Reader) (string, error) {
+	bucket, err := s.client.Bucket(s.bucketName)
 
 	if err != nil {
-		return "", fmt.Errorf("uuid.NewUUID() error : %w", err)
+		return "", fmt.Errorf("client.Bucket() error : %w", err)
 	}
 
-	wc := bucket.Object(s.folder + "/" + id.String()).NewWriter(context.Background())
+	wc := bucket.Object(s.folder + "/" + name).NewWriter(context.Background())
+	wc.ContentType = contentType
 
-	if _, err := io.Copy(wc, file); err != nil {
+	if _, err := io.Copy(wc, r); err != nil {
 		return "", fmt.Errorf("io.Copy: %v", err)
 	}
 	if err := wc.Close(); err != nil {
@@ -57,6 +61,23 @@ func (s *firebaseUploadServiceImpl) Upload(file multipart.File) (string, error)
 	return fmt.Sprintf(
 		"https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media",
 		s.bucketName,
-		s.folder+"%2F"+id.String(),
+		s.folder+"%2F"+name,
 	), nil
 }
+
+// remove deletes the object, an object that is already gone is fine.
+func (s *firebaseUploadServiceImpl) remove(name string) error {
+	bucket, err := s.client.Bucket(s.bucketName)
+
+	if err != nil {
+		return fmt.Errorf("client.Bucket() error : %w", err)
+	}
+
+	err = bucket.Object(s.folder + "/" + name).Delete(context.Background())
+
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return fmt.Errorf("Object.Delete: %v", err)
+	}
+
+	return nil
+}
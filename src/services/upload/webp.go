@@ -0,0 +1,20 @@
+//go:build cgo
+
+package upload
+
+import (
+	"image"
+	"io"
+
+	"github.com/chai2010/webp"
+)
+
+// WebP tells if every rendition is also stored as webp, the webp
+// encoder needs cgo.
+const WebP = true
+
+// encodeWebP makes the webp copy every rendition has, lossy like
+// the jpeg ones and keeping the transparency.
+func encodeWebP(w io.Writer, m image.Image) error {
+	return webp.Encode(w, m, &webp.Options{Quality: jpegQuality})
+}
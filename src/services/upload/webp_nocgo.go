@@ -0,0 +1,19 @@
+//go:build !cgo
+
+package upload
+
+import (
+	"errors"
+	"image"
+	"io"
+
+	_ "golang.org/x/image/webp" //* The webp uploads are still decoded
+)
+
+// WebP is off without cgo, the renditions are only stored as jpeg
+// or png.
+const WebP = false
+
+func encodeWebP(io.Writer, image.Image) error {
+	return errors.New("the webp encoder needs cgo")
+}
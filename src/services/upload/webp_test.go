@@ -0,0 +1,59 @@
+//go:build cgo
+
+package upload
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chai2010/webp"
+)
+
+func Test_renderImage_WebP(t *testing.T) {
+	m := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+	m.Set(0, 0, color.NRGBA{R: 255, A: 128})
+
+	imgs, err := renderImage(bytes.NewReader(encodeTestImage(t, m, "png")))
+
+	if err != nil {
+		t.Fatalf("renderImage() error = %v", err)
+	}
+
+	if len(imgs) != 6 {
+		t.Fatalf("renderImage() got %d renditions, want 6", len(imgs))
+	}
+
+	for i := 1; i < len(imgs); i += 2 {
+		ri := imgs[i]
+
+		if ri.Format != "webp" || ri.contentType != "image/webp" || ri.Name != imgs[i-1].Name {
+			t.Errorf("rendition %d = %s %s, want the webp of %s", i, ri.Name, ri.Format, imgs[i-1].Name)
+			continue
+		}
+
+		dec, format, err := image.Decode(bytes.NewReader(ri.data))
+
+		if err != nil || format != "webp" {
+			t.Errorf("rendition %s is not a webp: %v", ri.Name, err)
+			continue
+		}
+
+		if dec.Bounds().Dx() != ri.Width || dec.Bounds().Dy() != ri.Height {
+			t.Errorf("rendition %s webp = %dx%d, want %dx%d",
+				ri.Name, dec.Bounds().Dx(), dec.Bounds().Dy(), ri.Width, ri.Height)
+		}
+	}
+
+	//* The webp uploads are decoded too
+	src := &bytes.Buffer{}
+
+	if err := webp.Encode(src, m, &webp.Options{Lossless: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := renderImage(src); err != nil {
+		t.Errorf("renderImage() of a webp error = %v", err)
+	}
+}
@@ -1,12 +1,12 @@
 package validation
 
 import (
-	"mime/multipart"
+	"io"
 )
 
 //* Service
 
 type ValidationService interface {
 	Validate(any) error
-	ValidateFileType(f multipart.File, exts ...string) error
+	ValidateFile(name string, r io.Reader, rule FileRule) (io.Reader, error)
 }
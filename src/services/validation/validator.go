@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strings"
 
 	"github.com/ZaphCode/clean-arch/src/utils"
 	"github.com/go-playground/validator/v10"
@@ -37,18 +39,54 @@ func (s *validationServiceImpl) Validate(data any) error {
 	return nil
 }
 
-func (s *validationServiceImpl) ValidateFileType(f multipart.File, exts ...string) error {
-	bt, err := io.ReadAll(f)
-	if err != nil {
-		return err
+// ValidateFile sniffs only the header of the file and returns a reader
+// that sends it again before the rest. That reader fails with the
+// FieldError once the file goes over the max size.
+func (s *validationServiceImpl) ValidateFile(name string, r io.Reader, rule FileRule) (io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("error reading the file: %w", err)
 	}
-	mimeType := http.DetectContentType(bt) // []string{"image/png", "image/jpeg", "image/jpg"}
 
-	if ok := utils.ItemInSlice(mimeType, exts); !ok {
-		return fmt.Errorf("file type: %s is not supported", mimeType)
+	head = head[:n]
+
+	if n == 0 {
+		return nil, ValidationErrors{{rule.Field, "The file is empty"}}
 	}
 
-	return nil
+	tooBig := ValidationErrors{{rule.Field, fmt.Sprintf("Should be less than %d KB", rule.MaxSize>>10)}}
+
+	if rule.MaxSize > 0 && int64(n) > rule.MaxSize {
+		return nil, tooBig
+	}
+
+	var errs ValidationErrors
+
+	//* The magic bytes say the type, the extension has to agree
+	mimeType := http.DetectContentType(head)
+	ext := strings.ToLower(filepath.Ext(name))
+
+	if !utils.ItemInSlice(mimeType, rule.Types) {
+		errs = append(errs, FieldError{rule.Field, fmt.Sprintf("The file type %s is not supported", mimeType)})
+	} else if !utils.ItemInSlice(ext, fileExts[mimeType]) {
+		errs = append(errs, FieldError{rule.Field, fmt.Sprintf(
+			"The extension %q does not match the file type %s", ext, mimeType,
+		)})
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	rest := io.MultiReader(bytes.NewReader(head), r)
+
+	if rule.MaxSize > 0 {
+		rest = &maxSizeReader{r: rest, left: rule.MaxSize, err: tooBig}
+	}
+
+	return rest, nil
 }
 
 func (s *validationServiceImpl) getErrorMsg(fe validator.FieldError) string {
@@ -76,6 +114,51 @@ func (s *validationServiceImpl) getErrorMsg(fe validator.FieldError) string {
 
 //* Custom types
 
+// FileRule is what an uploaded file has to be.
+type FileRule struct {
+	Field   string   // form field the errors point at
+	Types   []string // mime types accepted
+	MaxSize int64    // bytes, no limit when zero
+}
+
+const sniffLen = 512 // bytes http.DetectContentType looks at
+
+// fileExts are the extensions a file of every type can have.
+var fileExts = map[string][]string{
+	"image/png":       {".png"},
+	"image/jpeg":      {".jpg", ".jpeg"},
+	"image/gif":       {".gif"},
+	"image/webp":      {".webp"},
+	"application/pdf": {".pdf"},
+}
+
+// maxSizeReader reads up to left bytes, then fails with err.
+type maxSizeReader struct {
+	r    io.Reader
+	left int64
+	err  error
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	if m.left < 0 {
+		return 0, m.err
+	}
+
+	//* One byte more to know when the file goes over
+	if int64(len(p)) > m.left+1 {
+		p = p[:m.left+1]
+	}
+
+	n, err := m.r.Read(p)
+	m.left -= int64(n)
+
+	if m.left < 0 {
+		return n + int(m.left), m.err
+	}
+
+	return n, err
+}
+
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// pngFile is a png header followed by size zeros.
+func pngFile(size int) []byte {
+	return append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), make([]byte, size)...)
+}
+
+func Test_validationService_ValidateFile(t *testing.T) {
+	svc := NewValidationService()
+	rule := FileRule{Field: "image", Types: []string{"image/png", "image/jpeg"}, MaxSize: 1024}
+
+	testCases := []struct {
+		desc    string
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{
+			desc: "valid png",
+			name: "photo.PNG",
+			data: pngFile(600),
+		},
+		{
+			desc:    "empty file",
+			name:    "photo.png",
+			data:    nil,
+			wantErr: "The file is empty",
+		},
+		{
+			desc:    "type not accepted",
+			name:    "notes.txt",
+			data:    []byte("just some text"),
+			wantErr: "The file type text/plain; charset=utf-8 is not supported",
+		},
+		{
+			desc:    "extension does not match",
+			name:    "photo.jpg",
+			data:    pngFile(0),
+			wantErr: `The extension ".jpg" does not match the file type image/png`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			r, err := svc.ValidateFile(tC.name, bytes.NewReader(tC.data), rule)
+
+			if tC.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateFile() error = %v", err)
+				}
+
+				got, err := io.ReadAll(r)
+
+				if err != nil || !bytes.Equal(got, tC.data) {
+					t.Errorf("the reader should send the whole file, got %d bytes: %v", len(got), err)
+				}
+
+				return
+			}
+
+			var verrs ValidationErrors
+
+			if !errors.As(err, &verrs) || len(verrs) != 1 {
+				t.Fatalf("ValidateFile() error = %v, want one field error", err)
+			}
+
+			if verrs[0].Field != "image" || verrs[0].Message != tC.wantErr {
+				t.Errorf("ValidateFile() error = %+v, want %q", verrs[0], tC.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validationService_ValidateFile_MaxSize(t *testing.T) {
+	svc := NewValidationService()
+	data := pngFile(2048)
+
+	r, err := svc.ValidateFile("photo.png", bytes.NewReader(data), FileRule{
+		Field: "image", Types: []string{"image/png"}, MaxSize: 1024,
+	})
+
+	if err != nil {
+		t.Fatalf("the header alone is fine, ValidateFile() error = %v", err)
+	}
+
+	got, err := io.ReadAll(r)
+
+	var verrs ValidationErrors
+
+	if !errors.As(err, &verrs) {
+		t.Fatalf("reading should fail with the field error, got %v", err)
+	}
+
+	if len(got) != 1024 {
+		t.Errorf("read %d bytes, want up to the max size", len(got))
+	}
+}
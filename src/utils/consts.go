@@ -29,21 +29,147 @@ func GetUserRoles() []string {
 //* Firestore collection names
 
 const (
-	UserColl  = "users"
-	ProdColl  = "products"
-	AddrColl  = "addresses"
-	OrderColl = "orders"
-	CategColl = "categories"
+	UserColl     = "users"
+	ProdColl     = "products"
+	AddrColl     = "addresses"
+	OrderColl    = "orders"
+	CategColl    = "categories"
+	CartColl     = "carts"
+	CouponColl   = "coupons"
+	IdemColl     = "idempotency_keys"
+	ShipColl     = "shipping_methods"
+	ShipmentColl = "shipments"
+	ReturnColl   = "returns"
+	InvoiceColl  = "invoices"
+	ReviewColl   = "reviews"
 )
 
+//* Search
+
+// SearchIndexDir keeps the product search index between restarts.
+const SearchIndexDir = "tmpdata/search.bleve"
+
+//* Uploads
+
+const (
+	UploadDir        = "tmpdata/uploads"
+	UploadRoute      = "/uploads"
+	MaxProductImages = 10
+	MaxImageSize     = 8 << 20 // bytes
+	MaxImageWidth    = 6000    // pixels
+	MaxImageHeight   = 6000    // pixels
+	MaxImagePixels   = 24_000_000
+	MaxUploadBody    = MaxImageSize + 1<<20 // bytes, the image and the rest of its form
+)
+
+// ImageTypes are the images the upload service can decode.
+var ImageTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
 //* Errors
 
 var (
-	ErrNotFound = errors.New("resource not found")
+	ErrNotFound          = errors.New("resource not found")
+	ErrOutOfStock        = errors.New("not enough stock")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrInvalidSignature  = errors.New("invalid webhook signature")
+	ErrInvalidCoupon     = errors.New("invalid coupon")
+	ErrKeyInProgress     = errors.New("idempotency key in progress")
+	ErrKeyReused         = errors.New("idempotency key reused")
+	ErrNoShipping        = errors.New("shipping not available")
+	ErrInvalidShipment   = errors.New("invalid shipment")
+	ErrInvalidReturn     = errors.New("invalid return")
+	ErrNotInvoiceable    = errors.New("order cannot be invoiced")
+	ErrInvalidFilter     = errors.New("invalid filter")
+	ErrInvalidVariant    = errors.New("invalid variant")
+	ErrNotPurchased      = errors.New("product not purchased")
+	ErrAlreadyReviewed   = errors.New("product already reviewed")
+	ErrInvalidImage      = errors.New("invalid image")
+	ErrTooManyImages     = errors.New("too many images")
+)
+
+//* Order status
+
+const (
+	StatusPending    = "pending"
+	StatusPaid       = "paid"
+	StatusProcessing = "processing"
+	StatusShipped    = "shipped"
+	StatusDelivered  = "delivered"
+	StatusCancelled  = "cancelled"
+	StatusRefunded   = "refunded"
+)
+
+func GetOrderStatuses() []string {
+	return []string{
+		StatusPending,
+		StatusPaid,
+		StatusProcessing,
+		StatusShipped,
+		StatusDelivered,
+		StatusCancelled,
+		StatusRefunded,
+	}
+}
+
+//* Money
+
+const DefaultCurrency = "usd"
+
+//* Idempotency
+
+const (
+	IdempotencyHeader = "Idempotency-Key"
+	ReplayedHeader    = "Idempotent-Replayed"
+)
+
+//* Return status
+
+const (
+	ReturnRequested = "requested"
+	ReturnApproving = "approving" // claimed while the refund is made
+	ReturnApproved  = "approved"
+	ReturnRejected  = "rejected"
+	ReturnReceived  = "received"
 )
 
+//* Coupon types
+
 const (
-	StatusPending   = "pending"
-	StatusComing    = "coming"
-	StatusCompleted = "completed"
+	CouponPercentage = "percentage"
+	CouponFixed      = "fixed"
 )
+
+func GetCouponTypes() []string {
+	return []string{CouponPercentage, CouponFixed}
+}
+
+//* Shipping rate types
+
+const (
+	ShippingFlat   = "flat"
+	ShippingWeight = "weight"
+)
+
+func GetShippingTypes() []string {
+	return []string{ShippingFlat, ShippingWeight}
+}
+
+//* Cart item issues
+
+const (
+	CartItemDeleted     = "deleted"
+	CartItemUnavailable = "unavailable"
+	CartItemOutOfStock  = "out_of_stock"
+)
+
+//* Payment events
+
+const (
+	EventPaymentSucceeded = "payment_intent.succeeded"
+	EventPaymentFailed    = "payment_intent.payment_failed"
+	EventChargeRefunded   = "charge.refunded"
+)
+
+func GetPaymentEvents() []string {
+	return []string{EventPaymentSucceeded, EventPaymentFailed, EventChargeRefunded}
+}
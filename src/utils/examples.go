@@ -97,11 +97,13 @@ var ProductExp1 = domain.Product{
 	Category:     "clothes",
 	Name:         "Black T-shirt",
 	Description:  "the best black t-shirt.",
-	Price:        2400,
+	Price:        domain.NewMoney(2400, DefaultCurrency),
 	DiscountRate: 14,
-	ImagesUrl:    []string{"https://parspng.com/wp-content/uploads/2022/07/Tshirtpng.parspng.com_.png"},
+	Images:       []domain.Image{domain.NewURLImage("https://parspng.com/wp-content/uploads/2022/07/Tshirtpng.parspng.com_.png")},
 	Tags:         []string{"clothes", "t-shirt", "black"},
 	Available:    true,
+	Stock:        30,
+	Weight:       200,
 }
 
 var ProductExp2 = domain.Product{
@@ -113,11 +115,13 @@ var ProductExp2 = domain.Product{
 	Category:     "headsets",
 	Name:         "Corsair void pro",
 	Description:  "the best headset.",
-	Price:        6000,
+	Price:        domain.NewMoney(6000, DefaultCurrency),
 	DiscountRate: 9,
-	ImagesUrl:    []string{"https://http2.mlstatic.com/D_NQ_NP_798698-MLA41021638035_032020-O.jpg"},
+	Images:       []domain.Image{domain.NewURLImage("https://http2.mlstatic.com/D_NQ_NP_798698-MLA41021638035_032020-O.jpg")},
 	Tags:         []string{"headsets", "corsair", "technology"},
 	Available:    true,
+	Stock:        12,
+	Weight:       350,
 }
 
 var ProductExpToDev1 = domain.Product{
@@ -129,13 +133,16 @@ var ProductExpToDev1 = domain.Product{
 	Category:     "tenis",
 	Name:         "Adidas Black T-Shirt Basketball",
 	Description:  "The best T-shirt in the world.",
-	Price:        2599,
+	Price:        domain.NewMoney(2599, DefaultCurrency),
 	DiscountRate: 30,
-	ImagesUrl: []string{
-		"https://titan22.com/cdn/shop/files/IR8492-A_1082x.png?v=1690430352",
-		"https://titan22.com/cdn/shop/files/IR8492-B_1082x.png?v=1690430352"},
+	Images: []domain.Image{
+		domain.NewURLImage("https://titan22.com/cdn/shop/files/IR8492-A_1082x.png?v=1690430352"),
+		domain.NewURLImage("https://titan22.com/cdn/shop/files/IR8492-B_1082x.png?v=1690430352"),
+	},
 	Tags:      []string{"t-shirts", "clothes", "Adidas"},
 	Available: true,
+	Stock:     50,
+	Weight:    220,
 }
 
 var ProductExpToDev2 = domain.Product{
@@ -147,14 +154,16 @@ var ProductExpToDev2 = domain.Product{
 	Category:     "clothes",
 	Name:         "Nike Black Cup",
 	Description:  "The best cup. Super comfortable.",
-	Price:        1549,
+	Price:        domain.NewMoney(1549, DefaultCurrency),
 	DiscountRate: 0,
-	ImagesUrl: []string{
-		"https://static.nike.com/a/images/t_default/84588c76-14b7-42cb-a65c-5bbb77f6699d/gorra-estructurada-con-cierre-a-presi%C3%B3n-dri-fit-rise-hR0Mq4.png",
-		"https://static.nike.com/a/images/t_PDP_1728_v1/f_auto,q_auto:eco/ad1dbe01-7a5a-4862-bdd6-416e3a120703/gorra-estructurada-con-cierre-a-presi%C3%B3n-dri-fit-rise-hR0Mq4.png",
+	Images: []domain.Image{
+		domain.NewURLImage("https://static.nike.com/a/images/t_default/84588c76-14b7-42cb-a65c-5bbb77f6699d/gorra-estructurada-con-cierre-a-presi%C3%B3n-dri-fit-rise-hR0Mq4.png"),
+		domain.NewURLImage("https://static.nike.com/a/images/t_PDP_1728_v1/f_auto,q_auto:eco/ad1dbe01-7a5a-4862-bdd6-416e3a120703/gorra-estructurada-con-cierre-a-presi%C3%B3n-dri-fit-rise-hR0Mq4.png"),
 	},
 	Tags:      []string{"cups", "clothes", "Nike"},
 	Available: true,
+	Stock:     40,
+	Weight:    120,
 }
 
 var ProductExpToDev3 = domain.Product{
@@ -166,14 +175,16 @@ var ProductExpToDev3 = domain.Product{
 	Category:     "tenis",
 	Name:         "Running Tenis Puma Black",
 	Description:  "Very comfortable shoes for running.",
-	Price:        1530,
+	Price:        domain.NewMoney(1530, DefaultCurrency),
 	DiscountRate: 10,
-	ImagesUrl: []string{
-		"https://martimx.vtexassets.com/arquivos/ids/489205-800-800?v=637346702472670000&width=800&height=800&aspect=true",
-		"https://martimx.vtexassets.com/arquivos/ids/489294-800-800?v=637346703167700000&width=800&height=800&aspect=true",
+	Images: []domain.Image{
+		domain.NewURLImage("https://martimx.vtexassets.com/arquivos/ids/489205-800-800?v=637346702472670000&width=800&height=800&aspect=true"),
+		domain.NewURLImage("https://martimx.vtexassets.com/arquivos/ids/489294-800-800?v=637346703167700000&width=800&height=800&aspect=true"),
 	},
 	Tags:      []string{"shoes", "clothes", "puma"},
 	Available: true,
+	Stock:     25,
+	Weight:    800,
 }
 
 //* Addresses
@@ -209,3 +220,237 @@ var AddrExp2 = domain.Address{
 	Line2:      "Calle Pargo",
 	State:      "Baja California Sur",
 }
+
+//* Orders
+
+var OrderExp1 = domain.Order{
+	Model: domain.Model{
+		ID:        uuid.MustParse("63639c2c-4f67-11ef-928b-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	UserID:    UserExp1.ID,
+	AddressID: AddrExp1.ID,
+	Amount:    domain.NewMoney(2064, DefaultCurrency),
+	Status:    StatusPending,
+	Paid:      false,
+	Products: []domain.OrderProduct{
+		{
+			ID:           ProductExp1.ID,
+			Quantity:     1,
+			Name:         ProductExp1.Name,
+			UnitPrice:    ProductExp1.Price,
+			DiscountRate: ProductExp1.DiscountRate,
+			LineTotal:    domain.NewMoney(2064, DefaultCurrency),
+		},
+	},
+	StatusHistory: []domain.StatusChange{
+		{Status: StatusPending, ChangedAt: time.Now().Unix()},
+	},
+}
+
+var OrderExp2 = domain.Order{
+	Model: domain.Model{
+		ID:        uuid.MustParse("a9c41a68-484e-11ef-9903-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	UserID:    UserExp1.ID,
+	AddressID: AddrExp2.ID,
+	Amount:    domain.NewMoney(1819, DefaultCurrency),
+	Status:    StatusPending,
+	Paid:      false,
+	Products: []domain.OrderProduct{
+		{
+			ID:           ProductExpToDev1.ID,
+			Quantity:     1,
+			Name:         ProductExpToDev1.Name,
+			UnitPrice:    ProductExpToDev1.Price,
+			DiscountRate: ProductExpToDev1.DiscountRate,
+			LineTotal:    domain.NewMoney(1819, DefaultCurrency),
+		},
+	},
+	StatusHistory: []domain.StatusChange{
+		{Status: StatusPending, ChangedAt: time.Now().Unix()},
+	},
+}
+
+var OrderExp3 = domain.Order{
+	Model: domain.Model{
+		ID:        uuid.MustParse("c2d7e4b0-5a21-11ef-8f3c-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	UserID:          UserExp1.ID,
+	AddressID:       AddrExp1.ID,
+	PaymentIntentID: "pi_3NKQexG8UXDxPRba0d7cVcUE",
+	Amount:          domain.NewMoney(2064, DefaultCurrency),
+	Status:          StatusPending,
+	Paid:            false,
+	Products: []domain.OrderProduct{
+		{
+			ID:           ProductExp1.ID,
+			Quantity:     1,
+			Name:         ProductExp1.Name,
+			UnitPrice:    ProductExp1.Price,
+			DiscountRate: ProductExp1.DiscountRate,
+			LineTotal:    domain.NewMoney(2064, DefaultCurrency),
+		},
+	},
+	StatusHistory: []domain.StatusChange{
+		{Status: StatusPending, ChangedAt: time.Now().Unix()},
+	},
+}
+
+var OrderExp4 = domain.Order{
+	Model: domain.Model{
+		ID:        uuid.MustParse("e7f3a1c6-7c2e-11ef-b5a4-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	UserID:          UserExp1.ID,
+	AddressID:       AddrExp1.ID,
+	PaymentIntentID: "pi_3NKRf2G8UXDxPRba1KqYpA4t",
+	Amount:          domain.NewMoney(6447, DefaultCurrency),
+	RefundedAmount:  domain.NewMoney(0, DefaultCurrency),
+	ShippingMethod:  "standard",
+	ShippingCost:    domain.NewMoney(500, DefaultCurrency),
+	Status:          StatusPaid,
+	Paid:            true,
+	Products: []domain.OrderProduct{
+		{
+			ID:           ProductExp1.ID,
+			Quantity:     2,
+			Name:         ProductExp1.Name,
+			Category:     ProductExp1.Category,
+			UnitPrice:    ProductExp1.Price,
+			DiscountRate: ProductExp1.DiscountRate,
+			LineTotal:    domain.NewMoney(4128, DefaultCurrency),
+		},
+		{
+			ID:           ProductExpToDev1.ID,
+			Quantity:     1,
+			Name:         ProductExpToDev1.Name,
+			Category:     ProductExpToDev1.Category,
+			UnitPrice:    ProductExpToDev1.Price,
+			DiscountRate: ProductExpToDev1.DiscountRate,
+			LineTotal:    domain.NewMoney(1819, DefaultCurrency),
+		},
+	},
+	StatusHistory: []domain.StatusChange{
+		{Status: StatusPending, ChangedAt: time.Now().Unix()},
+		{Status: StatusPaid, ChangedAt: time.Now().Unix()},
+	},
+}
+
+var OrderExp5 = domain.Order{
+	Model: domain.Model{
+		ID:        uuid.MustParse("5b0d2f84-8a41-11ef-9c3e-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	UserID:          UserExp1.ID,
+	AddressID:       AddrExp1.ID,
+	PaymentIntentID: "pi_3NLa8kG8UXDxPRba0Wm2Qe7b",
+	Amount:          domain.NewMoney(6447, DefaultCurrency),
+	RefundedAmount:  domain.NewMoney(0, DefaultCurrency),
+	ShippingMethod:  "standard",
+	ShippingCost:    domain.NewMoney(500, DefaultCurrency),
+	Status:          StatusDelivered,
+	Paid:            true,
+	Products: []domain.OrderProduct{
+		{
+			ID:           ProductExp1.ID,
+			Quantity:     2,
+			Name:         ProductExp1.Name,
+			Category:     ProductExp1.Category,
+			UnitPrice:    ProductExp1.Price,
+			DiscountRate: ProductExp1.DiscountRate,
+			LineTotal:    domain.NewMoney(4128, DefaultCurrency),
+		},
+		{
+			ID:           ProductExpToDev1.ID,
+			Quantity:     1,
+			Name:         ProductExpToDev1.Name,
+			Category:     ProductExpToDev1.Category,
+			UnitPrice:    ProductExpToDev1.Price,
+			DiscountRate: ProductExpToDev1.DiscountRate,
+			LineTotal:    domain.NewMoney(1819, DefaultCurrency),
+		},
+	},
+	StatusHistory: []domain.StatusChange{
+		{Status: StatusPending, ChangedAt: time.Now().Unix()},
+		{Status: StatusPaid, ChangedAt: time.Now().Unix()},
+		{Status: StatusProcessing, ChangedAt: time.Now().Unix()},
+		{Status: StatusShipped, ChangedAt: time.Now().Unix()},
+		{Status: StatusDelivered, ChangedAt: time.Now().Unix()},
+	},
+}
+
+//* Coupons
+
+var CouponExp1 = domain.Coupon{
+	Model: domain.Model{
+		ID:        uuid.MustParse("5b1f0c9e-6e3a-11ef-9a41-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	Code:           "WELCOME10",
+	Type:           CouponPercentage,
+	Rate:           10,
+	MaxUsesPerUser: 1,
+	UsesByUser:     map[string]int64{},
+	Active:         true,
+}
+
+var CouponExp2 = domain.Coupon{
+	Model: domain.Model{
+		ID:        uuid.MustParse("8d2e4f6a-6e3a-11ef-9a41-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	Code:           "TENIS5",
+	Type:           CouponFixed,
+	Amount:         domain.NewMoney(500, DefaultCurrency),
+	MinOrderAmount: domain.NewMoney(2000, DefaultCurrency),
+	MaxUses:        100,
+	UsesByUser:     map[string]int64{},
+	Categories:     []string{"tenis"},
+	Active:         true,
+}
+
+//* Shipping methods
+
+var ShippingExp1 = domain.ShippingMethod{
+	Model: domain.Model{
+		ID:        uuid.MustParse("c41a7e52-7b1d-11ef-8f3e-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	Code: "standard",
+	Name: "Standard shipping",
+	Type: ShippingFlat,
+	Rates: []domain.ShippingRate{
+		{Country: "USA", Price: domain.NewMoney(500, DefaultCurrency)},
+		{Country: "Mexico", Price: domain.NewMoney(800, DefaultCurrency)},
+		{Price: domain.NewMoney(1500, DefaultCurrency)},
+	},
+	Active: true,
+}
+
+var ShippingExp2 = domain.ShippingMethod{
+	Model: domain.Model{
+		ID:        uuid.MustParse("d9b3c2a4-7b1d-11ef-8f3e-5e7be0b361c5"),
+		CreatedAt: time.Now().Unix(),
+		UpdatedAt: time.Now().Unix(),
+	},
+	Code: "express",
+	Name: "Express shipping",
+	Type: ShippingWeight,
+	Rates: []domain.ShippingRate{
+		{Country: "USA", MaxWeight: 1000, Price: domain.NewMoney(1200, DefaultCurrency)},
+		{Country: "USA", MaxWeight: 5000, Price: domain.NewMoney(2000, DefaultCurrency)},
+		{Country: "USA", State: "Washintong", MaxWeight: 1000, Price: domain.NewMoney(900, DefaultCurrency)},
+	},
+	Active: true,
+}
@@ -116,7 +116,7 @@ func (m *SyncMap[K, V]) Get(key K) (V, error) {
 	var zero V
 
 	if !m.exists(key) {
-		return zero, fmt.Errorf("data not found")
+		return zero, ErrNotFound
 	}
 
 	return m.smap[key], nil
@@ -168,6 +168,51 @@ func (m *SyncMap[K, V]) Update(key K, newval V) error {
 	return nil
 }
 
+// UpdateMany applies every update fn under the same lock.
+// If any key is missing or any fn fails nothing is written.
+func (m *SyncMap[K, V]) UpdateMany(fns map[K]func(V) (V, error)) error {
+	return m.updateMany(fns, false)
+}
+
+// UpdateExisting is UpdateMany skipping the keys that are missing.
+func (m *SyncMap[K, V]) UpdateExisting(fns map[K]func(V) (V, error)) error {
+	return m.updateMany(fns, true)
+}
+
+func (m *SyncMap[K, V]) updateMany(fns map[K]func(V) (V, error), skipMissing bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := make(map[K]V, len(fns))
+
+	for key, fn := range fns {
+		if !m.exists(key) {
+			if skipMissing {
+				continue
+			}
+			return fmt.Errorf("%w: data doesn't exist", ErrNotFound)
+		}
+
+		val, err := fn(m.smap[key])
+
+		if err != nil {
+			return err
+		}
+
+		updated[key] = val
+	}
+
+	for key, val := range updated {
+		m.smap[key] = val
+	}
+
+	if m.datafile != "" {
+		m.saveToFile()
+	}
+
+	return nil
+}
+
 func (m *SyncMap[K, V]) Count() int {
 	return len(m.smap)
 }
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -206,3 +207,60 @@ func (s *SyncMapSuite) TestRemove() {
 		})
 	}
 }
+
+func (s *SyncMapSuite) TestUpdateMany() {
+	rename := func(name string) func(ExampleModel) (ExampleModel, error) {
+		return func(m ExampleModel) (ExampleModel, error) {
+			m.Name = name
+			return m, nil
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		input    map[uuid.UUID]func(ExampleModel) (ExampleModel, error)
+		wantErr  bool
+		wantName string
+	}{
+		{
+			desc: "Error because one does'nt exist",
+			input: map[uuid.UUID]func(ExampleModel) (ExampleModel, error){
+				m1.ID:      rename("model 1 updated"),
+				uuid.New(): rename("ghost"),
+			},
+			wantErr:  true,
+			wantName: m1.Name,
+		},
+		{
+			desc: "Error because one update fails",
+			input: map[uuid.UUID]func(ExampleModel) (ExampleModel, error){
+				m1.ID: rename("model 1 updated"),
+				m2.ID: func(m ExampleModel) (ExampleModel, error) {
+					return m, fmt.Errorf("nope")
+				},
+			},
+			wantErr:  true,
+			wantName: m1.Name,
+		},
+		{
+			desc: "Update properly",
+			input: map[uuid.UUID]func(ExampleModel) (ExampleModel, error){
+				m1.ID: rename("model 1 updated"),
+				m2.ID: rename("model 2 updated"),
+			},
+			wantErr:  false,
+			wantName: "model 1 updated",
+		},
+	}
+	for _, tC := range testCases {
+		s.Run(tC.desc, func() {
+			err := s.syncMap.UpdateMany(tC.input)
+			s.Equal(tC.wantErr, err != nil, "wrong result")
+
+			got, err := s.syncMap.Get(m1.ID)
+			s.NoError(err, "should exists")
+
+			s.Equal(tC.wantName, got.Name, "wrong name")
+		})
+	}
+}